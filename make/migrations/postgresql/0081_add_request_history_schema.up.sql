@@ -0,0 +1,16 @@
+ALTER TABLE request
+    ADD COLUMN IF NOT EXISTS comment text;
+
+CREATE TABLE IF NOT EXISTS request_history
+(
+    id            SERIAL PRIMARY KEY NOT NULL,
+    request_id    int                NOT NULL,
+    from_state    int                NOT NULL,
+    to_state      int                NOT NULL,
+    operator      varchar(255),
+    comment       text,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    FOREIGN KEY (request_id) REFERENCES request (request_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_history_request_id ON request_history (request_id);
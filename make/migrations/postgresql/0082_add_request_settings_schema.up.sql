@@ -0,0 +1,6 @@
+ALTER TABLE request
+    ADD COLUMN IF NOT EXISTS registry_id int NOT NULL Default 0;
+ALTER TABLE request
+    ADD COLUMN IF NOT EXISTS metadata text;
+ALTER TABLE request
+    ADD COLUMN IF NOT EXISTS members text;
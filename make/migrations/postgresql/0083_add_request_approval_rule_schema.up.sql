@@ -0,0 +1,2 @@
+ALTER TABLE request_history
+    ADD COLUMN IF NOT EXISTS rule varchar(255);
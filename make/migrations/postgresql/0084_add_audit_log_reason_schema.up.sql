@@ -0,0 +1,2 @@
+ALTER TABLE audit_log
+    ADD COLUMN IF NOT EXISTS reason text;
@@ -0,0 +1,8 @@
+ALTER TABLE audit_log
+    ADD COLUMN IF NOT EXISTS client_ip varchar(64),
+    ADD COLUMN IF NOT EXISTS user_agent text,
+    ADD COLUMN IF NOT EXISTS request_id text,
+    ADD COLUMN IF NOT EXISTS auth_method varchar(32),
+    ADD COLUMN IF NOT EXISTS success boolean DEFAULT true;
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_request_id ON audit_log (request_id);
@@ -0,0 +1,16 @@
+/* artifact_accessory records the accessories(cosign signature, attestation, SBOM) attached to the subject artifact */
+CREATE TABLE IF NOT EXISTS artifact_accessory
+(
+    id                  SERIAL PRIMARY KEY NOT NULL,
+    artifact_id         int                NOT NULL,
+    subject_artifact_id int                NOT NULL,
+    type                varchar(256)       NOT NULL,
+    size                bigint,
+    digest              varchar(1024),
+    creation_time       timestamp default CURRENT_TIMESTAMP,
+    FOREIGN KEY (artifact_id) REFERENCES artifact (id) ON DELETE CASCADE,
+    FOREIGN KEY (subject_artifact_id) REFERENCES artifact (id) ON DELETE CASCADE,
+    CONSTRAINT unique_artifact_accessory UNIQUE (artifact_id, subject_artifact_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_artifact_accessory_subject_artifact_id ON artifact_accessory (subject_artifact_id);
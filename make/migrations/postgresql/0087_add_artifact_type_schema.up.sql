@@ -0,0 +1,2 @@
+/* artifact_type records the OCI artifactType of the manifest, falls back to the media type of the config */
+ALTER TABLE artifact ADD COLUMN IF NOT EXISTS artifact_type varchar(255);
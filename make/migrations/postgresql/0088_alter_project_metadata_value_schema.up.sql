@@ -0,0 +1,2 @@
+/* the value of project metadata may contain the PEM encoded cosign public keys which exceed 255 characters */
+ALTER TABLE project_metadata ALTER COLUMN value TYPE text;
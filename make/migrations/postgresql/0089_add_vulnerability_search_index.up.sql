@@ -0,0 +1,6 @@
+/* indexes to search the artifacts by the CVE, package and severity of the vulnerabilities */
+CREATE INDEX IF NOT EXISTS idx_vulnerability_record_cve_id ON vulnerability_record (cve_id);
+CREATE INDEX IF NOT EXISTS idx_vulnerability_record_package ON vulnerability_record (package, package_version);
+CREATE INDEX IF NOT EXISTS idx_vulnerability_record_severity ON vulnerability_record (severity);
+CREATE INDEX IF NOT EXISTS idx_report_vulnerability_record_vuln_record_id ON report_vulnerability_record (vuln_record_id);
+CREATE INDEX IF NOT EXISTS idx_scan_report_digest ON scan_report (digest);
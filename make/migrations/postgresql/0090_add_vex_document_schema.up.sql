@@ -0,0 +1,16 @@
+/* the VEX documents uploaded for the repositories or artifacts, the digest is empty when the document applies to the whole repository */
+CREATE TABLE IF NOT EXISTS vex_document (
+    id SERIAL PRIMARY KEY NOT NULL,
+    project_id int NOT NULL,
+    repository_id int NOT NULL,
+    repository_name varchar(255) NOT NULL,
+    digest varchar(255) NOT NULL DEFAULT '',
+    format varchar(32) NOT NULL,
+    statements text,
+    content text NOT NULL,
+    creator varchar(255),
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT fk_vex_document_repository_id FOREIGN KEY (repository_id) REFERENCES repository(repository_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_vex_document_repository_digest ON vex_document (repository_name, digest);
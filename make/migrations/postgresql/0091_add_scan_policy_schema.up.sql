@@ -0,0 +1,18 @@
+/* the per-project policies to re-scan the artifacts whose scan reports are stale */
+CREATE TABLE IF NOT EXISTS scan_policy (
+    id SERIAL PRIMARY KEY NOT NULL,
+    project_id int NOT NULL,
+    enabled boolean NOT NULL DEFAULT true,
+    cron varchar(64),
+    stale_days int NOT NULL DEFAULT 0,
+    outdated_scanner boolean NOT NULL DEFAULT false,
+    pulled_within_days int NOT NULL DEFAULT 0,
+    max_concurrent_jobs int NOT NULL DEFAULT 1,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    update_time timestamp default CURRENT_TIMESTAMP,
+    UNIQUE (project_id),
+    CONSTRAINT fk_scan_policy_project_id FOREIGN KEY (project_id) REFERENCES project(project_id) ON DELETE CASCADE
+);
+
+/* used to select the recently pulled artifacts of the project to re-scan */
+CREATE INDEX IF NOT EXISTS idx_artifact_project_id_pull_time ON artifact (project_id, pull_time);
@@ -0,0 +1,6 @@
+/* the speed limit shared by all the replication tasks whose destination is the registry */
+ALTER TABLE registry ADD COLUMN IF NOT EXISTS speed_kb int NOT NULL DEFAULT 0;
+
+/* the JSON arrays of the time windows of the day which override the speed limits */
+ALTER TABLE registry ADD COLUMN IF NOT EXISTS bandwidth_schedule text;
+ALTER TABLE replication_policy ADD COLUMN IF NOT EXISTS bandwidth_schedule text;
@@ -0,0 +1,2 @@
+/* the JSON array of the rules which rewrite the names of the destination repositories and tags */
+ALTER TABLE replication_policy ADD COLUMN IF NOT EXISTS rewrite_rules text;
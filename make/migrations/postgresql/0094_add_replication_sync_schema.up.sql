@@ -0,0 +1,4 @@
+/* reconcile the resources between the source and destination registries in both directions */
+ALTER TABLE replication_policy ADD COLUMN IF NOT EXISTS sync boolean NOT NULL DEFAULT false;
+/* how to resolve the tags pointing to different digests on the two registries: newest, prefer_source or report_only */
+ALTER TABLE replication_policy ADD COLUMN IF NOT EXISTS conflict_resolution varchar(32);
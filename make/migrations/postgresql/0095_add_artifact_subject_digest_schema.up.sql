@@ -0,0 +1,3 @@
+/* subject_digest records the digest of the subject declared in the manifest, the referrers pushed before their subjects are linked by it later */
+ALTER TABLE artifact ADD COLUMN IF NOT EXISTS subject_digest varchar(255);
+CREATE INDEX IF NOT EXISTS idx_artifact_subject_digest ON artifact (repository_name, subject_digest);
@@ -157,11 +157,31 @@ const (
 
 	// Global notification enable configuration
 	NotificationEnable = "notification_enable"
+	// NotificationEmailLanguage the language of the templates used by the email notification
+	NotificationEmailLanguage = "notification_email_language"
+	// NotificationEmailTemplates the templates customized by admin for the email notification
+	NotificationEmailTemplates = "notification_email_templates"
 
 	// Quota setting items for project
 	QuotaPerProjectEnable = "quota_per_project_enable"
 	StoragePerProject     = "storage_per_project"
 
+	// ProjectRequestTTL the hours a pending project request lives before it's expired
+	ProjectRequestTTL = "project_request_ttl"
+	// ProjectRequestApprovalRules the rules to approve the project requests automatically
+	ProjectRequestApprovalRules = "project_request_approval_rules"
+
+	// AuditLogForwardEndpoint the endpoint of the syslog server or the HTTP endpoint to which the audit logs are forwarded
+	AuditLogForwardEndpoint = "audit_log_forward_endpoint"
+	// AuditLogForwardInsecure skip the certificate verification when forwarding the audit logs over TLS
+	AuditLogForwardInsecure = "audit_log_forward_insecure"
+	// SkipAuditLogDatabase skip persisting the audit logs into database when they're forwarded
+	SkipAuditLogDatabase = "skip_audit_log_database"
+	// AuditLogForwardBufferDir the directory to buffer the audit logs waiting to be forwarded
+	AuditLogForwardBufferDir = "audit_log_forward_buffer_dir"
+	// AuditLogForwardBufferSize the max size in bytes of the buffered audit logs
+	AuditLogForwardBufferSize = "audit_log_forward_buffer_size"
+
 	// DefaultGCTimeWindowHours is the reserve blob time window used by GC, default is 2 hours
 	DefaultGCTimeWindowHours = int64(2)
 
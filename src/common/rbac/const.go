@@ -71,6 +71,7 @@ const (
 	ResourceReplication        = Resource("replication")
 	ResourceDistribution       = Resource("distribution")
 	ResourceGarbageCollection  = Resource("garbage-collection")
+	ResourcePurgeAuditLog      = Resource("purge-audit")
 	ResourceReplicationAdapter = Resource("replication-adapter")
 	ResourceReplicationPolicy  = Resource("replication-policy")
 	ResourceScanAll            = Resource("scan-all")
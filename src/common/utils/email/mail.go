@@ -15,10 +15,15 @@
 package email
 
 import (
+	"bytes"
 	tlspkg "crypto/tls"
 	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
 	"net"
 	"net/smtp"
+	"net/textproto"
 	"strings"
 	"time"
 
@@ -29,7 +34,29 @@ import (
 func Send(addr, identity, username, password string,
 	timeout int, tls, insecure bool, from string,
 	to []string, subject, message string) error {
+	template := "From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-version: 1.0;\r\nContent-Type: text/html; charset=\"UTF-8\"\r\n\n%s\r\n"
+	data := fmt.Sprintf(template, from,
+		strings.Join(to, ","), subject, message)
+
+	return send(addr, identity, username, password, timeout, tls, insecure, from, to, []byte(data))
+}
+
+// SendMultipart sends the email which contains both the plain text and the HTML parts,
+// the mail clients display the HTML part when they support it
+func SendMultipart(addr, identity, username, password string,
+	timeout int, tls, insecure bool, from string,
+	to []string, subject, text, html string) error {
+	data, err := multipartMessage(from, to, subject, text, html)
+	if err != nil {
+		return err
+	}
+
+	return send(addr, identity, username, password, timeout, tls, insecure, from, to, data)
+}
 
+func send(addr, identity, username, password string,
+	timeout int, tls, insecure bool, from string,
+	to []string, data []byte) error {
 	client, err := newClient(addr, identity, username,
 		password, timeout, tls, insecure)
 	if err != nil {
@@ -52,11 +79,7 @@ func Send(addr, identity, username, password string,
 		return err
 	}
 
-	template := "From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-version: 1.0;\r\nContent-Type: text/html; charset=\"UTF-8\"\r\n\n%s\r\n"
-	data := fmt.Sprintf(template, from,
-		strings.Join(to, ","), subject, message)
-
-	_, err = w.Write([]byte(data))
+	_, err = w.Write(data)
 	if err != nil {
 		return err
 	}
@@ -69,6 +92,51 @@ func Send(addr, identity, username, password string,
 	return client.Quit()
 }
 
+// multipartMessage builds the multipart/alternative message, the subject is encoded
+// to support the non-ASCII characters, e.g. Korean
+func multipartMessage(from string, to []string, subject, text, html string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	writer := multipart.NewWriter(buf)
+
+	fmt.Fprintf(buf, "From: %s\r\n", from)
+	fmt.Fprintf(buf, "To: %s\r\n", strings.Join(to, ","))
+	fmt.Fprintf(buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", writer.Boundary())
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{contentType: "text/plain; charset=\"UTF-8\"", content: text},
+		{contentType: "text/html; charset=\"UTF-8\"", content: html},
+	}
+	for _, p := range parts {
+		if len(p.content) == 0 {
+			continue
+		}
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qw := quotedprintable.NewWriter(w)
+		if _, err := qw.Write([]byte(p.content)); err != nil {
+			return nil, err
+		}
+		if err := qw.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // Ping tests the connection and authentication with email server
 // If tls is true, a secure connection is established, or Ping
 // trys to upgrate the insecure connection to a secure one if
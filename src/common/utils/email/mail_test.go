@@ -17,7 +17,10 @@ package email
 import (
 	"strings"
 	"testing"
-	//	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/testing/smtp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSend(t *testing.T) {
@@ -66,6 +69,31 @@ func TestSend(t *testing.T) {
 	}
 }
 
+func TestSendMultipart(t *testing.T) {
+	server, err := smtp.NewServer()
+	require.Nil(t, err)
+	defer server.Close()
+
+	err = SendMultipart(server.Addr(), "", "", "", 5, false, false,
+		"harbor@example.com", []string{"dev@example.com", "qa@example.com"},
+		"프로젝트 요청", "plain text", "<b>html</b>")
+	require.Nil(t, err)
+
+	messages := server.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "harbor@example.com", messages[0].From)
+	assert.Equal(t, []string{"dev@example.com", "qa@example.com"}, messages[0].To)
+	assert.Contains(t, messages[0].Data, "Content-Type: multipart/alternative")
+	assert.Contains(t, messages[0].Data, "Subject: =?UTF-8?b?")
+	assert.Contains(t, messages[0].Data, "plain text")
+	assert.Contains(t, messages[0].Data, "<b>html</b>")
+
+	server.DataReplyCode = 554
+	err = SendMultipart(server.Addr(), "", "", "", 5, false, false,
+		"harbor@example.com", []string{"dev@example.com"}, "subject", "text", "")
+	assert.NotNil(t, err)
+}
+
 func TestPing(t *testing.T) {
 	addr := "smtp.gmail.com:465"
 	identity := ""
@@ -131,6 +131,16 @@ func (a *abstractor) abstractManifestV2Metadata(artifact *artifact.Artifact, con
 	}
 	// set annotations
 	artifact.Annotations = manifest.Annotations
+	// set the artifact type and subject defined in OCI 1.1
+	refer := &referrer{}
+	if err := json.Unmarshal(content, refer); err != nil {
+		return err
+	}
+	artifact.ArtifactType = refer.ArtifactType
+	if len(artifact.ArtifactType) == 0 {
+		artifact.ArtifactType = manifest.Config.MediaType
+	}
+	artifact.Subject = refer.Subject
 	return nil
 }
 
@@ -148,6 +158,14 @@ func (a *abstractor) abstractIndexMetadata(ctx context.Context, art *artifact.Ar
 	// set annotations
 	art.Annotations = index.Annotations
 
+	// set the artifact type and subject defined in OCI 1.1
+	refer := &referrer{}
+	if err := json.Unmarshal(content, refer); err != nil {
+		return err
+	}
+	art.ArtifactType = refer.ArtifactType
+	art.Subject = refer.Subject
+
 	art.Size += int64(len(content))
 	// populate the referenced artifacts
 	for _, mani := range index.Manifests {
@@ -178,3 +196,10 @@ func (a *abstractor) abstractIndexMetadata(ctx context.Context, art *artifact.Ar
 
 	return nil
 }
+
+// referrer contains the OCI 1.1 fields of manifest/index which aren't
+// covered by the image spec vendored
+type referrer struct {
+	ArtifactType string         `json:"artifactType,omitempty"`
+	Subject      *v1.Descriptor `json:"subject,omitempty"`
+}
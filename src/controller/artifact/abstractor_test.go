@@ -27,6 +27,7 @@ import (
 	"github.com/goharbor/harbor/src/testing/pkg/registry"
 
 	"github.com/docker/distribution"
+	_ "github.com/docker/distribution/manifest/ocischema"
 	"github.com/docker/distribution/manifest/schema1"
 	"github.com/docker/distribution/manifest/schema2"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
@@ -176,6 +177,29 @@ var (
   }
 }`
 
+	ociReferrer = `{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "artifactType": "application/vnd.example.sbom.v1",
+  "config": {
+    "mediaType": "application/vnd.docker.container.image.v1+json",
+    "size": 2,
+    "digest": "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"
+  },
+  "layers": [
+    {
+      "mediaType": "application/vnd.example.sbom.v1+json",
+      "size": 100,
+      "digest": "sha256:1b930d010525941c1d56ec53b97bd057a67ae1865eebf042686d2a2d18271ced"
+    }
+  ],
+  "subject": {
+    "mediaType": "application/vnd.oci.image.manifest.v1+json",
+    "size": 7143,
+    "digest": "sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f"
+  }
+}`
+
 	index = `{
   "schemaVersion": 2,
   "manifests": [
@@ -265,6 +289,26 @@ func (a *abstractorTestSuite) TestAbstractMetadataOfV2Manifest() {
 	a.Assert().Equal(int64(3043), artifact.Size)
 	a.Require().Len(artifact.Annotations, 1)
 	a.Equal("value1", artifact.Annotations["com.example.key1"])
+	a.Equal(schema2.MediaTypeImageConfig, artifact.ArtifactType)
+	a.Nil(artifact.Subject)
+}
+
+// OCI manifest with the artifact type and subject
+func (a *abstractorTestSuite) TestAbstractMetadataOfReferrer() {
+	manifest, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, []byte(ociReferrer))
+	a.Require().Nil(err)
+	a.regCli.On("PullManifest").Return(manifest, "", nil)
+	artifact := &artifact.Artifact{
+		ID: 1,
+	}
+	a.processor.On("AbstractMetadata", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	err = a.abstractor.AbstractMetadata(nil, artifact)
+	a.Require().Nil(err)
+	a.Equal(v1.MediaTypeImageManifest, artifact.ManifestMediaType)
+	a.Equal(schema2.MediaTypeImageConfig, artifact.MediaType)
+	a.Equal("application/vnd.example.sbom.v1", artifact.ArtifactType)
+	a.Require().NotNil(artifact.Subject)
+	a.Equal("sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f", artifact.Subject.Digest.String())
 }
 
 // OCI index
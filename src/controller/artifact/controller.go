@@ -25,6 +25,10 @@ import (
 	"github.com/goharbor/harbor/src/controller/artifact/processor/chart"
 	"github.com/goharbor/harbor/src/controller/artifact/processor/cnab"
 	"github.com/goharbor/harbor/src/controller/artifact/processor/image"
+	// register the processors of WASM, OPA and SIF artifacts
+	_ "github.com/goharbor/harbor/src/controller/artifact/processor/opa"
+	_ "github.com/goharbor/harbor/src/controller/artifact/processor/sif"
+	_ "github.com/goharbor/harbor/src/controller/artifact/processor/wasm"
 	"github.com/goharbor/harbor/src/lib/icon"
 
 	"github.com/goharbor/harbor/src/controller/artifact/processor"
@@ -35,6 +39,8 @@ import (
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
 	"github.com/goharbor/harbor/src/pkg/artifact"
 	"github.com/goharbor/harbor/src/pkg/artifactrash"
 	"github.com/goharbor/harbor/src/pkg/artifactrash/model"
@@ -79,17 +85,17 @@ type Controller interface {
 	// The "created" will be set as true when the artifact is created
 	Ensure(ctx context.Context, repository, digest string, tags ...string) (created bool, id int64, err error)
 	// Count returns the total count of artifacts according to the query.
-	// The artifacts that referenced by others and without tags are not counted
+	// The artifacts that referenced by others and without tags are not counted, neither are the accessories
 	Count(ctx context.Context, query *q.Query) (total int64, err error)
 	// List artifacts according to the query, specify the properties returned with option
-	// The artifacts that referenced by others and without tags are not returned
+	// The artifacts that referenced by others and without tags are not returned, neither are the accessories
 	List(ctx context.Context, query *q.Query, option *Option) (artifacts []*Artifact, err error)
 	// Get the artifact specified by ID, specify the properties returned with option
 	Get(ctx context.Context, id int64, option *Option) (artifact *Artifact, err error)
 	// Get the artifact specified by repository name and reference, the reference can be tag or digest,
 	// specify the properties returned with option
 	GetByReference(ctx context.Context, repository, reference string, option *Option) (artifact *Artifact, err error)
-	// Delete the artifact specified by artifact ID
+	// Delete the artifact specified by artifact ID, the accessories attached to it are deleted as well
 	Delete(ctx context.Context, id int64) (err error)
 	// Copy the artifact specified by "srcRepo" and "reference" into the repository specified by "dstRepo"
 	Copy(ctx context.Context, srcRepo, reference, dstRepo string) (id int64, err error)
@@ -118,6 +124,7 @@ func NewController() Controller {
 		blobMgr:      blob.Mgr,
 		sigMgr:       signature.GetManager(),
 		labelMgr:     label.Mgr,
+		accessoryMgr: accessory.Mgr,
 		immutableMtr: rule.NewRuleMatcher(),
 		regCli:       registry.Cli,
 		abstractor:   NewAbstractor(),
@@ -132,6 +139,7 @@ type controller struct {
 	blobMgr      blob.Manager
 	sigMgr       signature.Manager
 	labelMgr     label.Manager
+	accessoryMgr accessory.Manager
 	immutableMtr match.ImmutableTagMatcher
 	regCli       registry.Client
 	abstractor   Abstractor
@@ -146,6 +154,9 @@ func (c *controller) Ensure(ctx context.Context, repository, digest string, tags
 		if err = c.tagCtl.Ensure(ctx, artifact.RepositoryID, artifact.ID, tag); err != nil {
 			return false, 0, err
 		}
+		if err = c.ensureAccessory(ctx, artifact, tag); err != nil {
+			return false, 0, err
+		}
 	}
 	// fire event
 	e := &metadata.PushArtifactEventMetadata{
@@ -191,6 +202,10 @@ func (c *controller) ensureArtifact(ctx context.Context, repository, digest stri
 
 	// populate the artifact type
 	artifact.Type = processor.Get(artifact.MediaType).GetArtifactType(ctx, artifact)
+	// record the subject, the referrer may be pushed before the subject
+	if artifact.Subject != nil {
+		artifact.SubjectDigest = artifact.Subject.Digest.String()
+	}
 
 	// create it
 	// use orm.WithTransaction here to avoid the issue:
@@ -216,9 +231,116 @@ func (c *controller) ensureArtifact(ctx context.Context, repository, digest stri
 		}
 	}
 
+	if created {
+		// the manifest declares the subject(OCI 1.1), record the reference relationship
+		if len(artifact.SubjectDigest) > 0 {
+			if err = c.linkAccessory(ctx, artifact, artifact.SubjectDigest, accessorymodel.TypeSubject); err != nil {
+				return false, nil, err
+			}
+		}
+		// link the referrers which are pushed before the artifact
+		if err = c.linkReferrers(ctx, artifact); err != nil {
+			return false, nil, err
+		}
+	}
+
 	return created, artifact, nil
 }
 
+// link the artifact to its subject artifact as an accessory if the tag follows the cosign naming convention
+func (c *controller) ensureAccessory(ctx context.Context, art *artifact.Artifact, tag string) error {
+	subjectDigest, typ, ok := accessorymodel.ParseCosignTag(tag)
+	if !ok {
+		return nil
+	}
+	return c.linkAccessory(ctx, art, subjectDigest, typ)
+}
+
+// link the referrers which declare the artifact as the subject but are pushed before it
+func (c *controller) linkReferrers(ctx context.Context, subject *artifact.Artifact) error {
+	referrers, err := c.artMgr.List(ctx, q.New(q.KeyWords{
+		"RepositoryName": subject.RepositoryName,
+		"SubjectDigest":  subject.Digest,
+	}))
+	if err != nil {
+		return err
+	}
+	for _, referrer := range referrers {
+		if err = c.linkAccessory(ctx, referrer, subject.Digest, accessorymodel.TypeSubject); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// link the artifact to the subject artifact under the same repository as the specified type of accessory
+func (c *controller) linkAccessory(ctx context.Context, art *artifact.Artifact, subjectDigest, typ string) error {
+	subject, err := c.artMgr.GetByDigest(ctx, art.RepositoryName, subjectDigest)
+	if err != nil {
+		// the subject artifact doesn't exist, keep it as an ordinary artifact
+		if errors.IsNotFoundErr(err) {
+			log.Warningf("the subject artifact %s@%s of the accessory %s not found, skip",
+				art.RepositoryName, subjectDigest, art.Digest)
+			return nil
+		}
+		return err
+	}
+	// the tags are arbitrary, refuse the link which makes the artifact an accessory of itself
+	if subject.ID == art.ID {
+		log.Warningf("the artifact %s@%s cannot be the accessory of itself, skip", art.RepositoryName, art.Digest)
+		return nil
+	}
+	cycle, err := c.isAccessoryOf(ctx, subject.ID, art.ID)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		log.Warningf("the subject artifact %s@%s is an accessory of %s already, skip",
+			art.RepositoryName, subjectDigest, art.Digest)
+		return nil
+	}
+	// use orm.WithTransaction here to avoid the issue:
+	// https://www.postgresql.org/message-id/002e01c04da9%24a8f95c20%2425efe6c1%40lasting.ro
+	if err = orm.WithTransaction(func(ctx context.Context) error {
+		_, err := c.accessoryMgr.Create(ctx, &accessorymodel.Accessory{
+			ArtifactID:        art.ID,
+			SubjectArtifactID: subject.ID,
+			Type:              typ,
+			Size:              art.Size,
+			Digest:            art.Digest,
+		})
+		return err
+	})(orm.SetTransactionOpNameToContext(ctx, "tx-ensure-accessory")); err != nil && !errors.IsConflictErr(err) {
+		return err
+	}
+	return nil
+}
+
+// returns whether the artifact is the accessory of the subject artifact directly or transitively
+func (c *controller) isAccessoryOf(ctx context.Context, artifactID, subjectID int64) (bool, error) {
+	visited := map[int64]bool{}
+	ids := []int64{artifactID}
+	for len(ids) > 0 {
+		id := ids[0]
+		ids = ids[1:]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		accessories, err := c.accessoryMgr.List(ctx, q.New(q.KeyWords{"ArtifactID": id}))
+		if err != nil {
+			return false, err
+		}
+		for _, acc := range accessories {
+			if acc.SubjectArtifactID == subjectID {
+				return true, nil
+			}
+			ids = append(ids, acc.SubjectArtifactID)
+		}
+	}
+	return false, nil
+}
+
 func (c *controller) Count(ctx context.Context, query *q.Query) (int64, error) {
 	return c.artMgr.Count(ctx, query)
 }
@@ -283,12 +405,18 @@ func (c *controller) getByTag(ctx context.Context, repository, tag string, optio
 }
 
 func (c *controller) Delete(ctx context.Context, id int64) error {
-	return c.deleteDeeply(ctx, id, true)
+	return c.deleteDeeply(ctx, id, true, map[int64]bool{})
 }
 
 // "isRoot" is used to specify whether the artifact is the root parent artifact
 // the error handling logic for the root parent artifact and others is different
-func (c *controller) deleteDeeply(ctx context.Context, id int64, isRoot bool) error {
+func (c *controller) deleteDeeply(ctx context.Context, id int64, isRoot bool, visited map[int64]bool) error {
+	// the artifact is being deleted, e.g. the accessories reference each other
+	if visited[id] {
+		return nil
+	}
+	visited[id] = true
+
 	art, err := c.Get(ctx, id, &Option{WithTag: true})
 	if err != nil {
 		// return nil if the nonexistent artifact isn't the root parent
@@ -319,6 +447,17 @@ func (c *controller) deleteDeeply(ctx context.Context, id int64, isRoot bool) er
 		// the child artifact is referenced by other artifacts, skip
 		return nil
 	}
+	// delete the accessories attached to the artifact
+	accessories, err := c.accessoryMgr.List(ctx, q.New(q.KeyWords{"SubjectArtifactID": id}))
+	if err != nil {
+		return err
+	}
+	for _, acc := range accessories {
+		if err = c.deleteDeeply(ctx, acc.ArtifactID, true, visited); err != nil && !errors.IsNotFoundErr(err) {
+			return err
+		}
+	}
+
 	// delete child artifacts if contains any
 	for _, reference := range art.References {
 		// delete reference
@@ -326,7 +465,7 @@ func (c *controller) deleteDeeply(ctx context.Context, id int64, isRoot bool) er
 			!errors.IsErr(err, errors.NotFoundCode) {
 			return err
 		}
-		if err = c.deleteDeeply(ctx, reference.ChildID, false); err != nil {
+		if err = c.deleteDeeply(ctx, reference.ChildID, false, visited); err != nil {
 			return err
 		}
 	}
@@ -582,6 +721,9 @@ func (c *controller) assembleArtifact(ctx context.Context, art *artifact.Artifac
 	if option.WithLabel {
 		c.populateLabels(ctx, artifact)
 	}
+	if option.WithAccessory {
+		c.populateAccessories(ctx, artifact)
+	}
 	return artifact
 }
 
@@ -617,6 +759,15 @@ func (c *controller) populateLabels(ctx context.Context, art *Artifact) {
 	art.Labels = labels
 }
 
+func (c *controller) populateAccessories(ctx context.Context, art *Artifact) {
+	accessories, err := c.accessoryMgr.List(ctx, q.New(q.KeyWords{"SubjectArtifactID": art.ID}))
+	if err != nil {
+		log.Errorf("failed to list accessories of artifact %d: %v", art.ID, err)
+		return
+	}
+	art.Accessories = accessories
+}
+
 func (c *controller) populateAdditionLinks(ctx context.Context, artifact *Artifact) {
 	types := processor.Get(artifact.MediaType).ListAdditionTypes(ctx, &artifact.Artifact)
 	if len(types) > 0 {
@@ -28,12 +28,14 @@ import (
 	"github.com/goharbor/harbor/src/lib/icon"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
+	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
 	"github.com/goharbor/harbor/src/pkg/artifact"
 	"github.com/goharbor/harbor/src/pkg/label/model"
 	repomodel "github.com/goharbor/harbor/src/pkg/repository/model"
 	model_tag "github.com/goharbor/harbor/src/pkg/tag/model/tag"
 	tagtesting "github.com/goharbor/harbor/src/testing/controller/tag"
 	ormtesting "github.com/goharbor/harbor/src/testing/lib/orm"
+	accessorytesting "github.com/goharbor/harbor/src/testing/pkg/accessory"
 	arttesting "github.com/goharbor/harbor/src/testing/pkg/artifact"
 	artrashtesting "github.com/goharbor/harbor/src/testing/pkg/artifactrash"
 	"github.com/goharbor/harbor/src/testing/pkg/blob"
@@ -41,6 +43,7 @@ import (
 	"github.com/goharbor/harbor/src/testing/pkg/label"
 	"github.com/goharbor/harbor/src/testing/pkg/registry"
 	repotesting "github.com/goharbor/harbor/src/testing/pkg/repository"
+	godigest "github.com/opencontainers/go-digest"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -50,10 +53,12 @@ import (
 
 type fakeAbstractor struct {
 	mock.Mock
+	subject *v1.Descriptor
 }
 
 func (f *fakeAbstractor) AbstractMetadata(ctx context.Context, artifact *artifact.Artifact) error {
 	args := f.Called()
+	artifact.Subject = f.subject
 	return args.Error(0)
 }
 
@@ -66,6 +71,7 @@ type controllerTestSuite struct {
 	blobMgr      *blob.Manager
 	tagCtl       *tagtesting.FakeController
 	labelMgr     *label.Manager
+	accessoryMgr *accessorytesting.Manager
 	abstractor   *fakeAbstractor
 	immutableMtr *immutable.FakeMatcher
 	regCli       *registry.FakeClient
@@ -78,6 +84,7 @@ func (c *controllerTestSuite) SetupTest() {
 	c.blobMgr = &blob.Manager{}
 	c.tagCtl = &tagtesting.FakeController{}
 	c.labelMgr = &label.Manager{}
+	c.accessoryMgr = &accessorytesting.Manager{}
 	c.abstractor = &fakeAbstractor{}
 	c.immutableMtr = &immutable.FakeMatcher{}
 	c.regCli = &registry.FakeClient{}
@@ -88,6 +95,7 @@ func (c *controllerTestSuite) SetupTest() {
 		blobMgr:      c.blobMgr,
 		tagCtl:       c.tagCtl,
 		labelMgr:     c.labelMgr,
+		accessoryMgr: c.accessoryMgr,
 		abstractor:   c.abstractor,
 		immutableMtr: c.immutableMtr,
 		regCli:       c.regCli,
@@ -105,7 +113,8 @@ func (c *controllerTestSuite) TestAssembleArtifact() {
 		TagOption: &tag.Option{
 			WithImmutableStatus: false,
 		},
-		WithLabel: true,
+		WithLabel:     true,
+		WithAccessory: true,
 	}
 	tg := &tag.Tag{
 		Tag: model_tag.Tag{
@@ -126,12 +135,22 @@ func (c *controllerTestSuite) TestAssembleArtifact() {
 	c.labelMgr.On("ListByArtifact", mock.Anything, mock.Anything).Return([]*model.Label{
 		lb,
 	}, nil)
+	acc := &accessorymodel.Accessory{
+		ID:                1,
+		ArtifactID:        2,
+		SubjectArtifactID: 1,
+		Type:              accessorymodel.TypeCosignSignature,
+	}
+	c.accessoryMgr.On("List", mock.Anything, mock.Anything).Return([]*accessorymodel.Accessory{
+		acc,
+	}, nil)
 	artifact := c.ctl.assembleArtifact(ctx, art, option)
 	c.Require().NotNil(artifact)
 	c.Equal(art.ID, artifact.ID)
 	c.Equal(icon.DigestOfIconDefault, artifact.Icon)
 	c.Contains(artifact.Tags, tg)
 	c.Contains(artifact.Labels, lb)
+	c.Contains(artifact.Accessories, acc)
 	// TODO check other fields of option
 }
 
@@ -212,11 +231,76 @@ func (c *controllerTestSuite) TestEnsureArtifact() {
 	}, nil)
 	c.artMgr.On("GetByDigest", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.NotFoundError(nil))
 	c.artMgr.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	c.artMgr.On("List", mock.Anything, mock.Anything).Return([]*artifact.Artifact{}, nil)
+	c.abstractor.On("AbstractMetadata").Return(nil)
+	created, art, err = c.ctl.ensureArtifact(orm.NewContext(nil, &ormtesting.FakeOrmer{}), "library/hello-world", digest)
+	c.Require().Nil(err)
+	c.True(created)
+	c.Equal(int64(1), art.ID)
+	c.accessoryMgr.AssertNotCalled(c.T(), "Create", mock.Anything, mock.Anything)
+
+	// reset the mock
+	c.SetupTest()
+
+	// the artifact doesn't exist and declares the subject in its manifest
+	subjectDigest := "sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f"
+	c.abstractor.subject = &v1.Descriptor{Digest: godigest.Digest(subjectDigest)}
+	c.repoMgr.On("GetByName", mock.Anything, mock.Anything).Return(&repomodel.RepoRecord{
+		ProjectID: 1,
+	}, nil)
+	c.artMgr.On("GetByDigest", mock.Anything, "library/hello-world", digest).Return(nil, errors.NotFoundError(nil))
+	c.artMgr.On("GetByDigest", mock.Anything, "library/hello-world", subjectDigest).Return(&artifact.Artifact{ID: 2}, nil)
+	c.artMgr.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	c.artMgr.On("List", mock.Anything, mock.Anything).Return([]*artifact.Artifact{}, nil)
+	c.abstractor.On("AbstractMetadata").Return(nil)
+	c.accessoryMgr.On("List", mock.Anything, mock.Anything).Return([]*accessorymodel.Accessory{}, nil)
+	c.accessoryMgr.On("Create", mock.Anything, &accessorymodel.Accessory{
+		ArtifactID:        1,
+		SubjectArtifactID: 2,
+		Type:              accessorymodel.TypeSubject,
+		Digest:            digest,
+	}).Return(int64(1), nil)
+	created, art, err = c.ctl.ensureArtifact(orm.NewContext(nil, &ormtesting.FakeOrmer{}), "library/hello-world", digest)
+	c.Require().Nil(err)
+	c.True(created)
+	c.Equal(int64(1), art.ID)
+	c.accessoryMgr.AssertExpectations(c.T())
+
+	// reset the mock
+	c.SetupTest()
+
+	// the referrer is pushed before the artifact
+	c.repoMgr.On("GetByName", mock.Anything, mock.Anything).Return(&repomodel.RepoRecord{
+		ProjectID: 1,
+	}, nil)
+	referrer := &artifact.Artifact{
+		ID:             2,
+		RepositoryName: "library/hello-world",
+		Digest:         subjectDigest,
+		SubjectDigest:  digest,
+		Size:           1024,
+	}
+	c.artMgr.On("GetByDigest", mock.Anything, "library/hello-world", digest).Return(nil, errors.NotFoundError(nil)).Once()
+	c.artMgr.On("GetByDigest", mock.Anything, "library/hello-world", digest).Return(&artifact.Artifact{ID: 1}, nil)
+	c.artMgr.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	c.artMgr.On("List", mock.Anything, mock.MatchedBy(func(query *q.Query) bool {
+		return query.Keywords["SubjectDigest"] == digest
+	})).Return([]*artifact.Artifact{referrer}, nil)
 	c.abstractor.On("AbstractMetadata").Return(nil)
+	c.accessoryMgr.On("List", mock.Anything, mock.Anything).Return([]*accessorymodel.Accessory{}, nil)
+	c.accessoryMgr.On("Create", mock.Anything, &accessorymodel.Accessory{
+		ArtifactID:        2,
+		SubjectArtifactID: 1,
+		Type:              accessorymodel.TypeSubject,
+		Size:              1024,
+		Digest:            subjectDigest,
+	}).Return(int64(1), nil)
 	created, art, err = c.ctl.ensureArtifact(orm.NewContext(nil, &ormtesting.FakeOrmer{}), "library/hello-world", digest)
 	c.Require().Nil(err)
 	c.True(created)
 	c.Equal(int64(1), art.ID)
+	c.accessoryMgr.AssertExpectations(c.T())
+	c.artMgr.AssertExpectations(c.T())
 }
 
 func (c *controllerTestSuite) TestEnsure() {
@@ -228,6 +312,7 @@ func (c *controllerTestSuite) TestEnsure() {
 	}, nil)
 	c.artMgr.On("GetByDigest", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.NotFoundError(nil))
 	c.artMgr.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	c.artMgr.On("List", mock.Anything, mock.Anything).Return([]*artifact.Artifact{}, nil)
 	c.abstractor.On("AbstractMetadata").Return(nil)
 	c.tagCtl.On("Ensure").Return(nil)
 	_, id, err := c.ctl.Ensure(orm.NewContext(nil, &ormtesting.FakeOrmer{}), "library/hello-world", digest, "latest")
@@ -239,6 +324,75 @@ func (c *controllerTestSuite) TestEnsure() {
 	c.Equal(int64(1), id)
 }
 
+func (c *controllerTestSuite) TestEnsureAccessory() {
+	subjectDigest := "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180"
+	sigTag := "sha256-418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180.sig"
+	art := &artifact.Artifact{
+		ID:             2,
+		RepositoryName: "library/hello-world",
+		Digest:         "sha256:signature",
+		Size:           1024,
+	}
+	ctx := orm.NewContext(nil, &ormtesting.FakeOrmer{})
+
+	// not a cosign tag
+	c.Require().Nil(c.ctl.ensureAccessory(ctx, art, "latest"))
+	c.artMgr.AssertNotCalled(c.T(), "GetByDigest", mock.Anything, mock.Anything, mock.Anything)
+
+	// the subject artifact doesn't exist
+	c.artMgr.On("GetByDigest", mock.Anything, "library/hello-world", subjectDigest).Return(nil, errors.NotFoundError(nil)).Once()
+	c.Require().Nil(c.ctl.ensureAccessory(ctx, art, sigTag))
+	c.accessoryMgr.AssertNotCalled(c.T(), "Create", mock.Anything, mock.Anything)
+
+	// link the signature to the subject artifact
+	c.artMgr.On("GetByDigest", mock.Anything, "library/hello-world", subjectDigest).Return(&artifact.Artifact{ID: 1}, nil)
+	c.accessoryMgr.On("List", mock.Anything, mock.Anything).Return([]*accessorymodel.Accessory{}, nil)
+	c.accessoryMgr.On("Create", mock.Anything, &accessorymodel.Accessory{
+		ArtifactID:        2,
+		SubjectArtifactID: 1,
+		Type:              accessorymodel.TypeCosignSignature,
+		Size:              1024,
+		Digest:            "sha256:signature",
+	}).Return(int64(1), nil).Once()
+	c.Require().Nil(c.ctl.ensureAccessory(ctx, art, sigTag))
+
+	// the accessory already exists
+	c.accessoryMgr.On("Create", mock.Anything, mock.Anything).Return(int64(0), errors.ConflictError(nil)).Once()
+	c.Require().Nil(c.ctl.ensureAccessory(ctx, art, sigTag))
+	c.accessoryMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestLinkAccessoryCycle() {
+	digest := "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180"
+	ctx := orm.NewContext(nil, &ormtesting.FakeOrmer{})
+
+	// the artifact is tagged as the signature of itself
+	art := &artifact.Artifact{
+		ID:             1,
+		RepositoryName: "library/hello-world",
+		Digest:         digest,
+	}
+	c.artMgr.On("GetByDigest", mock.Anything, "library/hello-world", digest).Return(&artifact.Artifact{ID: 1}, nil)
+	c.Require().Nil(c.ctl.linkAccessory(ctx, art, digest, accessorymodel.TypeCosignSignature))
+	c.accessoryMgr.AssertNotCalled(c.T(), "Create", mock.Anything, mock.Anything)
+
+	// reset the mock
+	c.SetupTest()
+
+	// the subject is the accessory of the artifact already
+	subjectDigest := "sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f"
+	c.artMgr.On("GetByDigest", mock.Anything, "library/hello-world", subjectDigest).Return(&artifact.Artifact{ID: 2}, nil)
+	c.accessoryMgr.On("List", mock.Anything, q.New(q.KeyWords{"ArtifactID": int64(2)})).Return([]*accessorymodel.Accessory{
+		{
+			ID:                1,
+			ArtifactID:        2,
+			SubjectArtifactID: 1,
+		},
+	}, nil)
+	c.Require().Nil(c.ctl.linkAccessory(ctx, art, subjectDigest, accessorymodel.TypeCosignSignature))
+	c.accessoryMgr.AssertNotCalled(c.T(), "Create", mock.Anything, mock.Anything)
+}
+
 func (c *controllerTestSuite) TestCount() {
 	c.artMgr.On("Count", mock.Anything, mock.Anything).Return(int64(1), nil)
 	total, err := c.ctl.Count(nil, nil)
@@ -406,7 +560,7 @@ func (c *controllerTestSuite) TestGetByReference() {
 func (c *controllerTestSuite) TestDeleteDeeply() {
 	// root artifact and doesn't exist
 	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(nil, errors.NotFoundError(nil))
-	err := c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, true)
+	err := c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, true, map[int64]bool{})
 	c.Require().NotNil(err)
 	c.Assert().True(errors.IsErr(err, errors.NotFoundCode))
 
@@ -415,7 +569,7 @@ func (c *controllerTestSuite) TestDeleteDeeply() {
 
 	// child artifact and doesn't exist
 	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(nil, errors.NotFoundError(nil))
-	err = c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, false)
+	err = c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, false, map[int64]bool{})
 	c.Require().Nil(err)
 
 	// reset the mock
@@ -433,7 +587,7 @@ func (c *controllerTestSuite) TestDeleteDeeply() {
 	}, nil)
 	c.repoMgr.On("Get", mock.Anything, mock.Anything).Return(&repomodel.RepoRecord{}, nil)
 	c.artrashMgr.On("Create").Return(0, nil)
-	err = c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, false)
+	err = c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, false, map[int64]bool{})
 	c.Require().Nil(err)
 
 	// reset the mock
@@ -448,7 +602,7 @@ func (c *controllerTestSuite) TestDeleteDeeply() {
 			ID: 1,
 		},
 	}, nil)
-	err = c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, true)
+	err = c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, true, map[int64]bool{})
 	c.Require().NotNil(err)
 
 	// reset the mock
@@ -463,8 +617,67 @@ func (c *controllerTestSuite) TestDeleteDeeply() {
 			ID: 1,
 		},
 	}, nil)
-	err = c.ctl.deleteDeeply(nil, 1, false)
+	err = c.ctl.deleteDeeply(nil, 1, false, map[int64]bool{})
+	c.Require().Nil(err)
+
+	// reset the mock
+	c.SetupTest()
+
+	// root artifact with accessory attached
+	c.artMgr.On("Get", mock.Anything, int64(1)).Return(&artifact.Artifact{ID: 1}, nil)
+	c.artMgr.On("Get", mock.Anything, int64(2)).Return(&artifact.Artifact{ID: 2}, nil)
+	c.tagCtl.On("List").Return(nil, nil)
+	c.tagCtl.On("DeleteTags").Return(nil)
+	c.artMgr.On("ListReferences", mock.Anything, mock.Anything).Return(nil, nil)
+	c.accessoryMgr.On("List", mock.Anything, q.New(q.KeyWords{"SubjectArtifactID": int64(1)})).Return([]*accessorymodel.Accessory{
+		{
+			ID:                1,
+			ArtifactID:        2,
+			SubjectArtifactID: 1,
+		},
+	}, nil)
+	c.accessoryMgr.On("List", mock.Anything, q.New(q.KeyWords{"SubjectArtifactID": int64(2)})).Return(nil, nil)
+	c.labelMgr.On("RemoveAllFrom", mock.Anything, mock.Anything).Return(nil)
+	c.artMgr.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	c.blobMgr.On("List", mock.Anything, mock.Anything).Return(nil, nil)
+	c.blobMgr.On("CleanupAssociationsForProject", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.artrashMgr.On("Create").Return(0, nil)
+	err = c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, true, map[int64]bool{})
+	c.Require().Nil(err)
+	c.artMgr.AssertCalled(c.T(), "Delete", mock.Anything, int64(2))
+	c.artMgr.AssertCalled(c.T(), "Delete", mock.Anything, int64(1))
+
+	// reset the mock
+	c.SetupTest()
+
+	// the artifacts are accessories of each other
+	c.artMgr.On("Get", mock.Anything, int64(1)).Return(&artifact.Artifact{ID: 1}, nil)
+	c.artMgr.On("Get", mock.Anything, int64(2)).Return(&artifact.Artifact{ID: 2}, nil)
+	c.tagCtl.On("List").Return(nil, nil)
+	c.tagCtl.On("DeleteTags").Return(nil)
+	c.artMgr.On("ListReferences", mock.Anything, mock.Anything).Return(nil, nil)
+	c.accessoryMgr.On("List", mock.Anything, q.New(q.KeyWords{"SubjectArtifactID": int64(1)})).Return([]*accessorymodel.Accessory{
+		{
+			ID:                1,
+			ArtifactID:        2,
+			SubjectArtifactID: 1,
+		},
+	}, nil)
+	c.accessoryMgr.On("List", mock.Anything, q.New(q.KeyWords{"SubjectArtifactID": int64(2)})).Return([]*accessorymodel.Accessory{
+		{
+			ID:                2,
+			ArtifactID:        1,
+			SubjectArtifactID: 2,
+		},
+	}, nil)
+	c.labelMgr.On("RemoveAllFrom", mock.Anything, mock.Anything).Return(nil)
+	c.artMgr.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	c.blobMgr.On("List", mock.Anything, mock.Anything).Return(nil, nil)
+	c.blobMgr.On("CleanupAssociationsForProject", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.artrashMgr.On("Create").Return(0, nil)
+	err = c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, true, map[int64]bool{})
 	c.Require().Nil(err)
+	c.artMgr.AssertNumberOfCalls(c.T(), "Delete", 2)
 }
 
 func (c *controllerTestSuite) TestCopy() {
@@ -493,6 +706,7 @@ func (c *controllerTestSuite) TestCopy() {
 	}, nil)
 	c.abstractor.On("AbstractMetadata").Return(nil)
 	c.artMgr.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	c.artMgr.On("List", mock.Anything, mock.Anything).Return([]*artifact.Artifact{}, nil)
 	c.regCli.On("Copy").Return(nil)
 	c.tagCtl.On("Ensure").Return(nil)
 	_, err := c.ctl.Copy(orm.NewContext(nil, &ormtesting.FakeOrmer{}), "library/hello-world", "latest", "library/hello-world2")
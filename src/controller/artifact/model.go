@@ -19,6 +19,7 @@ import (
 	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/controller/tag"
 	"github.com/goharbor/harbor/src/lib/encode/repository"
+	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
 	"github.com/goharbor/harbor/src/pkg/artifact"
 	"github.com/goharbor/harbor/src/pkg/label/model"
 )
@@ -26,9 +27,10 @@ import (
 // Artifact is the overall view of artifact
 type Artifact struct {
 	artifact.Artifact
-	Tags          []*tag.Tag               `json:"tags"`           // the list of tags that attached to the artifact
-	AdditionLinks map[string]*AdditionLink `json:"addition_links"` // the resource link for build history(image), values.yaml(chart), dependency(chart), etc
-	Labels        []*model.Label           `json:"labels"`
+	Tags          []*tag.Tag                  `json:"tags"`           // the list of tags that attached to the artifact
+	AdditionLinks map[string]*AdditionLink    `json:"addition_links"` // the resource link for build history(image), values.yaml(chart), dependency(chart), etc
+	Labels        []*model.Label              `json:"labels"`
+	Accessories   []*accessorymodel.Accessory `json:"accessories"` // the cosign signatures, attestations, etc. attached to the artifact
 }
 
 // SetAdditionLink set a addition link
@@ -56,4 +58,6 @@ type Option struct {
 	WithTag   bool
 	TagOption *tag.Option // only works when WithTag is set to true
 	WithLabel bool
+	// WithAccessory populates the accessories attached to the artifact
+	WithAccessory bool
 }
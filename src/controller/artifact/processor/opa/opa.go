@@ -0,0 +1,241 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	ps "github.com/goharbor/harbor/src/controller/artifact/processor"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/base"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// const definitions
+const (
+	// ArtifactTypeOPA defines the artifact type for OPA policy bundle
+	ArtifactTypeOPA = "OPA"
+	// AdditionTypeReadme is the README of the policy bundle
+	AdditionTypeReadme = "README.MD"
+	// AdditionTypeManifest is the summary of the policy bundle: revision, roots and files
+	AdditionTypeManifest = "MANIFEST"
+
+	// the media types used by OPA and conftest when pushing policies to OCI registry
+	mediaType            = "application/vnd.cncf.openpolicyagent.config.v1+json"
+	mediaTypeBundleLayer = "application/vnd.cncf.openpolicyagent.layer.v1.tar+gzip"
+	mediaTypePolicyLayer = "application/vnd.cncf.openpolicyagent.policy.layer.v1+rego"
+	mediaTypeDataLayer   = "application/vnd.cncf.openpolicyagent.data.layer.v1+json"
+
+	manifestFile = ".manifest"
+	readmeFile   = "readme.md"
+	// the max size of the README and ".manifest" file read
+	maxFileSize = 1 << 20
+)
+
+func init() {
+	pc := &processor{
+		ManifestProcessor: base.NewManifestProcessor(),
+	}
+	if err := ps.Register(pc, mediaType); err != nil {
+		log.Errorf("failed to register processor for media type %s: %v", mediaType, err)
+		return
+	}
+}
+
+// bundle is the summary of the OPA policy bundle
+type bundle struct {
+	Revision string                 `json:"revision,omitempty"`
+	Roots    []string               `json:"roots,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Policies []string               `json:"policies"`
+	Data     []string               `json:"data"`
+	readme   []byte
+}
+
+type processor struct {
+	*base.ManifestProcessor
+}
+
+func (p *processor) AbstractMetadata(ctx context.Context, art *artifact.Artifact, manifest []byte) error {
+	// populate all attributes in the config layer
+	if err := p.ManifestProcessor.AbstractMetadata(ctx, art, manifest); err != nil {
+		return err
+	}
+	if art.ExtraAttrs == nil {
+		art.ExtraAttrs = map[string]interface{}{}
+	}
+
+	b, err := p.inspect(art.RepositoryName, manifest)
+	if err != nil {
+		// the bundle may be in the layout which isn't supported, don't block the pushing
+		log.G(ctx).Warningf("failed to inspect the OPA bundle %s@%s: %v", art.RepositoryName, art.Digest, err)
+		return nil
+	}
+	if len(b.Revision) > 0 {
+		art.ExtraAttrs["revision"] = b.Revision
+	}
+	if len(b.Roots) > 0 {
+		art.ExtraAttrs["roots"] = b.Roots
+	}
+	art.ExtraAttrs["policies"] = b.Policies
+	return nil
+}
+
+func (p *processor) AbstractAddition(ctx context.Context, art *artifact.Artifact, addition string) (*ps.Addition, error) {
+	if addition != AdditionTypeReadme && addition != AdditionTypeManifest {
+		return nil, errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("addition %s isn't supported for %s", addition, ArtifactTypeOPA)
+	}
+	m, _, err := p.RegCli.PullManifest(art.RepositoryName, art.Digest)
+	if err != nil {
+		return nil, err
+	}
+	_, payload, err := m.Payload()
+	if err != nil {
+		return nil, err
+	}
+	b, err := p.inspect(art.RepositoryName, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	switch addition {
+	case AdditionTypeReadme:
+		if len(b.readme) == 0 {
+			return nil, errors.New(nil).WithCode(errors.NotFoundCode).
+				WithMessage("the README of %s@%s not found", art.RepositoryName, art.Digest)
+		}
+		return &ps.Addition{
+			Content:     b.readme,
+			ContentType: "text/markdown; charset=utf-8",
+		}, nil
+	default:
+		content, err := json.Marshal(b)
+		if err != nil {
+			return nil, err
+		}
+		return &ps.Addition{
+			Content:     content,
+			ContentType: "application/json; charset=utf-8",
+		}, nil
+	}
+}
+
+func (p *processor) GetArtifactType(ctx context.Context, artifact *artifact.Artifact) string {
+	return ArtifactTypeOPA
+}
+
+func (p *processor) ListAdditionTypes(ctx context.Context, artifact *artifact.Artifact) []string {
+	return []string{AdditionTypeReadme, AdditionTypeManifest}
+}
+
+// inspect the layers of the policy bundle. The bundle is either a tarball built by "opa build"
+// or the separated rego and data files pushed by conftest
+func (p *processor) inspect(repository string, manifest []byte) (*bundle, error) {
+	mani := &v1.Manifest{}
+	if err := json.Unmarshal(manifest, mani); err != nil {
+		return nil, err
+	}
+	b := &bundle{
+		Policies: []string{},
+		Data:     []string{},
+	}
+	for _, layer := range mani.Layers {
+		title := layer.Annotations[v1.AnnotationTitle]
+		switch layer.MediaType {
+		case mediaTypeBundleLayer:
+			if err := p.inspectTarball(repository, layer.Digest.String(), b); err != nil {
+				return nil, err
+			}
+		case mediaTypePolicyLayer:
+			b.Policies = append(b.Policies, title)
+		case mediaTypeDataLayer:
+			b.Data = append(b.Data, title)
+		default:
+			if strings.ToLower(path.Base(title)) == readmeFile && len(b.readme) == 0 {
+				content, err := p.pullFile(repository, layer.Digest.String())
+				if err != nil {
+					return nil, err
+				}
+				b.readme = content
+			}
+		}
+	}
+	return b, nil
+}
+
+func (p *processor) inspectTarball(repository, digest string, b *bundle) error {
+	_, blob, err := p.RegCli.PullBlob(repository, digest)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+	gzipReader, err := gzip.NewReader(blob)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		hdr, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := strings.TrimPrefix(path.Clean("/"+hdr.Name), "/")
+		switch {
+		case name == manifestFile:
+			content, err := ioutil.ReadAll(io.LimitReader(tarReader, maxFileSize))
+			if err != nil {
+				return err
+			}
+			if err = json.Unmarshal(content, b); err != nil {
+				return err
+			}
+		case strings.ToLower(name) == readmeFile:
+			if b.readme, err = ioutil.ReadAll(io.LimitReader(tarReader, maxFileSize)); err != nil {
+				return err
+			}
+		case path.Ext(name) == ".rego":
+			b.Policies = append(b.Policies, name)
+		case path.Base(name) == "data.json" || path.Base(name) == "data.yaml":
+			b.Data = append(b.Data, name)
+		}
+	}
+}
+
+func (p *processor) pullFile(repository, digest string) ([]byte, error) {
+	_, blob, err := p.RegCli.PullBlob(repository, digest)
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Close()
+	return ioutil.ReadAll(io.LimitReader(blob, maxFileSize))
+}
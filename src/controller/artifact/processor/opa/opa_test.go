@@ -0,0 +1,136 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/distribution"
+	_ "github.com/docker/distribution/manifest/ocischema"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/base"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/testing/pkg/registry"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	bundleManifest   = `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.cncf.openpolicyagent.config.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":0},"layers":[{"mediaType":"application/vnd.cncf.openpolicyagent.layer.v1.tar+gzip","digest":"sha256:0bd64cfb958b68c71b46597e22185a41e784dc96e04090bc7d2a480b704c3b65","size":1024}]}`
+	conftestManifest = `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.cncf.openpolicyagent.config.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":0},"layers":[` +
+		`{"mediaType":"application/vnd.cncf.openpolicyagent.policy.layer.v1+rego","digest":"sha256:1b930d010525941c1d56ec53b97bd057a67ae1865eebf042686d2a2d18271ced","size":100,"annotations":{"org.opencontainers.image.title":"policy/deny.rego"}},` +
+		`{"mediaType":"application/vnd.cncf.openpolicyagent.data.layer.v1+json","digest":"sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f","size":100,"annotations":{"org.opencontainers.image.title":"data/exceptions.json"}}]}`
+	readme = "# Kubernetes policies\n"
+)
+
+// build the bundle tarball as "opa build" does
+func buildBundle() []byte {
+	files := map[string]string{
+		"/.manifest":                      `{"revision":"v1.0.0","roots":["kubernetes"]}`,
+		"/README.md":                      readme,
+		"/kubernetes/admission/deny.rego": "package kubernetes.admission\n",
+		"/kubernetes/data.json":           "{}",
+	}
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, name := range []string{"/.manifest", "/README.md", "/kubernetes/admission/deny.rego", "/kubernetes/data.json"} {
+		tarWriter.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0600,
+			Size:     int64(len(files[name])),
+			Typeflag: tar.TypeReg,
+		})
+		tarWriter.Write([]byte(files[name]))
+	}
+	tarWriter.Close()
+	gzipWriter.Close()
+	return buf.Bytes()
+}
+
+type processorTestSuite struct {
+	suite.Suite
+	processor *processor
+	regCli    *registry.FakeClient
+}
+
+func (p *processorTestSuite) SetupTest() {
+	p.regCli = &registry.FakeClient{}
+	p.processor = &processor{}
+	p.processor.ManifestProcessor = &base.ManifestProcessor{RegCli: p.regCli}
+}
+
+func (p *processorTestSuite) TestAbstractMetadataOfBundle() {
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(bytes.NewReader(buildBundle())), nil)
+	art := &artifact.Artifact{}
+	err := p.processor.AbstractMetadata(nil, art, []byte(bundleManifest))
+	p.Require().Nil(err)
+	p.Equal("v1.0.0", art.ExtraAttrs["revision"])
+	p.Equal([]string{"kubernetes"}, art.ExtraAttrs["roots"])
+	p.Equal([]string{"kubernetes/admission/deny.rego"}, art.ExtraAttrs["policies"])
+}
+
+func (p *processorTestSuite) TestAbstractMetadataOfConftest() {
+	art := &artifact.Artifact{}
+	err := p.processor.AbstractMetadata(nil, art, []byte(conftestManifest))
+	p.Require().Nil(err)
+	p.Equal([]string{"policy/deny.rego"}, art.ExtraAttrs["policies"])
+	p.Nil(art.ExtraAttrs["revision"])
+}
+
+func (p *processorTestSuite) TestAbstractAddition() {
+	// unknown addition
+	_, err := p.processor.AbstractAddition(nil, nil, "unknown_addition")
+	p.True(errors.IsErr(err, errors.BadRequestCode))
+
+	manifest, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, []byte(bundleManifest))
+	p.Require().Nil(err)
+	p.regCli.On("PullManifest").Return(manifest, "", nil)
+
+	// README
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(bytes.NewReader(buildBundle())), nil).Once()
+	addition, err := p.processor.AbstractAddition(nil, &artifact.Artifact{}, AdditionTypeReadme)
+	p.Require().Nil(err)
+	p.Equal("text/markdown; charset=utf-8", addition.ContentType)
+	p.Equal(readme, string(addition.Content))
+
+	// manifest summary
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(bytes.NewReader(buildBundle())), nil).Once()
+	addition, err = p.processor.AbstractAddition(nil, &artifact.Artifact{}, AdditionTypeManifest)
+	p.Require().Nil(err)
+	p.Equal("application/json; charset=utf-8", addition.ContentType)
+	summary := map[string]interface{}{}
+	p.Require().Nil(json.Unmarshal(addition.Content, &summary))
+	p.Equal("v1.0.0", summary["revision"])
+	p.True(strings.Contains(string(addition.Content), "kubernetes/data.json"))
+}
+
+func (p *processorTestSuite) TestGetArtifactType() {
+	p.Assert().Equal(ArtifactTypeOPA, p.processor.GetArtifactType(nil, nil))
+}
+
+func (p *processorTestSuite) TestListAdditionTypes() {
+	p.Equal([]string{AdditionTypeReadme, AdditionTypeManifest}, p.processor.ListAdditionTypes(nil, nil))
+}
+
+func TestProcessorTestSuite(t *testing.T) {
+	suite.Run(t, &processorTestSuite{})
+}
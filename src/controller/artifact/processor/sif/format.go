@@ -0,0 +1,200 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+)
+
+// the definitions of the SIF(Singularity Image Format) file layout, see https://github.com/sylabs/sif
+const (
+	sifMagic = "SIF_MAGIC"
+
+	dataTypeDeffile = 0x4001
+	dataTypeLabels  = 0x4003
+
+	// the objects located beyond the limit aren't read, as the whole file(mostly the squashfs partition) is huge
+	maxObjectOffset = 64 << 20
+)
+
+var (
+	archs = map[string]string{
+		"01": "386",
+		"02": "amd64",
+		"03": "arm",
+		"04": "arm64",
+		"05": "ppc64",
+		"06": "ppc64le",
+		"07": "mips",
+		"08": "mipsle",
+		"09": "mips64",
+		"10": "mips64le",
+		"11": "s390x",
+		"12": "riscv64",
+	}
+)
+
+// the global header of SIF file
+type header struct {
+	LaunchScript      [32]byte
+	Magic             [10]byte
+	Version           [3]byte
+	Arch              [3]byte
+	ID                [16]byte
+	CreatedAt         int64
+	ModifiedAt        int64
+	DescriptorsFree   int64
+	DescriptorsTotal  int64
+	DescriptorsOffset int64
+	DescriptorsSize   int64
+	DataOffset        int64
+	DataSize          int64
+}
+
+// the descriptor of the data object in SIF file
+type descriptor struct {
+	DataType        int32
+	Used            bool
+	ID              uint32
+	GroupID         uint32
+	LinkedID        uint32
+	Offset          int64
+	Size            int64
+	SizeWithPadding int64
+	CreatedAt       int64
+	ModifiedAt      int64
+	UID             int64
+	GID             int64
+	Name            [128]byte
+	Extra           [384]byte
+}
+
+// image contains the metadata of SIF file
+type image struct {
+	Version      string
+	Architecture string
+	ID           string
+	CreatedAt    time.Time
+	// the content of data objects keyed by the data type
+	Objects map[int32][]byte
+}
+
+// parseImage reads the header, the descriptors and the specified types of data objects from the SIF file
+func parseImage(r io.Reader, dataTypes ...int32) (*image, error) {
+	reader := &countingReader{r: r}
+	hdr := &header{}
+	if err := binary.Read(reader, binary.LittleEndian, hdr); err != nil {
+		return nil, fmt.Errorf("failed to read the SIF header: %v", err)
+	}
+	if cstring(hdr.Magic[:]) != sifMagic {
+		return nil, fmt.Errorf("invalid SIF magic")
+	}
+	img := &image{
+		Version:      cstring(hdr.Version[:]),
+		Architecture: archs[cstring(hdr.Arch[:])],
+		ID:           fmt.Sprintf("%x-%x-%x-%x-%x", hdr.ID[0:4], hdr.ID[4:6], hdr.ID[6:8], hdr.ID[8:10], hdr.ID[10:]),
+		CreatedAt:    time.Unix(hdr.CreatedAt, 0).UTC(),
+		Objects:      map[int32][]byte{},
+	}
+	if len(dataTypes) == 0 {
+		return img, nil
+	}
+
+	if hdr.DescriptorsOffset < 0 || hdr.DescriptorsOffset > maxObjectOffset {
+		return nil, fmt.Errorf("invalid offset of the SIF descriptors: %d", hdr.DescriptorsOffset)
+	}
+	if err := reader.skipTo(hdr.DescriptorsOffset); err != nil {
+		return nil, err
+	}
+	var descs []*descriptor
+	for i := int64(0); i < hdr.DescriptorsTotal; i++ {
+		// the descriptors beyond the limit aren't read either
+		if reader.offset > maxObjectOffset {
+			break
+		}
+		desc := &descriptor{}
+		if err := binary.Read(reader, binary.LittleEndian, desc); err != nil {
+			return nil, fmt.Errorf("failed to read the SIF descriptor: %v", err)
+		}
+		if !desc.Used || !contains(dataTypes, desc.DataType) {
+			continue
+		}
+		// only the first object of each type is read
+		if _, exist := img.Objects[desc.DataType]; exist {
+			continue
+		}
+		img.Objects[desc.DataType] = nil
+		descs = append(descs, desc)
+	}
+
+	// read the objects in order as the file is read as stream
+	sort.Slice(descs, func(i, j int) bool { return descs[i].Offset < descs[j].Offset })
+	for _, desc := range descs {
+		if desc.Offset < 0 || desc.Size < 0 || desc.Offset > maxObjectOffset || desc.Size > maxObjectOffset-desc.Offset ||
+			desc.Offset < reader.offset {
+			delete(img.Objects, desc.DataType)
+			continue
+		}
+		if err := reader.skipTo(desc.Offset); err != nil {
+			return nil, err
+		}
+		data := make([]byte, desc.Size)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, fmt.Errorf("failed to read the SIF data object: %v", err)
+		}
+		img.Objects[desc.DataType] = data
+	}
+	return img, nil
+}
+
+func cstring(b []byte) string {
+	return strings.TrimRight(string(bytes.TrimRight(b, "\x00")), "\n")
+}
+
+func contains(types []int32, t int32) bool {
+	for _, typ := range types {
+		if typ == t {
+			return true
+		}
+	}
+	return false
+}
+
+// countingReader records the offset of the reading
+type countingReader struct {
+	r      io.Reader
+	offset int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.offset += int64(n)
+	return n, err
+}
+
+func (c *countingReader) skipTo(offset int64) error {
+	if offset < c.offset {
+		return fmt.Errorf("cannot skip backward to offset %d", offset)
+	}
+	_, err := io.CopyN(ioutil.Discard, c, offset-c.offset)
+	return err
+}
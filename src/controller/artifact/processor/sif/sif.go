@@ -0,0 +1,141 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sif
+
+import (
+	"context"
+	"encoding/json"
+
+	ps "github.com/goharbor/harbor/src/controller/artifact/processor"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/base"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// const definitions
+const (
+	// ArtifactTypeSIF defines the artifact type for Singularity image
+	ArtifactTypeSIF = "SIF"
+	// AdditionTypeDefinition is the definition file which the Singularity image is built from
+	AdditionTypeDefinition = "DEFINITION"
+
+	// the media types used by Singularity/Apptainer when pushing SIF to OCI registry
+	mediaType      = "application/vnd.sylabs.sif.config.v1+json"
+	mediaTypeLayer = "application/vnd.sylabs.sif.layer.v1.sif"
+)
+
+func init() {
+	pc := &processor{
+		ManifestProcessor: base.NewManifestProcessor(),
+	}
+	if err := ps.Register(pc, mediaType); err != nil {
+		log.Errorf("failed to register processor for media type %s: %v", mediaType, err)
+		return
+	}
+}
+
+type processor struct {
+	*base.ManifestProcessor
+}
+
+func (p *processor) AbstractMetadata(ctx context.Context, art *artifact.Artifact, manifest []byte) error {
+	// populate all attributes in the config layer
+	if err := p.ManifestProcessor.AbstractMetadata(ctx, art, manifest); err != nil {
+		return err
+	}
+	if art.ExtraAttrs == nil {
+		art.ExtraAttrs = map[string]interface{}{}
+	}
+
+	img, err := p.pullImage(art.RepositoryName, manifest, dataTypeLabels)
+	if err != nil {
+		// the SIF file may be in the format which isn't supported, don't block the pushing
+		log.G(ctx).Warningf("failed to parse the SIF file of %s@%s: %v", art.RepositoryName, art.Digest, err)
+		return nil
+	}
+	if img == nil {
+		return nil
+	}
+	art.ExtraAttrs["sif_version"] = img.Version
+	art.ExtraAttrs["architecture"] = img.Architecture
+	art.ExtraAttrs["id"] = img.ID
+	art.ExtraAttrs["created"] = img.CreatedAt
+	if data := img.Objects[dataTypeLabels]; len(data) > 0 {
+		labels := map[string]interface{}{}
+		if err = json.Unmarshal(data, &labels); err != nil {
+			log.G(ctx).Warningf("failed to parse the labels of SIF file of %s@%s: %v", art.RepositoryName, art.Digest, err)
+			return nil
+		}
+		art.ExtraAttrs["labels"] = labels
+	}
+	return nil
+}
+
+func (p *processor) AbstractAddition(ctx context.Context, art *artifact.Artifact, addition string) (*ps.Addition, error) {
+	if addition != AdditionTypeDefinition {
+		return nil, errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("addition %s isn't supported for %s", addition, ArtifactTypeSIF)
+	}
+	m, _, err := p.RegCli.PullManifest(art.RepositoryName, art.Digest)
+	if err != nil {
+		return nil, err
+	}
+	_, payload, err := m.Payload()
+	if err != nil {
+		return nil, err
+	}
+	img, err := p.pullImage(art.RepositoryName, payload, dataTypeDeffile)
+	if err != nil {
+		return nil, err
+	}
+	if img == nil || len(img.Objects[dataTypeDeffile]) == 0 {
+		return nil, errors.New(nil).WithCode(errors.NotFoundCode).
+			WithMessage("the definition file of %s@%s not found", art.RepositoryName, art.Digest)
+	}
+	return &ps.Addition{
+		Content:     img.Objects[dataTypeDeffile],
+		ContentType: "text/plain; charset=utf-8",
+	}, nil
+}
+
+func (p *processor) GetArtifactType(ctx context.Context, artifact *artifact.Artifact) string {
+	return ArtifactTypeSIF
+}
+
+func (p *processor) ListAdditionTypes(ctx context.Context, artifact *artifact.Artifact) []string {
+	return []string{AdditionTypeDefinition}
+}
+
+// pull the SIF layer and parse the specified types of data objects, returns nil if no SIF layer found
+func (p *processor) pullImage(repository string, manifest []byte, dataTypes ...int32) (*image, error) {
+	mani := &v1.Manifest{}
+	if err := json.Unmarshal(manifest, mani); err != nil {
+		return nil, err
+	}
+	for _, layer := range mani.Layers {
+		if layer.MediaType != mediaTypeLayer {
+			continue
+		}
+		_, blob, err := p.RegCli.PullBlob(repository, layer.Digest.String())
+		if err != nil {
+			return nil, err
+		}
+		defer blob.Close()
+		return parseImage(blob, dataTypes...)
+	}
+	return nil, nil
+}
@@ -0,0 +1,164 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/distribution"
+	_ "github.com/docker/distribution/manifest/ocischema"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/base"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/testing/pkg/registry"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	sifManifest = `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.sylabs.sif.config.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":0},"layers":[{"mediaType":"application/vnd.sylabs.sif.layer.v1.sif","digest":"sha256:0bd64cfb958b68c71b46597e22185a41e784dc96e04090bc7d2a480b704c3b65","size":2048}]}`
+	deffile     = "Bootstrap: docker\nFrom: alpine:3.15\n"
+	labels      = `{"org.label-schema.build-arch":"amd64","maintainer":"harbor"}`
+)
+
+// build a SIF file containing the definition file and the labels
+func buildSIF() []byte {
+	objects := []struct {
+		dataType int32
+		data     string
+	}{
+		{dataTypeDeffile, deffile},
+		{dataTypeLabels, labels},
+	}
+	hdr := &header{
+		DescriptorsTotal:  int64(len(objects)),
+		DescriptorsOffset: 128,
+		CreatedAt:         1640995200,
+	}
+	copy(hdr.Magic[:], sifMagic)
+	copy(hdr.Version[:], "01")
+	copy(hdr.Arch[:], "02")
+
+	buf := &bytes.Buffer{}
+	binary.Write(buf, binary.LittleEndian, hdr)
+	offset := int64(128 + 585*len(objects))
+	for i, obj := range objects {
+		desc := &descriptor{
+			DataType: obj.dataType,
+			Used:     true,
+			ID:       uint32(i + 1),
+			Offset:   offset,
+			Size:     int64(len(obj.data)),
+		}
+		binary.Write(buf, binary.LittleEndian, desc)
+		offset += int64(len(obj.data))
+	}
+	for _, obj := range objects {
+		buf.WriteString(obj.data)
+	}
+	return buf.Bytes()
+}
+
+type processorTestSuite struct {
+	suite.Suite
+	processor *processor
+	regCli    *registry.FakeClient
+}
+
+func (p *processorTestSuite) SetupTest() {
+	p.regCli = &registry.FakeClient{}
+	p.processor = &processor{}
+	p.processor.ManifestProcessor = &base.ManifestProcessor{RegCli: p.regCli}
+}
+
+func (p *processorTestSuite) TestParseImage() {
+	img, err := parseImage(bytes.NewReader(buildSIF()), dataTypeLabels, dataTypeDeffile)
+	p.Require().Nil(err)
+	p.Equal("01", img.Version)
+	p.Equal("amd64", img.Architecture)
+	p.Equal(int64(1640995200), img.CreatedAt.Unix())
+	p.Equal(deffile, string(img.Objects[dataTypeDeffile]))
+	p.Equal(labels, string(img.Objects[dataTypeLabels]))
+
+	// invalid SIF file
+	_, err = parseImage(strings.NewReader(strings.Repeat("invalid", 100)))
+	p.NotNil(err)
+}
+
+func (p *processorTestSuite) TestParseCraftedImage() {
+	// the negative size of the first descriptor
+	data := buildSIF()
+	binary.LittleEndian.PutUint64(data[128+25:], uint64(0xffffffffffffff00))
+	img, err := parseImage(bytes.NewReader(data), dataTypeLabels, dataTypeDeffile)
+	p.Require().Nil(err)
+	p.NotContains(img.Objects, int32(dataTypeDeffile))
+	p.Equal(labels, string(img.Objects[dataTypeLabels]))
+
+	// the size overflows the limit
+	data = buildSIF()
+	binary.LittleEndian.PutUint64(data[128+25:], uint64(1<<63-1))
+	img, err = parseImage(bytes.NewReader(data), dataTypeLabels, dataTypeDeffile)
+	p.Require().Nil(err)
+	p.NotContains(img.Objects, int32(dataTypeDeffile))
+
+	// the descriptors are beyond the limit
+	data = buildSIF()
+	binary.LittleEndian.PutUint64(data[96:], uint64(maxObjectOffset+1))
+	_, err = parseImage(bytes.NewReader(data), dataTypeLabels, dataTypeDeffile)
+	p.NotNil(err)
+}
+
+func (p *processorTestSuite) TestAbstractMetadata() {
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(bytes.NewReader(buildSIF())), nil)
+	art := &artifact.Artifact{}
+	err := p.processor.AbstractMetadata(nil, art, []byte(sifManifest))
+	p.Require().Nil(err)
+	p.Equal("amd64", art.ExtraAttrs["architecture"])
+	p.Equal("01", art.ExtraAttrs["sif_version"])
+	l, ok := art.ExtraAttrs["labels"].(map[string]interface{})
+	p.Require().True(ok)
+	p.Equal("harbor", l["maintainer"])
+}
+
+func (p *processorTestSuite) TestAbstractAddition() {
+	// unknown addition
+	_, err := p.processor.AbstractAddition(nil, nil, "unknown_addition")
+	p.True(errors.IsErr(err, errors.BadRequestCode))
+
+	manifest, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, []byte(sifManifest))
+	p.Require().Nil(err)
+	p.regCli.On("PullManifest").Return(manifest, "", nil)
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(bytes.NewReader(buildSIF())), nil)
+	addition, err := p.processor.AbstractAddition(nil, &artifact.Artifact{}, AdditionTypeDefinition)
+	p.Require().Nil(err)
+	p.Equal("text/plain; charset=utf-8", addition.ContentType)
+	p.Equal(deffile, string(addition.Content))
+}
+
+func (p *processorTestSuite) TestGetArtifactType() {
+	p.Assert().Equal(ArtifactTypeSIF, p.processor.GetArtifactType(nil, nil))
+}
+
+func (p *processorTestSuite) TestListAdditionTypes() {
+	p.Equal([]string{AdditionTypeDefinition}, p.processor.ListAdditionTypes(nil, nil))
+}
+
+func TestProcessorTestSuite(t *testing.T) {
+	suite.Run(t, &processorTestSuite{})
+}
@@ -0,0 +1,161 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wasm
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+const (
+	sectionIDExport = 7
+	sectionIDCode   = 10
+
+	// the names of the exported items are identifiers, the longer ones are treated as invalid
+	maxExportNameLength = 1024
+	// each exported item takes at least 3 bytes: the length of the name, the kind and the index
+	minExportSize = 3
+)
+
+var (
+	wasmMagic = []byte{0x00, 0x61, 0x73, 0x6d}
+	// the kinds of the exported item
+	exportKinds = map[byte]string{
+		0x00: "func",
+		0x01: "table",
+		0x02: "memory",
+		0x03: "global",
+	}
+)
+
+// Export is the item exported by the WASM module
+type Export struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+// parseExports reads the WASM binary module and returns the items defined in the export section.
+// The sections after the export section(code and data which are the most part of module) aren't read
+func parseExports(r io.ByteReader) ([]*Export, error) {
+	header := make([]byte, 8)
+	for i := range header {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("invalid WASM module header: %v", err)
+		}
+		header[i] = b
+	}
+	if !bytes.Equal(header[:4], wasmMagic) {
+		return nil, fmt.Errorf("invalid WASM magic number")
+	}
+
+	exports := []*Export{}
+	for {
+		id, err := r.ReadByte()
+		if err == io.EOF {
+			return exports, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		size, err := readUint32(r)
+		if err != nil {
+			return nil, err
+		}
+		switch id {
+		case sectionIDExport:
+			return readExportSection(r, size)
+		case sectionIDCode:
+			// the export section must appear before the code section
+			return exports, nil
+		default:
+			if err = skip(r, size); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func readExportSection(r io.ByteReader, size uint32) ([]*Export, error) {
+	count, err := readUint32(r)
+	if err != nil {
+		return nil, err
+	}
+	// the values are read from the pushed blob, so bound them by the declared section size
+	if count > size/minExportSize {
+		return nil, fmt.Errorf("invalid count of exports: %d, the size of the export section is %d", count, size)
+	}
+	exports := []*Export{}
+	for i := uint32(0); i < count; i++ {
+		length, err := readUint32(r)
+		if err != nil {
+			return nil, err
+		}
+		if length > maxExportNameLength || length > size {
+			return nil, fmt.Errorf("invalid length of the export name: %d", length)
+		}
+		name := make([]byte, length)
+		for j := range name {
+			if name[j], err = r.ReadByte(); err != nil {
+				return nil, err
+			}
+		}
+		kind, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		// the index of the exported item
+		if _, err = readUint32(r); err != nil {
+			return nil, err
+		}
+		k, ok := exportKinds[kind]
+		if !ok {
+			k = "unknown"
+		}
+		exports = append(exports, &Export{Name: string(name), Kind: k})
+	}
+	return exports, nil
+}
+
+// read the unsigned LEB128 encoded integer
+func readUint32(r io.ByteReader) (uint32, error) {
+	var result uint32
+	for shift := uint(0); shift < 35; shift += 7 {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		result |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return result, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid LEB128 encoded integer")
+}
+
+func skip(r io.ByteReader, n uint32) error {
+	if reader, ok := r.(io.Reader); ok {
+		_, err := io.CopyN(ioutil.Discard, reader, int64(n))
+		return err
+	}
+	for i := uint32(0); i < n; i++ {
+		if _, err := r.ReadByte(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
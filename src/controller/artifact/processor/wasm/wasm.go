@@ -0,0 +1,93 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wasm
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+
+	ps "github.com/goharbor/harbor/src/controller/artifact/processor"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/base"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// const definitions
+const (
+	// ArtifactTypeWASM defines the artifact type for WebAssembly module
+	ArtifactTypeWASM = "WASM"
+
+	// the media types defined by the wasm-to-oci tool
+	mediaTypeConfigV1 = "application/vnd.wasm.config.v1+json"
+	mediaTypeLayerV1  = "application/vnd.wasm.content.layer.v1+wasm"
+	// the media types defined by the CNCF TAG Runtime WASM OCI artifact layout
+	mediaTypeConfigV0 = "application/vnd.wasm.config.v0+json"
+	mediaTypeLayerV0  = "application/wasm"
+)
+
+func init() {
+	pc := &processor{
+		ManifestProcessor: base.NewManifestProcessor(),
+	}
+	if err := ps.Register(pc, mediaTypeConfigV1, mediaTypeConfigV0); err != nil {
+		log.Errorf("failed to register processor for WASM media types: %v", err)
+		return
+	}
+}
+
+type processor struct {
+	*base.ManifestProcessor
+}
+
+func (p *processor) AbstractMetadata(ctx context.Context, art *artifact.Artifact, manifest []byte) error {
+	// populate all attributes in the config layer
+	if err := p.ManifestProcessor.AbstractMetadata(ctx, art, manifest); err != nil {
+		return err
+	}
+	if art.ExtraAttrs == nil {
+		art.ExtraAttrs = map[string]interface{}{}
+	}
+
+	mani := &v1.Manifest{}
+	if err := json.Unmarshal(manifest, mani); err != nil {
+		return err
+	}
+	for _, layer := range mani.Layers {
+		if layer.MediaType != mediaTypeLayerV1 && layer.MediaType != mediaTypeLayerV0 {
+			continue
+		}
+		_, blob, err := p.RegCli.PullBlob(art.RepositoryName, layer.Digest.String())
+		if err != nil {
+			return err
+		}
+		exports, err := parseExports(bufio.NewReader(blob))
+		blob.Close()
+		if err != nil {
+			// the module may use the newer binary format which isn't supported, don't block the pushing
+			log.G(ctx).Warningf("failed to parse the exports of WASM module %s@%s: %v", art.RepositoryName, layer.Digest, err)
+			return nil
+		}
+		art.ExtraAttrs["exports"] = exports
+		// only one module is contained in the artifact
+		return nil
+	}
+	return nil
+}
+
+func (p *processor) GetArtifactType(ctx context.Context, artifact *artifact.Artifact) string {
+	return ArtifactTypeWASM
+}
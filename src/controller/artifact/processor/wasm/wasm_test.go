@@ -0,0 +1,94 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wasm
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/goharbor/harbor/src/controller/artifact/processor/base"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/testing/pkg/registry"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	wasmManifest = `{"schemaVersion":2,"config":{"mediaType":"application/vnd.wasm.config.v0+json","digest":"sha256:76a59ebef39013bf7b57e411629b569a5175590024f31eeaaa577a0f8da9e523","size":60},"layers":[{"mediaType":"application/wasm","digest":"sha256:0bd64cfb958b68c71b46597e22185a41e784dc96e04090bc7d2a480b704c3b65","size":44}]}`
+	wasmConfig   = `{"architecture":"wasm","os":"wasip1","created":"2023-01-01T00:00:00Z"}`
+	// a module exports the function "add" and the memory "memory"
+	wasmModule = []byte{
+		0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, // header
+		0x01, 0x04, 0x01, 0x60, 0x00, 0x00, // type section
+		0x03, 0x02, 0x01, 0x00, // function section
+		0x05, 0x03, 0x01, 0x00, 0x01, // memory section
+		0x07, 0x10, 0x02, 0x03, 'a', 'd', 'd', 0x00, 0x00, 0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00, // export section
+		0x0a, 0x04, 0x01, 0x02, 0x00, 0x0b, // code section
+	}
+)
+
+type processorTestSuite struct {
+	suite.Suite
+	processor *processor
+	regCli    *registry.FakeClient
+}
+
+func (p *processorTestSuite) SetupTest() {
+	p.regCli = &registry.FakeClient{}
+	p.processor = &processor{}
+	p.processor.ManifestProcessor = &base.ManifestProcessor{RegCli: p.regCli}
+}
+
+func (p *processorTestSuite) TestParseExports() {
+	exports, err := parseExports(bytes.NewReader(wasmModule))
+	p.Require().Nil(err)
+	p.Require().Len(exports, 2)
+	p.Equal(&Export{Name: "add", Kind: "func"}, exports[0])
+	p.Equal(&Export{Name: "memory", Kind: "memory"}, exports[1])
+
+	// invalid module
+	_, err = parseExports(bytes.NewReader([]byte("invalid module")))
+	p.NotNil(err)
+
+	header := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	// the length of the name(4 GiB - 1) exceeds the size of the section
+	_, err = parseExports(bytes.NewReader(append(header, 0x07, 0x08, 0x01, 0xff, 0xff, 0xff, 0xff, 0x0f, 0x00, 0x00)))
+	p.NotNil(err)
+
+	// the count of exports exceeds the size of the section
+	_, err = parseExports(bytes.NewReader(append(header, 0x07, 0x05, 0xff, 0xff, 0xff, 0xff, 0x0f)))
+	p.NotNil(err)
+}
+
+func (p *processorTestSuite) TestAbstractMetadata() {
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(strings.NewReader(wasmConfig)), nil).Once()
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(bytes.NewReader(wasmModule)), nil).Once()
+	art := &artifact.Artifact{}
+	err := p.processor.AbstractMetadata(nil, art, []byte(wasmManifest))
+	p.Require().Nil(err)
+	p.Equal("wasip1", art.ExtraAttrs["os"])
+	exports, ok := art.ExtraAttrs["exports"].([]*Export)
+	p.Require().True(ok)
+	p.Len(exports, 2)
+}
+
+func (p *processorTestSuite) TestGetArtifactType() {
+	p.Assert().Equal(ArtifactTypeWASM, p.processor.GetArtifactType(nil, nil))
+}
+
+func TestProcessorTestSuite(t *testing.T) {
+	suite.Run(t, &processorTestSuite{})
+}
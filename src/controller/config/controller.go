@@ -19,14 +19,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 
 	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/controller/event/operator"
 	"github.com/goharbor/harbor/src/lib/config"
-	"github.com/goharbor/harbor/src/lib/config/metadata"
+	cfgMetadata "github.com/goharbor/harbor/src/lib/config/metadata"
 	"github.com/goharbor/harbor/src/lib/config/models"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/audit/forward"
+	"github.com/goharbor/harbor/src/pkg/notification"
 	"github.com/goharbor/harbor/src/pkg/user"
 )
 
@@ -88,13 +95,47 @@ func (c *controller) UpdateUserConfigs(ctx context.Context, conf map[string]inte
 	if err != nil {
 		return err
 	}
+	changes := c.changes(ctx, conf)
 	if err := mgr.UpdateConfig(ctx, conf); err != nil {
 		log.Errorf("failed to upload configurations: %v", err)
 		return fmt.Errorf("failed to validate configuration")
 	}
+	if len(changes) > 0 {
+		notification.AddEvent(ctx, &metadata.UpdateConfigEventMetadata{
+			Changes:  changes,
+			Operator: operator.FromContext(ctx),
+		})
+	}
 	return nil
 }
 
+// changes returns the configuration items whose values will be changed by the update
+func (c *controller) changes(ctx context.Context, conf map[string]interface{}) []*event.ConfigChange {
+	mgr := config.GetCfgManager(ctx)
+	var changes []*event.ConfigChange
+	for key, val := range conf {
+		item, ok := cfgMetadata.Instance().GetByName(key)
+		if !ok {
+			continue
+		}
+		newValue := utils.GetStrValueOfAnyType(val)
+		oldValue := mgr.Get(ctx, key).GetString()
+		if oldValue == newValue {
+			continue
+		}
+		change := &event.ConfigChange{Key: key}
+		if _, ok := item.ItemType.(*cfgMetadata.PasswordType); ok {
+			change.Sensitive = true
+		} else {
+			change.OldValue = oldValue
+			change.NewValue = newValue
+		}
+		changes = append(changes, change)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
 func (c *controller) validateCfg(ctx context.Context, cfgs map[string]interface{}) error {
 	mgr := config.GetCfgManager(ctx)
 
@@ -112,6 +153,15 @@ func (c *controller) validateCfg(ctx context.Context, cfgs map[string]interface{
 		}
 	}
 
+	// check if the audit log forward endpoint is supported
+	if nv, ok := cfgs[common.AuditLogForwardEndpoint]; ok {
+		if endpoint, _ := nv.(string); len(endpoint) > 0 {
+			if err := forward.ValidateEndpoint(endpoint); err != nil {
+				return err
+			}
+		}
+	}
+
 	err := mgr.ValidateCfg(ctx, cfgs)
 	if err != nil {
 		return errors.BadRequestError(err)
@@ -129,7 +179,7 @@ type ScanAllPolicy struct {
 func (c *controller) ConvertForGet(ctx context.Context, cfg map[string]interface{}, internal bool) (map[string]*models.Value, error) {
 	result := map[string]*models.Value{}
 
-	mList := metadata.Instance().GetAll()
+	mList := cfgMetadata.Instance().GetAll()
 
 	for _, item := range mList {
 		val, exist := cfg[item.Name]
@@ -139,14 +189,14 @@ func (c *controller) ConvertForGet(ctx context.Context, cfg map[string]interface
 		}
 
 		switch item.ItemType.(type) {
-		case *metadata.PasswordType:
+		case *cfgMetadata.PasswordType:
 			// remove password for external api call
 			if !internal {
 				delete(cfg, item.Name)
 				continue
 			}
-		case *metadata.MapType, *metadata.StringToStringMapType:
-			// convert to string for map type
+		case *cfgMetadata.MapType, *cfgMetadata.StringToStringMapType, *cfgMetadata.ListType, *cfgMetadata.ProjectRequestApprovalRulesType:
+			// convert to string for map and list type
 			valByte, err := json.Marshal(val)
 			if err != nil {
 				return nil, err
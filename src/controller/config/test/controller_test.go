@@ -18,9 +18,11 @@ import (
 	"context"
 	"github.com/goharbor/harbor/src/common"
 	. "github.com/goharbor/harbor/src/controller/config"
+	"github.com/goharbor/harbor/src/controller/event/metadata"
 	"github.com/goharbor/harbor/src/lib/errors"
 	_ "github.com/goharbor/harbor/src/pkg/config/db"
 	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
+	"github.com/goharbor/harbor/src/pkg/notification"
 
 	htesting "github.com/goharbor/harbor/src/testing"
 	"github.com/stretchr/testify/suite"
@@ -98,6 +100,14 @@ func (c *controllerTestSuite) TestConvertForGet() {
 	_, exist2 := resp2["ldap_search_password"]
 	c.True(exist2)
 
+	// list type should be converted to string
+	conf3 := map[string]interface{}{
+		"auth_mode":                      "db_auth",
+		"project_request_approval_rules": []interface{}{},
+	}
+	resp3, err3 := c.controller.ConvertForGet(ctx, conf3, false)
+	c.Nil(err3)
+	c.Equal("[]", resp3["project_request_approval_rules"].Val)
 }
 
 func (c *controllerTestSuite) TestGetAll() {
@@ -132,6 +142,29 @@ func (c *controllerTestSuite) TestUpdateUserCfg() {
 	c.True(errors.IsErr(err2, errors.BadRequestCode))
 }
 
+func (c *controllerTestSuite) TestUpdateUserCfgEvent() {
+	c.Require().Nil(c.controller.UpdateUserConfigs(ctx, map[string]interface{}{common.LDAPSearchPwd: "old"}))
+
+	evtCtx := notification.NewEventCtx()
+	userConf := map[string]interface{}{
+		common.LDAPBaseDN:    "dc=event,dc=com",
+		common.LDAPURL:       "ldap.example.com",
+		common.LDAPSearchPwd: "secret",
+	}
+	err := c.controller.UpdateUserConfigs(notification.NewContext(ctx, evtCtx), userConf)
+	c.Require().Nil(err)
+	c.Require().Equal(1, evtCtx.Events.Len())
+	m, ok := evtCtx.Events.Front().Value.(*metadata.UpdateConfigEventMetadata)
+	c.Require().True(ok)
+	// the unchanged ldap_url is not recorded
+	c.Require().Len(m.Changes, 2)
+	c.Equal(common.LDAPBaseDN, m.Changes[0].Key)
+	c.Equal("dc=event,dc=com", m.Changes[0].NewValue)
+	c.Equal(common.LDAPSearchPwd, m.Changes[1].Key)
+	c.True(m.Changes[1].Sensitive)
+	c.Empty(m.Changes[1].NewValue)
+}
+
 /*func (c *controllerTestSuite) TestCheckUnmodifiable() {
 	conf := map[string]interface{}{
 		"ldap_url":     "ldaps.myexample,com",
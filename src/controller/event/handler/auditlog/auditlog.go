@@ -17,8 +17,10 @@ package auditlog
 import (
 	"context"
 	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/audit"
+	"github.com/goharbor/harbor/src/pkg/audit/forward"
 	am "github.com/goharbor/harbor/src/pkg/audit/model"
 )
 
@@ -31,6 +33,11 @@ type AuditResolver interface {
 	ResolveToAuditLog() (*am.AuditLog, error)
 }
 
+// AuditsResolver - interface to resolve to multiple AuditLogs
+type AuditsResolver interface {
+	ResolveToAuditLogs() ([]*am.AuditLog, error)
+}
+
 // Name ...
 func (h *Handler) Name() string {
 	return "AuditLog"
@@ -38,30 +45,78 @@ func (h *Handler) Name() string {
 
 // Handle ...
 func (h *Handler) Handle(ctx context.Context, value interface{}) error {
-	var auditLog *am.AuditLog
+	var auditLogs []*am.AuditLog
 	switch v := value.(type) {
 	case *event.PushArtifactEvent, *event.PullArtifactEvent, *event.DeleteArtifactEvent,
 		*event.DeleteRepositoryEvent, *event.CreateProjectEvent, *event.DeleteProjectEvent,
-		*event.DeleteTagEvent, *event.CreateTagEvent:
-		resolver := value.(AuditResolver)
-		al, err := resolver.ResolveToAuditLog()
+		*event.DeleteTagEvent, *event.CreateTagEvent, *event.CreateRequestEvent,
+		*event.ApproveRequestEvent, *event.RejectRequestEvent, *event.DeleteRequestEvent,
+		*event.UpdateConfigEvent:
+		als, err := resolve(value)
+		if err != nil {
+			log.Errorf("failed to handler event %v", err)
+			return err
+		}
+		auditLogs = als
+	case *event.FailedOperationEvent:
+		// only the events which can be resolved to audit logs are recorded for the failed operations
+		als, err := resolve(v.Event)
 		if err != nil {
 			log.Errorf("failed to handler event %v", err)
 			return err
 		}
-		auditLog = al
+		auditLogs = als
+		value = v.Event
 	default:
 		log.Errorf("Can not handler this event type! %#v", v)
 	}
-	if auditLog != nil {
-		_, err := audit.Mgr.Create(ctx, auditLog)
-		if err != nil {
-			log.Debugf("add audit log err: %v", err)
+
+	var meta *event.RequestMeta
+	if getter, ok := value.(interface{ GetRequestMeta() *event.RequestMeta }); ok {
+		meta = getter.GetRequestMeta()
+	}
+	for _, auditLog := range auditLogs {
+		auditLog.Success = true
+		if meta != nil {
+			auditLog.RequestID = meta.ID
+			auditLog.ClientIP = meta.ClientIP
+			auditLog.UserAgent = meta.UserAgent
+			auditLog.AuthMethod = meta.AuthMethod
+			auditLog.Success = !meta.Failed
+		}
+		forwarding := len(config.AuditLogForwardEndpoint(ctx)) > 0
+		// the audit logs are always persisted into database when they're not forwarded
+		if !forwarding || !config.SkipAuditLogDatabase(ctx) {
+			_, err := audit.Mgr.Create(ctx, auditLog)
+			if err != nil {
+				log.Debugf("add audit log err: %v", err)
+			}
+		}
+		if forwarding {
+			if err := forward.Fwd.Forward(ctx, auditLog); err != nil {
+				log.Errorf("failed to forward audit log: %v", err)
+			}
 		}
 	}
 	return nil
 }
 
+func resolve(value interface{}) ([]*am.AuditLog, error) {
+	switch resolver := value.(type) {
+	case AuditResolver:
+		al, err := resolver.ResolveToAuditLog()
+		if err != nil {
+			return nil, err
+		}
+		return []*am.AuditLog{al}, nil
+	case AuditsResolver:
+		return resolver.ResolveToAuditLogs()
+	default:
+		log.Debugf("the event %#v can not be resolved to audit log", value)
+		return nil, nil
+	}
+}
+
 // IsStateful ...
 func (h *Handler) IsStateful() bool {
 	return false
@@ -11,6 +11,7 @@ import (
 	"github.com/goharbor/harbor/src/controller/event/handler/webhook/artifact"
 	"github.com/goharbor/harbor/src/controller/event/handler/webhook/chart"
 	"github.com/goharbor/harbor/src/controller/event/handler/webhook/quota"
+	"github.com/goharbor/harbor/src/controller/event/handler/webhook/request"
 	"github.com/goharbor/harbor/src/controller/event/handler/webhook/scan"
 	"github.com/goharbor/harbor/src/controller/event/metadata"
 	"github.com/goharbor/harbor/src/jobservice/job"
@@ -35,6 +36,9 @@ func init() {
 	notifier.Subscribe(event.TopicDeleteArtifact, &scan.DelArtHandler{})
 	notifier.Subscribe(event.TopicReplication, &artifact.ReplicationHandler{})
 	notifier.Subscribe(event.TopicTagRetention, &artifact.RetentionHandler{})
+	notifier.Subscribe(event.TopicCreateRequest, &request.Handler{})
+	notifier.Subscribe(event.TopicApproveRequest, &request.Handler{})
+	notifier.Subscribe(event.TopicRejectRequest, &request.Handler{})
 
 	// replication
 	notifier.Subscribe(event.TopicPushArtifact, &replication.Handler{})
@@ -56,6 +60,12 @@ func init() {
 	notifier.Subscribe(event.TopicDeleteRepository, &auditlog.Handler{})
 	notifier.Subscribe(event.TopicCreateTag, &auditlog.Handler{})
 	notifier.Subscribe(event.TopicDeleteTag, &auditlog.Handler{})
+	notifier.Subscribe(event.TopicCreateRequest, &auditlog.Handler{})
+	notifier.Subscribe(event.TopicApproveRequest, &auditlog.Handler{})
+	notifier.Subscribe(event.TopicRejectRequest, &auditlog.Handler{})
+	notifier.Subscribe(event.TopicDeleteRequest, &auditlog.Handler{})
+	notifier.Subscribe(event.TopicUpdateConfig, &auditlog.Handler{})
+	notifier.Subscribe(event.TopicFailedOperation, &auditlog.Handler{})
 
 	// internal
 	notifier.Subscribe(event.TopicPullArtifact, &internal.Handler{})
@@ -16,21 +16,19 @@ package internal
 
 import (
 	"context"
-	"fmt"
-	"github.com/goharbor/harbor/src/common/utils/email"
-	"github.com/goharbor/harbor/src/lib/config"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/event"
-	event2 "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/event/handler/webhook/request"
 	"github.com/goharbor/harbor/src/controller/repository"
 	"github.com/goharbor/harbor/src/controller/tag"
 	"github.com/goharbor/harbor/src/controller/user"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	notifierEvent "github.com/goharbor/harbor/src/pkg/notifier/event"
+	notifierModel "github.com/goharbor/harbor/src/pkg/notifier/model"
 )
 
 // Handler preprocess artifact event data
@@ -112,56 +110,42 @@ func (a *Handler) onPush(ctx context.Context, event *event.ArtifactEvent) error
 }
 
 func (a *Handler) onApprove(ctx context.Context, event *event.RequestEvent) error {
-	go func() {
-		if err := a.sendMail(ctx, event); err != nil {
-			log.Errorf("send mail %s@%s failed, error: %v", event.EventType, event.Project, err)
-		}
-	}()
-
+	if err := a.sendMail(ctx, event); err != nil {
+		log.Errorf("send mail %s@%s failed, error: %v", event.EventType, event.Project, err)
+	}
 	return nil
 }
 
 func (a *Handler) onReject(ctx context.Context, event *event.RequestEvent) error {
-	go func() {
-		if err := a.sendMail(ctx, event); err != nil {
-			log.Errorf("send mail %s@%s failed, error: %v", event.EventType, event.Project, err)
-		}
-	}()
-
+	if err := a.sendMail(ctx, event); err != nil {
+		log.Errorf("send mail %s@%s failed, error: %v", event.EventType, event.Project, err)
+	}
 	return nil
 }
 
+// sendMail notifies the requester the decision of the request by the email job, which is retried when failed
 func (a *Handler) sendMail(ctx context.Context, event *event.RequestEvent) error {
-	meta, err := config.Email(ctx)
-	if err != nil {
-		return err
-	}
-
-	addr := strings.TrimSpace(strings.Join([]string{meta.Host, strconv.Itoa(meta.Port)}, ":"))
 	owner, err := user.Ctl.Get(ctx, event.OwnerID, &user.Option{})
 	if err != nil {
 		log.Errorf("cannot get (%d)'s user mail info\n", event.OwnerID)
 		return err
 	}
-
-	log.Infof("host: %s/ identity: %s/ user: %s/ password: *****/ ssl: %v/ insecure: %v/ from: %s/ to: %s\n",
-		addr, meta.Identity, meta.Username, meta.SSL, meta.Insecure, meta.From, owner.Email)
-
-	var subject, message string
-	switch event.EventType {
-	case event2.TopicApproveRequest:
-		subject = fmt.Sprintf("[HyperRegistry] Approved request")
-		message = fmt.Sprintf("Hey %s! The project named %s has been created by request.", owner.Username, event.Project)
-	case event2.TopicRejectRequest:
-		subject = fmt.Sprintf("[HyperRegistry] Rejected request")
-		message = fmt.Sprintf("Sorry %s. Please contact admin %s.", owner.Username, event.Operator)
-	default:
-		log.Errorf("undefined event type: %s", event.EventType)
+	if len(owner.Email) == 0 {
+		log.Debugf("the email of user %s is empty, skip sending mail", owner.Username)
 		return nil
 	}
 
-	err = email.Send(addr, meta.Identity, meta.Username, meta.Password, 5, meta.SSL, meta.Insecure, meta.From,
-		[]string{owner.Email}, subject, message)
-
-	return err
+	evt := &notifierEvent.Event{}
+	hookMetadata := &notifierEvent.HookMetaData{
+		EventType: event.EventType,
+		Payload:   request.ConstructRequestPayload(event),
+		Target: &policy_model.EventTarget{
+			Type:    notifierModel.NotifyTypeEmail,
+			Address: owner.Email,
+		},
+	}
+	if err := evt.Build(hookMetadata); err != nil {
+		return err
+	}
+	return evt.Publish()
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/goharbor/harbor/src/controller/replication"
+	"github.com/goharbor/harbor/src/controller/replication/flow"
 	repctlmodel "github.com/goharbor/harbor/src/controller/replication/model"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/reg/filter"
@@ -73,9 +74,14 @@ func getRelatedPolicies(ctx context.Context, resource *model.Resource) ([]*repct
 		if !policy.Enabled {
 			continue
 		}
-		// currently, the events are produced only by local Harbor,
-		// so they should only apply to the policies whose source registry is local Harbor
-		if !(policy.SrcRegistry == nil || policy.SrcRegistry.ID == 0) {
+		if policy.Sync {
+			// the artifacts pushed into local Harbor by the sync policy itself are not synced back
+			if !resource.Deleted && flow.IsSynced(policy.ID, resource) {
+				continue
+			}
+		} else if !(policy.SrcRegistry == nil || policy.SrcRegistry.ID == 0) {
+			// currently, the events are produced only by local Harbor, so they should only apply
+			// to the policies whose source registry is local Harbor except the sync policies
 			continue
 		}
 		// has no trigger
@@ -0,0 +1,100 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goharbor/harbor/src/controller/replication"
+	"github.com/goharbor/harbor/src/controller/replication/flow"
+	repctlmodel "github.com/goharbor/harbor/src/controller/replication/model"
+	"github.com/goharbor/harbor/src/lib/cache"
+	_ "github.com/goharbor/harbor/src/lib/cache/memory"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	testingrep "github.com/goharbor/harbor/src/testing/controller/replication"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRelatedPolicies(t *testing.T) {
+	ctl := replication.Ctl
+	defer func() { replication.Ctl = ctl }()
+	mockCtl := &testingrep.Controller{}
+	replication.Ctl = mockCtl
+	require.Nil(t, cache.Initialize(cache.Memory, ""))
+
+	trigger := &model.Trigger{Type: model.TriggerTypeEventBased}
+	mockCtl.On("ListPolicies", mock.Anything, mock.Anything).Return([]*repctlmodel.Policy{
+		// push-based
+		{
+			ID:           1,
+			Enabled:      true,
+			SrcRegistry:  &model.Registry{ID: 0},
+			DestRegistry: &model.Registry{ID: 1},
+			Trigger:      trigger,
+		},
+		// pull-based
+		{
+			ID:           2,
+			Enabled:      true,
+			SrcRegistry:  &model.Registry{ID: 1},
+			DestRegistry: &model.Registry{ID: 0},
+			Trigger:      trigger,
+		},
+		// sync whose destination is local Harbor
+		{
+			ID:           3,
+			Enabled:      true,
+			SrcRegistry:  &model.Registry{ID: 1},
+			DestRegistry: &model.Registry{ID: 0},
+			Trigger:      trigger,
+			Sync:         true,
+		},
+	}, nil)
+
+	resource := &model.Resource{
+		Type: model.ResourceTypeArtifact,
+		Metadata: &model.ResourceMetadata{
+			Repository: &model.Repository{
+				Name: "library/hello-world",
+			},
+			Artifacts: []*model.Artifact{
+				{
+					Digest: "sha256:1",
+					Tags:   []string{"latest"},
+				},
+			},
+		},
+	}
+	policies, err := getRelatedPolicies(context.Background(), resource)
+	require.Nil(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, int64(1), policies[0].ID)
+	assert.Equal(t, int64(3), policies[1].ID)
+
+	// the artifact pushed by the sync policy itself isn't synced back, but the other policies still apply
+	flow.MarkSynced(3, resource)
+	policies, err = getRelatedPolicies(context.Background(), resource)
+	require.Nil(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, int64(1), policies[0].ID)
+
+	// the mark is consumed, the later pushes of the same artifact are synced
+	policies, err = getRelatedPolicies(context.Background(), resource)
+	require.Nil(t, err)
+	require.Len(t, policies, 2)
+}
@@ -0,0 +1,114 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/event/handler/util"
+	evtModel "github.com/goharbor/harbor/src/controller/event/model"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	notifyModel "github.com/goharbor/harbor/src/pkg/notifier/model"
+)
+
+// Handler preprocess project request event data
+type Handler struct {
+}
+
+// Name ...
+func (r *Handler) Name() string {
+	return "RequestWebhook"
+}
+
+// Handle ...
+func (r *Handler) Handle(ctx context.Context, value interface{}) error {
+	var requestEvent *event.RequestEvent
+	switch v := value.(type) {
+	case *event.CreateRequestEvent:
+		requestEvent = v.RequestEvent
+	case *event.ApproveRequestEvent:
+		requestEvent = v.RequestEvent
+	case *event.RejectRequestEvent:
+		requestEvent = v.RequestEvent
+	default:
+		return errors.New("invalid request event type")
+	}
+	if requestEvent == nil {
+		return fmt.Errorf("nil request event")
+	}
+
+	// the project doesn't exist before the request is approved, so the request events are
+	// sent by the policies of all the projects which subscribe them
+	policies, err := relatedPolicies(ctx, requestEvent.EventType)
+	if err != nil {
+		log.Errorf("failed to find policy for %s event: %v", requestEvent.EventType, err)
+		return err
+	}
+	if len(policies) == 0 {
+		log.Debugf("cannot find policy for %s event: %v", requestEvent.EventType, requestEvent)
+		return nil
+	}
+
+	payload := ConstructRequestPayload(requestEvent)
+	return util.SendHookWithPolicies(policies, payload, requestEvent.EventType)
+}
+
+// IsStateful ...
+func (r *Handler) IsStateful() bool {
+	return false
+}
+
+// relatedPolicies returns the enabled policies including the event type in all the projects
+func relatedPolicies(ctx context.Context, eventType string) ([]*policy_model.Policy, error) {
+	policies, err := notification.PolicyMgr.List(ctx, q.New(q.KeyWords{"enabled": true}))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*policy_model.Policy
+	for _, ply := range policies {
+		for _, t := range ply.EventTypes {
+			if t == eventType {
+				result = append(result, ply)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// ConstructRequestPayload constructs the notification payload of the project request event
+func ConstructRequestPayload(event *event.RequestEvent) *notifyModel.Payload {
+	return &notifyModel.Payload{
+		Type:     event.EventType,
+		OccurAt:  event.OccurAt.Unix(),
+		Operator: event.Operator,
+		EventData: &notifyModel.EventData{
+			Request: &evtModel.ProjectRequest{
+				RequestID:    event.RequestID,
+				ProjectName:  event.Project,
+				Requester:    event.Requester,
+				StorageQuota: event.StorageQuota,
+				Reason:       event.Reason,
+			},
+		},
+	}
+}
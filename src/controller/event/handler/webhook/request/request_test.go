@@ -0,0 +1,145 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/common"
+	common_dao "github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/lib/config"
+	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notification/policy"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	"github.com/goharbor/harbor/src/pkg/notifier"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	testing_notification "github.com/goharbor/harbor/src/testing/pkg/notification/policy"
+	"github.com/stretchr/testify/suite"
+)
+
+// RequestHandlerSuite ...
+type RequestHandlerSuite struct {
+	suite.Suite
+	om      policy.Manager
+	mgr     *testing_notification.Manager
+	handler *MockHandler
+}
+
+// TestRequestHandler ...
+func TestRequestHandler(t *testing.T) {
+	suite.Run(t, &RequestHandlerSuite{})
+}
+
+// SetupSuite prepares env for test suite.
+func (suite *RequestHandlerSuite) SetupSuite() {
+	common_dao.PrepareTestForPostgresSQL()
+	config.InitWithSettings(map[string]interface{}{
+		common.NotificationEnable: true,
+	})
+
+	suite.om = notification.PolicyMgr
+	suite.mgr = &testing_notification.Manager{}
+	notification.PolicyMgr = suite.mgr
+	suite.mgr.On("List", mock.Anything, mock.Anything).Return([]*policy_model.Policy{
+		{
+			ID:         1,
+			EventTypes: []string{event.TopicCreateRequest},
+			Targets:    []policy_model.EventTarget{{Type: model.WebhookTopic, Address: "http://127.0.0.1:8080"}},
+			Enabled:    true,
+		},
+		{
+			ID:         2,
+			EventTypes: []string{event.TopicPushArtifact},
+			Targets:    []policy_model.EventTarget{{Type: model.WebhookTopic, Address: "http://127.0.0.1:8080"}},
+			Enabled:    true,
+		},
+	}, nil)
+
+	suite.handler = &MockHandler{}
+	suite.Require().NoError(notifier.Subscribe(model.WebhookTopic, suite.handler))
+}
+
+// TearDownSuite ...
+func (suite *RequestHandlerSuite) TearDownSuite() {
+	notification.PolicyMgr = suite.om
+	notifier.UnSubscribe(model.WebhookTopic, suite.handler.Name())
+}
+
+// TestHandle ...
+func (suite *RequestHandlerSuite) TestHandle() {
+	handler := &Handler{}
+
+	suite.Error(handler.Handle(context.TODO(), &event.QuotaEvent{}))
+
+	evt := &event.CreateRequestEvent{
+		RequestEvent: &event.RequestEvent{
+			EventType:    event.TopicCreateRequest,
+			RequestID:    1,
+			Project:      "library",
+			Requester:    "dev",
+			StorageQuota: 1024,
+			OccurAt:      time.Now(),
+		},
+	}
+	suite.NoError(handler.Handle(context.TODO(), evt))
+
+	policies, err := relatedPolicies(context.TODO(), event.TopicCreateRequest)
+	suite.Require().NoError(err)
+	suite.Require().Len(policies, 1)
+	suite.Equal(int64(1), policies[0].ID)
+}
+
+// TestConstructRequestPayload ...
+func (suite *RequestHandlerSuite) TestConstructRequestPayload() {
+	payload := ConstructRequestPayload(&event.RequestEvent{
+		EventType:    event.TopicRejectRequest,
+		RequestID:    1,
+		Project:      "library",
+		Requester:    "dev",
+		StorageQuota: 1024,
+		Reason:       "name is reserved",
+		Operator:     "admin",
+		OccurAt:      time.Now(),
+	})
+	suite.Equal(event.TopicRejectRequest, payload.Type)
+	suite.Equal("admin", payload.Operator)
+	suite.Equal("library", payload.EventData.Request.ProjectName)
+	suite.Equal("dev", payload.EventData.Request.Requester)
+	suite.Equal(int64(1024), payload.EventData.Request.StorageQuota)
+	suite.Equal("name is reserved", payload.EventData.Request.Reason)
+}
+
+// MockHandler ...
+type MockHandler struct{}
+
+// Name ...
+func (m *MockHandler) Name() string {
+	return "RequestMock"
+}
+
+// Handle ...
+func (m *MockHandler) Handle(ctx context.Context, value interface{}) error {
+	return nil
+}
+
+// IsStateful ...
+func (m *MockHandler) IsStateful() bool {
+	return false
+}
@@ -0,0 +1,40 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"time"
+
+	event2 "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+)
+
+// UpdateConfigEventMetadata is the metadata from which the update config event can be resolved
+type UpdateConfigEventMetadata struct {
+	Changes  []*event2.ConfigChange
+	Operator string
+}
+
+// Resolve to the event from the metadata
+func (u *UpdateConfigEventMetadata) Resolve(event *event.Event) error {
+	event.Topic = event2.TopicUpdateConfig
+	event.Data = &event2.UpdateConfigEvent{
+		EventType: event2.TopicUpdateConfig,
+		Changes:   u.Changes,
+		Operator:  u.Operator,
+		OccurAt:   time.Now(),
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"testing"
+
+	event2 "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+	"github.com/stretchr/testify/suite"
+)
+
+type configEventTestSuite struct {
+	suite.Suite
+}
+
+func (c *configEventTestSuite) TestResolveOfUpdateConfigEventMetadata() {
+	e := &event.Event{}
+	metadata := &UpdateConfigEventMetadata{
+		Changes: []*event2.ConfigChange{
+			{Key: "auth_mode", OldValue: "db_auth", NewValue: "ldap_auth"},
+			{Key: "ldap_search_password", Sensitive: true},
+		},
+		Operator: "admin",
+	}
+	err := metadata.Resolve(e)
+	c.Require().Nil(err)
+	c.Equal(event2.TopicUpdateConfig, e.Topic)
+	c.Require().NotNil(e.Data)
+	data, ok := e.Data.(*event2.UpdateConfigEvent)
+	c.Require().True(ok)
+	c.Equal("admin", data.Operator)
+
+	logs, err := data.ResolveToAuditLogs()
+	c.Require().Nil(err)
+	c.Require().Len(logs, 2)
+	c.Equal("configuration", logs[0].ResourceType)
+	c.Equal("auth_mode", logs[0].Resource)
+	c.Equal("update", logs[0].Operation)
+	c.Equal("admin", logs[0].Username)
+	c.Equal(`changed from "db_auth" to "ldap_auth"`, logs[0].Reason)
+	c.Equal("ldap_search_password", logs[1].Resource)
+	c.Equal("value changed", logs[1].Reason)
+}
+
+func TestConfigEventTestSuite(t *testing.T) {
+	suite.Run(t, &configEventTestSuite{})
+}
@@ -20,12 +20,44 @@ import (
 	"time"
 )
 
+// CreateRequestEventMetadata is the metadata from which the create request event can be resolved
+type CreateRequestEventMetadata struct {
+	RequestID    int64
+	Project      string
+	OwnerID      int
+	Requester    string
+	StorageQuota int64
+	Operator     string
+}
+
+// Resolve to the event from the metadata
+func (m *CreateRequestEventMetadata) Resolve(event *event.Event) error {
+	event.Topic = event2.TopicCreateRequest
+	event.Data = &event2.CreateRequestEvent{
+		RequestEvent: &event2.RequestEvent{
+			EventType:    event2.TopicCreateRequest,
+			RequestID:    m.RequestID,
+			Project:      m.Project,
+			OwnerID:      m.OwnerID,
+			Requester:    m.Requester,
+			StorageQuota: m.StorageQuota,
+			Operator:     m.Operator,
+			OccurAt:      time.Now(),
+		},
+	}
+	return nil
+}
+
 // ApproveRequestEventMetadata is the metadata from which the create project event can be resolved
 type ApproveRequestEventMetadata struct {
-	ProjectID int64
-	Project   string
-	OwnerID   int
-	Operator  string
+	RequestID    int64
+	ProjectID    int64
+	Project      string
+	OwnerID      int
+	Requester    string
+	StorageQuota int64
+	Reason       string
+	Operator     string
 }
 
 // Resolve to the event from the metadata
@@ -33,11 +65,16 @@ func (m *ApproveRequestEventMetadata) Resolve(event *event.Event) error {
 	event.Topic = event2.TopicApproveRequest
 	event.Data = &event2.ApproveRequestEvent{
 		RequestEvent: &event2.RequestEvent{
-			EventType: event2.TopicApproveRequest,
-			Project:   m.Project,
-			OwnerID:   m.OwnerID,
-			Operator:  m.Operator,
-			OccurAt:   time.Now(),
+			EventType:    event2.TopicApproveRequest,
+			RequestID:    m.RequestID,
+			ProjectID:    m.ProjectID,
+			Project:      m.Project,
+			OwnerID:      m.OwnerID,
+			Requester:    m.Requester,
+			StorageQuota: m.StorageQuota,
+			Reason:       m.Reason,
+			Operator:     m.Operator,
+			OccurAt:      time.Now(),
 		},
 	}
 	return nil
@@ -45,10 +82,14 @@ func (m *ApproveRequestEventMetadata) Resolve(event *event.Event) error {
 
 // RejectRequestEventMetadata is the metadata from which the delete project event can be resolved
 type RejectRequestEventMetadata struct {
-	ProjectID int64
-	Project   string
-	OwnerID   int
-	Operator  string
+	RequestID    int64
+	ProjectID    int64
+	Project      string
+	OwnerID      int
+	Requester    string
+	StorageQuota int64
+	Reason       string
+	Operator     string
 }
 
 // Resolve to the event from the metadata
@@ -56,11 +97,43 @@ func (m *RejectRequestEventMetadata) Resolve(event *event.Event) error {
 	event.Topic = event2.TopicRejectRequest
 	event.Data = &event2.RejectRequestEvent{
 		RequestEvent: &event2.RequestEvent{
-			EventType: event2.TopicRejectRequest,
-			Project:   m.Project,
-			OwnerID:   m.OwnerID,
-			Operator:  m.Operator,
-			OccurAt:   time.Now(),
+			EventType:    event2.TopicRejectRequest,
+			RequestID:    m.RequestID,
+			Project:      m.Project,
+			OwnerID:      m.OwnerID,
+			Requester:    m.Requester,
+			StorageQuota: m.StorageQuota,
+			Reason:       m.Reason,
+			Operator:     m.Operator,
+			OccurAt:      time.Now(),
+		},
+	}
+	return nil
+}
+
+// DeleteRequestEventMetadata is the metadata from which the delete request event can be resolved
+type DeleteRequestEventMetadata struct {
+	RequestID    int64
+	Project      string
+	OwnerID      int
+	Requester    string
+	StorageQuota int64
+	Operator     string
+}
+
+// Resolve to the event from the metadata
+func (m *DeleteRequestEventMetadata) Resolve(event *event.Event) error {
+	event.Topic = event2.TopicDeleteRequest
+	event.Data = &event2.DeleteRequestEvent{
+		RequestEvent: &event2.RequestEvent{
+			EventType:    event2.TopicDeleteRequest,
+			RequestID:    m.RequestID,
+			Project:      m.Project,
+			OwnerID:      m.OwnerID,
+			Requester:    m.Requester,
+			StorageQuota: m.StorageQuota,
+			Operator:     m.Operator,
+			OccurAt:      time.Now(),
 		},
 	}
 	return nil
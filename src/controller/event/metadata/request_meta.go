@@ -0,0 +1,41 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	event2 "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+)
+
+// RequestMetaData attaches the metadata of the API request to the event resolved by the former metadata
+type RequestMetaData struct {
+	Meta *event2.RequestMeta
+}
+
+// Resolve to the event from the metadata
+func (r *RequestMetaData) Resolve(evt *event.Event) error {
+	if r.Meta == nil {
+		return nil
+	}
+	if setter, ok := evt.Data.(interface{ SetRequestMeta(*event2.RequestMeta) }); ok {
+		setter.SetRequestMeta(r.Meta)
+	}
+	// the events of the failed request are only delivered to the audit log
+	if r.Meta.Failed {
+		evt.Topic = event2.TopicFailedOperation
+		evt.Data = &event2.FailedOperationEvent{Event: evt.Data}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"testing"
+
+	event2 "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+	"github.com/stretchr/testify/suite"
+)
+
+type requestMetaDataTestSuite struct {
+	suite.Suite
+}
+
+func (r *requestMetaDataTestSuite) TestResolve() {
+	e := &event.Event{}
+	meta := &event2.RequestMeta{
+		ID:         "request-id",
+		ClientIP:   "10.0.0.1",
+		UserAgent:  "docker/20.10",
+		AuthMethod: "basic",
+	}
+	err := e.Build(&CreateProjectEventMetadata{
+		Project:  "library",
+		Operator: "admin",
+	}, &RequestMetaData{Meta: meta})
+	r.Require().Nil(err)
+	r.Equal(event2.TopicCreateProject, e.Topic)
+	data, ok := e.Data.(*event2.CreateProjectEvent)
+	r.Require().True(ok)
+	r.Equal("request-id", data.GetRequestMeta().ID)
+	r.Equal("10.0.0.1", data.ClientIP)
+	r.Equal("docker/20.10", data.UserAgent)
+	r.Equal("basic", data.AuthMethod)
+	r.False(data.Failed)
+}
+
+func (r *requestMetaDataTestSuite) TestResolveFailed() {
+	e := &event.Event{}
+	meta := &event2.RequestMeta{
+		ID:     "request-id",
+		Failed: true,
+	}
+	err := e.Build(&DeleteProjectEventMetadata{
+		Project:  "library",
+		Operator: "admin",
+	}, &RequestMetaData{Meta: meta})
+	r.Require().Nil(err)
+	r.Equal(event2.TopicFailedOperation, e.Topic)
+	data, ok := e.Data.(*event2.FailedOperationEvent)
+	r.Require().True(ok)
+	evt, ok := data.Event.(*event2.DeleteProjectEvent)
+	r.Require().True(ok)
+	r.Equal("library", evt.Project)
+	r.True(evt.Failed)
+}
+
+func TestRequestMetaDataTestSuite(t *testing.T) {
+	suite.Run(t, &requestMetaDataTestSuite{})
+}
@@ -0,0 +1,76 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"testing"
+
+	event2 "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+	"github.com/stretchr/testify/suite"
+)
+
+type requestEventTestSuite struct {
+	suite.Suite
+}
+
+func (r *requestEventTestSuite) TestResolveOfApproveRequestEventMetadata() {
+	e := &event.Event{}
+	metadata := &ApproveRequestEventMetadata{
+		RequestID: 1,
+		ProjectID: 2,
+		Project:   "library",
+		Reason:    "approved",
+		Operator:  "admin",
+	}
+	err := metadata.Resolve(e)
+	r.Require().Nil(err)
+	r.Equal(event2.TopicApproveRequest, e.Topic)
+	data, ok := e.Data.(*event2.ApproveRequestEvent)
+	r.Require().True(ok)
+
+	log, err := data.ResolveToAuditLog()
+	r.Require().Nil(err)
+	r.Equal(int64(2), log.ProjectID)
+	r.Equal("approve", log.Operation)
+	r.Equal("project_request", log.ResourceType)
+	r.Equal("library", log.Resource)
+	r.Equal("admin", log.Username)
+	r.Equal("approved", log.Reason)
+}
+
+func (r *requestEventTestSuite) TestResolveOfDeleteRequestEventMetadata() {
+	e := &event.Event{}
+	metadata := &DeleteRequestEventMetadata{
+		RequestID: 1,
+		Project:   "library",
+		Operator:  "admin",
+	}
+	err := metadata.Resolve(e)
+	r.Require().Nil(err)
+	r.Equal(event2.TopicDeleteRequest, e.Topic)
+	data, ok := e.Data.(*event2.DeleteRequestEvent)
+	r.Require().True(ok)
+	r.Equal("library", data.Project)
+
+	log, err := data.ResolveToAuditLog()
+	r.Require().Nil(err)
+	r.Equal("delete", log.Operation)
+	r.Equal("admin", log.Username)
+}
+
+func TestRequestEventTestSuite(t *testing.T) {
+	suite.Run(t, &requestEventTestSuite{})
+}
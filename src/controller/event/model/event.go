@@ -36,6 +36,15 @@ type ReplicationResource struct {
 	Namespace    string `json:"namespace,omitempty"`
 }
 
+// ProjectRequest describes the project request infos
+type ProjectRequest struct {
+	RequestID    int64  `json:"request_id"`
+	ProjectName  string `json:"project_name"`
+	Requester    string `json:"requester"`
+	StorageQuota int64  `json:"storage_quota"`
+	Reason       string `json:"reason,omitempty"`
+}
+
 // Retention describes tag retention infos
 type Retention struct {
 	Total             int              `json:"total"`
@@ -49,12 +49,47 @@ const (
 	TopicReplication     = "REPLICATION"
 	TopicArtifactLabeled = "ARTIFACT_LABELED"
 	TopicTagRetention    = "TAG_RETENTION"
+	TopicCreateRequest   = "CREATE_REQUEST"
 	TopicApproveRequest  = "APPROVE_REQUEST"
 	TopicRejectRequest   = "REJECT_REQUEST"
+	TopicDeleteRequest   = "DELETE_REQUEST"
+	TopicUpdateConfig    = "UPDATE_CONFIG"
+	// TopicFailedOperation is topic for the events fired during the processing of the failed requests,
+	// these events are not notified, but only recorded in the audit logs
+	TopicFailedOperation = "FAILED_OPERATION"
 )
 
+// RequestMeta is the metadata of the API request by which the event is fired
+type RequestMeta struct {
+	// the ID of the request, i.e. the X-Request-ID header
+	ID         string
+	ClientIP   string
+	UserAgent  string
+	AuthMethod string
+	// the request failed thus the event isn't notified
+	Failed bool
+}
+
+// SetRequestMeta sets the metadata of the API request
+func (r *RequestMeta) SetRequestMeta(meta *RequestMeta) {
+	if meta != nil {
+		*r = *meta
+	}
+}
+
+// GetRequestMeta returns the metadata of the API request
+func (r *RequestMeta) GetRequestMeta() *RequestMeta {
+	return r
+}
+
+// FailedOperationEvent wraps the event fired during the processing of the failed request
+type FailedOperationEvent struct {
+	Event interface{}
+}
+
 // CreateProjectEvent is the creating project event
 type CreateProjectEvent struct {
+	RequestMeta
 	EventType string
 	ProjectID int64
 	Project   string
@@ -81,6 +116,7 @@ func (c *CreateProjectEvent) String() string {
 
 // DeleteProjectEvent is the deleting project event
 type DeleteProjectEvent struct {
+	RequestMeta
 	EventType string
 	ProjectID int64
 	Project   string
@@ -107,6 +143,7 @@ func (d *DeleteProjectEvent) String() string {
 
 // DeleteRepositoryEvent is the deleting repository event
 type DeleteRepositoryEvent struct {
+	RequestMeta
 	EventType  string
 	ProjectID  int64
 	Repository string
@@ -134,6 +171,7 @@ func (d *DeleteRepositoryEvent) String() string {
 
 // ArtifactEvent is the pushing/pulling artifact event
 type ArtifactEvent struct {
+	RequestMeta
 	EventType  string
 	Repository string
 	Artifact   *artifact.Artifact
@@ -236,6 +274,7 @@ func (d *DeleteArtifactEvent) String() string {
 
 // CreateTagEvent is the creating tag event
 type CreateTagEvent struct {
+	RequestMeta
 	EventType        string
 	Repository       string
 	Tag              string
@@ -264,6 +303,7 @@ func (c *CreateTagEvent) String() string {
 
 // DeleteTagEvent is the deleting tag event
 type DeleteTagEvent struct {
+	RequestMeta
 	EventType        string
 	Repository       string
 	Tag              string
@@ -386,11 +426,19 @@ func (r *RetentionEvent) String() string {
 }
 
 type RequestEvent struct {
+	RequestMeta
 	EventType string
-	Project   string
-	Operator  string
-	OwnerID   int
-	OccurAt   time.Time
+	RequestID int64
+	// the ID of the created project, only set for the approving request event
+	ProjectID    int64
+	Project      string
+	Operator     string
+	OwnerID      int
+	Requester    string
+	StorageQuota int64
+	// the comment of the decision, empty for the creating request event
+	Reason  string
+	OccurAt time.Time
 }
 
 func (r *RequestEvent) String() string {
@@ -398,10 +446,40 @@ func (r *RequestEvent) String() string {
 		r.EventType, r.Project, r.OwnerID, r.Operator, r.OccurAt.Format("2006-01-02 15:04:05"))
 }
 
+func (r *RequestEvent) auditLog(operation string) *model.AuditLog {
+	return &model.AuditLog{
+		ProjectID:    r.ProjectID,
+		OpTime:       r.OccurAt,
+		Operation:    operation,
+		Username:     r.Operator,
+		ResourceType: "project_request",
+		Resource:     r.Project,
+		Reason:       r.Reason,
+	}
+}
+
+type CreateRequestEvent struct {
+	*RequestEvent
+}
+
+// ResolveToAuditLog ...
+func (r *CreateRequestEvent) ResolveToAuditLog() (*model.AuditLog, error) {
+	return r.auditLog("create"), nil
+}
+
+func (r *CreateRequestEvent) String() string {
+	return r.RequestEvent.String()
+}
+
 type ApproveRequestEvent struct {
 	*RequestEvent
 }
 
+// ResolveToAuditLog ...
+func (r *ApproveRequestEvent) ResolveToAuditLog() (*model.AuditLog, error) {
+	return r.auditLog("approve"), nil
+}
+
 func (r *ApproveRequestEvent) String() string {
 	return r.RequestEvent.String()
 }
@@ -410,6 +488,71 @@ type RejectRequestEvent struct {
 	*RequestEvent
 }
 
+// ResolveToAuditLog ...
+func (r *RejectRequestEvent) ResolveToAuditLog() (*model.AuditLog, error) {
+	return r.auditLog("reject"), nil
+}
+
 func (r *RejectRequestEvent) String() string {
 	return r.RequestEvent.String()
 }
+
+type DeleteRequestEvent struct {
+	*RequestEvent
+}
+
+// ResolveToAuditLog ...
+func (r *DeleteRequestEvent) ResolveToAuditLog() (*model.AuditLog, error) {
+	return r.auditLog("delete"), nil
+}
+
+func (r *DeleteRequestEvent) String() string {
+	return r.RequestEvent.String()
+}
+
+// ConfigChange is the change of a single configuration item
+type ConfigChange struct {
+	Key      string
+	OldValue string
+	NewValue string
+	// the values of the sensitive items, e.g. passwords, are not recorded
+	Sensitive bool
+}
+
+// UpdateConfigEvent is the updating system configurations event
+type UpdateConfigEvent struct {
+	RequestMeta
+	EventType string
+	Changes   []*ConfigChange
+	Operator  string
+	OccurAt   time.Time
+}
+
+// ResolveToAuditLogs resolves one audit log for each changed configuration item
+func (u *UpdateConfigEvent) ResolveToAuditLogs() ([]*model.AuditLog, error) {
+	var auditLogs []*model.AuditLog
+	for _, c := range u.Changes {
+		reason := "value changed"
+		if !c.Sensitive {
+			reason = fmt.Sprintf("changed from %q to %q", c.OldValue, c.NewValue)
+		}
+		auditLogs = append(auditLogs, &model.AuditLog{
+			OpTime:       u.OccurAt,
+			Operation:    "update",
+			Username:     u.Operator,
+			ResourceType: "configuration",
+			Resource:     c.Key,
+			Reason:       reason,
+		})
+	}
+	return auditLogs, nil
+}
+
+func (u *UpdateConfigEvent) String() string {
+	var keys []string
+	for _, c := range u.Changes {
+		keys = append(keys, c.Key)
+	}
+	return fmt.Sprintf("Keys-%v Operator-%s OccurAt-%s",
+		keys, u.Operator, u.OccurAt.Format("2006-01-02 15:04:05"))
+}
@@ -0,0 +1,40 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+func init() {
+	if err := scheduler.RegisterCallbackFunc(SchedulerCallback, purgeCallback); err != nil {
+		log.Fatalf("failed to registry purge audit log call back, %v", err)
+	}
+}
+
+func purgeCallback(ctx context.Context, p string) error {
+	param := &Policy{}
+	if err := json.Unmarshal([]byte(p), param); err != nil {
+		return fmt.Errorf("failed to unmarshal the param: %v", err)
+	}
+	_, err := Ctl.Start(ctx, *param, task.ExecutionTriggerSchedule)
+	return err
+}
@@ -0,0 +1,280 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purge
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/purge"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+func init() {
+	// keep only the latest created 50 purge execution records
+	task.SetExecutionSweeperCount(VendorType, 50)
+}
+
+var (
+	// Ctl is a global audit log purge controller instance
+	Ctl = NewController()
+)
+
+const (
+	// SchedulerCallback ...
+	SchedulerCallback = "PURGE_AUDIT_LOG"
+	// VendorType ...
+	VendorType = "PURGE_AUDIT_LOG"
+)
+
+// Controller manages the audit log purge
+type Controller interface {
+	// Start start a manual purge job
+	Start(ctx context.Context, policy Policy, trigger string) (int64, error)
+	// Stop stop a purge job
+	Stop(ctx context.Context, id int64) error
+
+	// ExecutionCount returns the total count of executions according to the query
+	ExecutionCount(ctx context.Context, query *q.Query) (count int64, err error)
+	// ListExecutions lists the executions according to the query
+	ListExecutions(ctx context.Context, query *q.Query) (executions []*Execution, err error)
+	// GetExecution gets the specific execution
+	GetExecution(ctx context.Context, executionID int64) (execution *Execution, err error)
+
+	// GetTask gets the specific task
+	GetTask(ctx context.Context, id int64) (*Task, error)
+	// ListTasks lists the tasks according to the query
+	ListTasks(ctx context.Context, query *q.Query) (tasks []*Task, err error)
+	// GetTaskLog gets log of the specific task
+	GetTaskLog(ctx context.Context, id int64) ([]byte, error)
+
+	// GetSchedule get the current purge schedule
+	GetSchedule(ctx context.Context) (*scheduler.Schedule, error)
+	// CreateSchedule create the purge schedule with cron type & string
+	CreateSchedule(ctx context.Context, cronType, cron string, policy Policy) (int64, error)
+	// DeleteSchedule remove the purge schedule
+	DeleteSchedule(ctx context.Context) error
+}
+
+// NewController creates an instance of the default purge controller
+func NewController() Controller {
+	return &controller{
+		taskMgr:      task.NewManager(),
+		exeMgr:       task.NewExecutionManager(),
+		schedulerMgr: scheduler.New(),
+	}
+}
+
+type controller struct {
+	taskMgr      task.Manager
+	exeMgr       task.ExecutionManager
+	schedulerMgr scheduler.Scheduler
+}
+
+// Start starts the manual purge
+func (c *controller) Start(ctx context.Context, policy Policy, trigger string) (int64, error) {
+	if err := validatePolicy(policy); err != nil {
+		return -1, err
+	}
+	para := parameters(policy)
+
+	execID, err := c.exeMgr.Create(ctx, VendorType, -1, trigger, para)
+	if err != nil {
+		return -1, err
+	}
+	_, err = c.taskMgr.Create(ctx, execID, &task.Job{
+		Name: job.PurgeAudit,
+		Metadata: &job.Metadata{
+			JobKind: job.KindGeneric,
+		},
+		Parameters: para,
+	})
+	if err != nil {
+		return -1, err
+	}
+	return execID, nil
+}
+
+// Stop ...
+func (c *controller) Stop(ctx context.Context, id int64) error {
+	return c.exeMgr.Stop(ctx, id)
+}
+
+// ExecutionCount ...
+func (c *controller) ExecutionCount(ctx context.Context, query *q.Query) (int64, error) {
+	query = q.MustClone(query)
+	query.Keywords["VendorType"] = VendorType
+	return c.exeMgr.Count(ctx, query)
+}
+
+// ListExecutions ...
+func (c *controller) ListExecutions(ctx context.Context, query *q.Query) ([]*Execution, error) {
+	query = q.MustClone(query)
+	query.Keywords["VendorType"] = VendorType
+
+	execs, err := c.exeMgr.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var executions []*Execution
+	for _, exec := range execs {
+		executions = append(executions, convertExecution(exec))
+	}
+	return executions, nil
+}
+
+// GetExecution ...
+func (c *controller) GetExecution(ctx context.Context, id int64) (*Execution, error) {
+	execs, err := c.exeMgr.List(ctx, &q.Query{
+		Keywords: map[string]interface{}{
+			"ID":         id,
+			"VendorType": VendorType,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(execs) == 0 {
+		return nil, errors.New(nil).WithCode(errors.NotFoundCode).
+			WithMessage("purge audit log execution %d not found", id)
+	}
+	return convertExecution(execs[0]), nil
+}
+
+// GetTask ...
+func (c *controller) GetTask(ctx context.Context, id int64) (*Task, error) {
+	tasks, err := c.taskMgr.List(ctx, &q.Query{
+		Keywords: map[string]interface{}{
+			"ID":         id,
+			"VendorType": VendorType,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, errors.New(nil).WithCode(errors.NotFoundCode).
+			WithMessage("purge audit log task %d not found", id)
+	}
+	return convertTask(tasks[0]), nil
+}
+
+// ListTasks ...
+func (c *controller) ListTasks(ctx context.Context, query *q.Query) ([]*Task, error) {
+	query = q.MustClone(query)
+	query.Keywords["VendorType"] = VendorType
+	tks, err := c.taskMgr.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []*Task
+	for _, tk := range tks {
+		tasks = append(tasks, convertTask(tk))
+	}
+	return tasks, nil
+}
+
+// GetTaskLog ...
+func (c *controller) GetTaskLog(ctx context.Context, id int64) ([]byte, error) {
+	_, err := c.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return c.taskMgr.GetLog(ctx, id)
+}
+
+// GetSchedule ...
+func (c *controller) GetSchedule(ctx context.Context) (*scheduler.Schedule, error) {
+	sch, err := c.schedulerMgr.ListSchedules(ctx, q.New(q.KeyWords{"VendorType": VendorType}))
+	if err != nil {
+		return nil, err
+	}
+	if len(sch) == 0 || sch[0] == nil {
+		return nil, errors.New(nil).WithCode(errors.NotFoundCode).WithMessage("no purge audit log schedule is found")
+	}
+	return sch[0], nil
+}
+
+// CreateSchedule ...
+func (c *controller) CreateSchedule(ctx context.Context, cronType, cron string, policy Policy) (int64, error) {
+	if err := validatePolicy(policy); err != nil {
+		return -1, err
+	}
+	return c.schedulerMgr.Schedule(ctx, VendorType, -1, cronType, cron, SchedulerCallback, policy, parameters(policy))
+}
+
+// DeleteSchedule ...
+func (c *controller) DeleteSchedule(ctx context.Context) error {
+	return c.schedulerMgr.UnScheduleByVendor(ctx, VendorType, -1)
+}
+
+func validatePolicy(policy Policy) error {
+	if policy.RetentionHour <= 0 {
+		return errors.BadRequestError(nil).WithMessage("the retention hour of the audit log must be greater than 0")
+	}
+	for op, hour := range policy.OperationRetentionHours {
+		if len(strings.TrimSpace(op)) == 0 {
+			return errors.BadRequestError(nil).WithMessage("the operation of the retention hour can not be empty")
+		}
+		if hour <= 0 {
+			return errors.BadRequestError(nil).WithMessage("the retention hour of the audit log of the operation %s must be greater than 0", op)
+		}
+	}
+	return nil
+}
+
+// parameters returns the job parameters of the policy
+func parameters(policy Policy) map[string]interface{} {
+	para := make(map[string]interface{})
+	para[purge.RetentionHour] = policy.RetentionHour
+	para[purge.IncludeOperations] = policy.IncludeOperations
+	if len(policy.OperationRetentionHours) > 0 {
+		para[purge.OperationRetentionHours] = policy.OperationRetentionHours
+	}
+	para[purge.DryRun] = policy.DryRun
+	return para
+}
+
+func convertExecution(exec *task.Execution) *Execution {
+	return &Execution{
+		ID:            exec.ID,
+		Status:        exec.Status,
+		StatusMessage: exec.StatusMessage,
+		Trigger:       exec.Trigger,
+		ExtraAttrs:    exec.ExtraAttrs,
+		StartTime:     exec.StartTime,
+		EndTime:       exec.EndTime,
+	}
+}
+
+func convertTask(task *task.Task) *Task {
+	return &Task{
+		ID:            task.ID,
+		ExecutionID:   task.ExecutionID,
+		Status:        task.Status,
+		StatusMessage: task.StatusMessage,
+		RunCount:      task.RunCount,
+		DryRun:        task.GetBoolFromExtraAttrs(purge.DryRun),
+		JobID:         task.JobID,
+		CreationTime:  task.CreationTime,
+		StartTime:     task.StartTime,
+		UpdateTime:    task.UpdateTime,
+		EndTime:       task.EndTime,
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purge
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
+	"github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/testing/mock"
+	schedulertesting "github.com/goharbor/harbor/src/testing/pkg/scheduler"
+	tasktesting "github.com/goharbor/harbor/src/testing/pkg/task"
+	"github.com/stretchr/testify/suite"
+)
+
+type purgeCtrTestSuite struct {
+	suite.Suite
+	scheduler *schedulertesting.Scheduler
+	execMgr   *tasktesting.ExecutionManager
+	taskMgr   *tasktesting.Manager
+	ctl       *controller
+}
+
+func (p *purgeCtrTestSuite) SetupTest() {
+	p.execMgr = &tasktesting.ExecutionManager{}
+	p.taskMgr = &tasktesting.Manager{}
+	p.scheduler = &schedulertesting.Scheduler{}
+	p.ctl = &controller{
+		taskMgr:      p.taskMgr,
+		exeMgr:       p.execMgr,
+		schedulerMgr: p.scheduler,
+	}
+}
+
+func (p *purgeCtrTestSuite) TestStart() {
+	// invalid retention hour
+	_, err := p.ctl.Start(nil, Policy{}, task.ExecutionTriggerManual)
+	p.True(errors.IsErr(err, errors.BadRequestCode))
+
+	p.execMgr.On("Create", mock.Anything, VendorType, int64(-1), task.ExecutionTriggerManual, map[string]interface{}{
+		"audit_retention_hour": 24,
+		"include_operations":   "create,delete",
+		"dry_run":              true,
+	}).Return(int64(1), nil)
+	p.taskMgr.On("Create", mock.Anything, int64(1), mock.Anything).Return(int64(1), nil)
+
+	id, err := p.ctl.Start(nil, Policy{
+		RetentionHour:     24,
+		IncludeOperations: "create,delete",
+		DryRun:            true,
+	}, task.ExecutionTriggerManual)
+	p.Nil(err)
+	p.Equal(int64(1), id)
+	p.execMgr.AssertExpectations(p.T())
+	p.taskMgr.AssertExpectations(p.T())
+}
+
+func (p *purgeCtrTestSuite) TestGetTaskLog() {
+	p.taskMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Task{
+		{
+			ID:          1,
+			ExecutionID: 1,
+			Status:      job.SuccessStatus.String(),
+		},
+	}, nil)
+	p.taskMgr.On("GetLog", mock.Anything, mock.Anything).Return([]byte("hello world"), nil)
+
+	log, err := p.ctl.GetTaskLog(nil, 1)
+	p.Nil(err)
+	p.Equal([]byte("hello world"), log)
+}
+
+func (p *purgeCtrTestSuite) TestGetExecution() {
+	p.execMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Execution{}, nil).Once()
+	_, err := p.ctl.GetExecution(nil, int64(1))
+	p.True(errors.IsErr(err, errors.NotFoundCode))
+
+	p.execMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Execution{
+		{
+			ID:         1,
+			Trigger:    "Manual",
+			VendorType: VendorType,
+		},
+	}, nil)
+	exec, err := p.ctl.GetExecution(nil, int64(1))
+	p.Require().Nil(err)
+	p.Equal("Manual", exec.Trigger)
+}
+
+func (p *purgeCtrTestSuite) TestListExecutions() {
+	p.execMgr.On("Count", mock.Anything, mock.Anything).Return(int64(1), nil)
+	p.execMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Execution{
+		{
+			ID:      1,
+			Trigger: "Schedule",
+		},
+	}, nil)
+
+	count, err := p.ctl.ExecutionCount(nil, q.New(q.KeyWords{}))
+	p.Require().Nil(err)
+	p.Equal(int64(1), count)
+	execs, err := p.ctl.ListExecutions(nil, q.New(q.KeyWords{}))
+	p.Require().Nil(err)
+	p.Require().Len(execs, 1)
+	p.Equal("Schedule", execs[0].Trigger)
+}
+
+func (p *purgeCtrTestSuite) TestSchedule() {
+	_, err := p.ctl.CreateSchedule(nil, "Daily", "0 0 0 * * *", Policy{})
+	p.True(errors.IsErr(err, errors.BadRequestCode))
+	_, err = p.ctl.CreateSchedule(nil, "Daily", "0 0 0 * * *", Policy{
+		RetentionHour:           24,
+		OperationRetentionHours: map[string]int{"delete": 0},
+	})
+	p.True(errors.IsErr(err, errors.BadRequestCode))
+
+	p.scheduler.On("Schedule", mock.Anything, VendorType, int64(-1), "Daily", "0 0 0 * * *",
+		SchedulerCallback, mock.Anything, map[string]interface{}{
+			"audit_retention_hour":      24,
+			"include_operations":        "",
+			"operation_retention_hours": map[string]int{"delete": 720},
+			"dry_run":                   false,
+		}).Return(int64(1), nil)
+	id, err := p.ctl.CreateSchedule(nil, "Daily", "0 0 0 * * *", Policy{
+		RetentionHour:           24,
+		OperationRetentionHours: map[string]int{"delete": 720},
+	})
+	p.Nil(err)
+	p.Equal(int64(1), id)
+
+	p.scheduler.On("ListSchedules", mock.Anything, mock.Anything).Return([]*scheduler.Schedule{
+		{
+			ID:         1,
+			VendorType: VendorType,
+		},
+	}, nil)
+	sche, err := p.ctl.GetSchedule(nil)
+	p.Require().Nil(err)
+	p.Equal(VendorType, sche.VendorType)
+
+	p.scheduler.On("UnScheduleByVendor", mock.Anything, VendorType, int64(-1)).Return(nil)
+	p.Nil(p.ctl.DeleteSchedule(nil))
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &purgeCtrTestSuite{})
+}
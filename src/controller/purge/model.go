@@ -0,0 +1,58 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purge
+
+import (
+	"time"
+)
+
+// Policy defines the policy of the audit log purge
+type Policy struct {
+	// RetentionHour the audit logs older than the retention hours are purged
+	RetentionHour int `json:"audit_retention_hour"`
+	// IncludeOperations the comma separated operations to purge, all operations if it's empty
+	IncludeOperations string `json:"include_operations"`
+	// OperationRetentionHours the retention hours of the specific operations, which override
+	// the RetentionHour and the IncludeOperations, e.g. keep the deletes longer than the pulls
+	OperationRetentionHours map[string]int         `json:"operation_retention_hours"`
+	DryRun                  bool                   `json:"dry_run"`
+	ExtraAttrs              map[string]interface{} `json:"extra_attrs"`
+}
+
+// Execution model for purge
+type Execution struct {
+	ID            int64
+	Status        string
+	StatusMessage string
+	Trigger       string
+	ExtraAttrs    map[string]interface{}
+	StartTime     time.Time
+	EndTime       time.Time
+}
+
+// Task model for purge
+type Task struct {
+	ID            int64
+	ExecutionID   int64
+	Status        string
+	StatusMessage string
+	RunCount      int32
+	DryRun        bool
+	JobID         string
+	CreationTime  time.Time
+	StartTime     time.Time
+	UpdateTime    time.Time
+	EndTime       time.Time
+}
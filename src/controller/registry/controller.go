@@ -94,6 +94,13 @@ func (c *controller) validate(ctx context.Context, registry *model.Registry) err
 	}
 	registry.URL = url
 
+	if registry.Speed < 0 {
+		return errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("the speed cannot be negative")
+	}
+	if err := registry.BandwidthSchedule.Validate(); err != nil {
+		return err
+	}
+
 	healthy, err := c.IsHealthy(ctx, registry)
 	if err != nil {
 		return err
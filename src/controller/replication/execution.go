@@ -16,6 +16,7 @@ package replication
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -59,6 +60,9 @@ type Controller interface {
 	DeletePolicy(ctx context.Context, id int64) (err error)
 	// Start the replication according to the policy
 	Start(ctx context.Context, policy *replicationmodel.Policy, resource *model.Resource, trigger string) (executionID int64, err error)
+	// DryRun plans the replication according to the policy and returns the resources that would
+	// be copied or deleted without transferring anything, the policy isn't required to be enabled
+	DryRun(ctx context.Context, policy *replicationmodel.Policy, resource *model.Resource) (plan *replicationmodel.Plan, err error)
 	// Stop the replication specified by the execution ID
 	Stop(ctx context.Context, executionID int64) (err error)
 	// ExecutionCount returns the total count of executions according to the query
@@ -162,6 +166,10 @@ func (c *controller) markError(ctx context.Context, executionID int64, err error
 	}
 }
 
+func (c *controller) DryRun(ctx context.Context, policy *replicationmodel.Policy, resource *model.Resource) (*replicationmodel.Plan, error) {
+	return c.flowCtl.DryRun(ctx, policy, resource)
+}
+
 func (c *controller) Stop(ctx context.Context, id int64) error {
 	return c.execMgr.Stop(ctx, id)
 }
@@ -262,7 +270,7 @@ func (c *controller) GetTaskLog(ctx context.Context, id int64) ([]byte, error) {
 }
 
 func convertExecution(exec *task.Execution) *Execution {
-	return &Execution{
+	execution := &Execution{
 		ID:            exec.ID,
 		PolicyID:      exec.VendorID,
 		Status:        exec.Status,
@@ -272,6 +280,17 @@ func convertExecution(exec *task.Execution) *Execution {
 		StartTime:     exec.StartTime,
 		EndTime:       exec.EndTime,
 	}
+	if conflicts, exist := exec.ExtraAttrs["conflicts"]; exist {
+		// the extra attributes are read from JSON, convert them back to the conflicts
+		data, err := json.Marshal(conflicts)
+		if err == nil {
+			err = json.Unmarshal(data, &execution.Conflicts)
+		}
+		if err != nil {
+			log.Errorf("failed to parse the conflicts of execution %d: %v", exec.ID, err)
+		}
+	}
+	return execution
 }
 
 func convertTask(task *task.Task) *Task {
@@ -103,6 +103,17 @@ func (r *replicationTestSuite) TestStart() {
 	r.ormCreator.AssertExpectations(r.T())
 }
 
+func (r *replicationTestSuite) TestDryRun() {
+	r.flowCtl.On("DryRun", mock.Anything, mock.Anything, mock.Anything).Return(&repctlmodel.Plan{
+		TotalSize: 1024,
+	}, nil)
+	// the disabled policy can be planned as well
+	plan, err := r.ctl.DryRun(nil, &repctlmodel.Policy{Enabled: false}, nil)
+	r.Require().Nil(err)
+	r.Equal(int64(1024), plan.TotalSize)
+	r.flowCtl.AssertExpectations(r.T())
+}
+
 func (r *replicationTestSuite) TestStop() {
 	r.execMgr.On("Stop", mock.Anything, mock.Anything).Return(nil)
 	err := r.ctl.Stop(nil, 1)
@@ -132,6 +143,17 @@ func (r *replicationTestSuite) TestListExecutions() {
 			Trigger:   task.ExecutionTriggerManual,
 			StartTime: time.Time{},
 			EndTime:   time.Time{},
+			ExtraAttrs: map[string]interface{}{
+				"conflicts": []interface{}{
+					map[string]interface{}{
+						"repository":         "library/hello-world",
+						"tag":                "latest",
+						"source_digest":      "sha256:1",
+						"destination_digest": "sha256:2",
+						"winner":             "source",
+					},
+				},
+			},
 		},
 	}, nil)
 	executions, err := r.ctl.ListExecutions(nil, nil)
@@ -139,6 +161,9 @@ func (r *replicationTestSuite) TestListExecutions() {
 	r.Require().Len(executions, 1)
 	r.Equal(int64(1), executions[0].ID)
 	r.Equal(int64(1), executions[0].PolicyID)
+	r.Require().Len(executions[0].Conflicts, 1)
+	r.Equal("latest", executions[0].Conflicts[0].Tag)
+	r.Equal("source", executions[0].Conflicts[0].Winner)
 	r.execMgr.AssertExpectations(r.T())
 }
 
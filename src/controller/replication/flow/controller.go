@@ -29,6 +29,8 @@ type Flow interface {
 // Controller controls the replication flow
 type Controller interface {
 	Start(ctx context.Context, executionID int64, policy *repctlmodel.Policy, resource *model.Resource) (err error)
+	// DryRun returns the plan of the replication without transferring anything
+	DryRun(ctx context.Context, policy *repctlmodel.Policy, resource *model.Resource) (plan *repctlmodel.Plan, err error)
 }
 
 // NewController returns an instance of the default flow controller
@@ -41,12 +43,39 @@ type controller struct{}
 func (c *controller) Start(ctx context.Context, executionID int64, policy *repctlmodel.Policy, resource *model.Resource) error {
 	// deletion flow
 	if resource != nil && resource.Deleted {
-		return NewDeletionFlow(executionID, policy, resource).Run(ctx)
+		return NewDeletionFlow(executionID, localAsSource(policy), resource).Run(ctx)
 	}
-	// copy flow
 	resources := []*model.Resource{}
 	if resource != nil {
 		resources = append(resources, resource)
 	}
+	// sync flow
+	if policy.Sync {
+		return NewSyncFlow(executionID, policy, resources...).Run(ctx)
+	}
+	// copy flow
 	return NewCopyFlow(executionID, policy, resources...).Run(ctx)
 }
+
+func (c *controller) DryRun(ctx context.Context, policy *repctlmodel.Policy, resource *model.Resource) (*repctlmodel.Plan, error) {
+	resources := []*model.Resource{}
+	if resource != nil {
+		resources = append(resources, resource)
+	}
+	if resource != nil && resource.Deleted {
+		policy = localAsSource(policy)
+	}
+	return dryRun(ctx, policy, resources...)
+}
+
+// the events are produced by local Harbor, when the local Harbor is the destination registry of the
+// sync policy, returns a copy of the policy whose source and destination registries are swapped to
+// replicate the events to the remote registry
+func localAsSource(policy *repctlmodel.Policy) *repctlmodel.Policy {
+	if !policy.Sync || policy.DestRegistry == nil || policy.DestRegistry.ID != 0 {
+		return policy
+	}
+	p := *policy
+	p.SrcRegistry, p.DestRegistry = policy.DestRegistry, policy.SrcRegistry
+	return &p
+}
@@ -91,7 +91,7 @@ func (c *copyFlow) Run(ctx context.Context) error {
 		return err
 	}
 
-	return c.createTasks(ctx, srcResources, dstResources, c.policy.Speed)
+	return c.createTasks(ctx, "copy", srcResources, dstResources, c.policy.Speed, c.policy.BandwidthSchedule)
 }
 
 func (c *copyFlow) isExecutionStopped(ctx context.Context) (bool, error) {
@@ -102,7 +102,11 @@ func (c *copyFlow) isExecutionStopped(ctx context.Context) (bool, error) {
 	return execution.Status == job.StoppedStatus.String(), nil
 }
 
-func (c *copyFlow) createTasks(ctx context.Context, srcResources, dstResources []*model.Resource, speed int32) error {
+func (c *copyFlow) createTasks(ctx context.Context, operation string, srcResources, dstResources []*model.Resource, speed int32, schedule model.BandwidthSchedule) error {
+	bandwidthSchedule, err := json.Marshal(schedule)
+	if err != nil {
+		return err
+	}
 	for i, resource := range srcResources {
 		src, err := json.Marshal(resource)
 		if err != nil {
@@ -119,14 +123,16 @@ func (c *copyFlow) createTasks(ctx context.Context, srcResources, dstResources [
 				JobKind: job.KindGeneric,
 			},
 			Parameters: map[string]interface{}{
-				"src_resource": string(src),
-				"dst_resource": string(dest),
-				"speed":        speed,
+				"src_resource":       string(src),
+				"dst_resource":       string(dest),
+				"policy_id":          c.policy.ID,
+				"speed":              speed,
+				"bandwidth_schedule": string(bandwidthSchedule),
 			},
 		}
 
 		if _, err = c.taskMgr.Create(ctx, c.executionID, job, map[string]interface{}{
-			"operation":            "copy",
+			"operation":            operation,
 			"resource_type":        string(resource.Type),
 			"source_resource":      getResourceName(resource),
 			"destination_resource": getResourceName(dstResources[i])}); err != nil {
@@ -0,0 +1,325 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/distribution/manifest/schema2"
+	repctlmodel "github.com/goharbor/harbor/src/controller/replication/model"
+	"github.com/goharbor/harbor/src/lib/log"
+	adp "github.com/goharbor/harbor/src/pkg/reg/adapter"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// dryRun plans the replication of the resources according to the policy without creating
+// any task or transferring anything. If the parameter "resources" isn't provided,
+// will fetch the resources first
+func dryRun(ctx context.Context, policy *repctlmodel.Policy, resources ...*model.Resource) (*repctlmodel.Plan, error) {
+	srcAdapter, dstAdapter, err := initialize(policy)
+	if err != nil {
+		return nil, err
+	}
+	if policy.Sync && !(len(resources) > 0 && resources[0].Deleted) {
+		return syncPlan(ctx, policy, srcAdapter, dstAdapter, resources...)
+	}
+	srcResources := resources
+	if len(srcResources) == 0 {
+		srcResources, err = fetchResources(srcAdapter, policy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	plan := &repctlmodel.Plan{
+		Copies:    []*repctlmodel.PlanItem{},
+		Deletions: []*repctlmodel.PlanItem{},
+		Skips:     []*repctlmodel.PlanItem{},
+	}
+	if len(srcResources) == 0 {
+		return plan, nil
+	}
+
+	srcResources = assembleSourceResources(srcResources, policy)
+	info, err := dstAdapter.Info()
+	if err != nil {
+		return nil, err
+	}
+	dstResources, err := assembleDestinationResources(srcResources, policy, info.SupportedRepositoryPathComponentType)
+	if err != nil {
+		return nil, err
+	}
+
+	srcRegistry, _ := srcAdapter.(adp.ArtifactRegistry)
+	for i, resource := range srcResources {
+		item := &repctlmodel.PlanItem{
+			ResourceType:        resource.Type,
+			SourceResource:      getResourceName(resource),
+			DestinationResource: getResourceName(dstResources[i]),
+		}
+		// the deleted resources come from the deletion events
+		if resource.Deleted {
+			item.Operation = "deletion"
+			if dstResources[i].IsDeleteTag {
+				item.Operation = "tag deletion"
+			}
+			item.Artifacts = planArtifacts(resource)
+			plan.Deletions = append(plan.Deletions, item)
+			continue
+		}
+		copies, skips := planExistence(ctx, srcAdapter, dstAdapter, resource, dstResources[i])
+		if len(skips) > 0 {
+			plan.Skips = append(plan.Skips, &repctlmodel.PlanItem{
+				Operation:           "skip",
+				ResourceType:        item.ResourceType,
+				SourceResource:      item.SourceResource,
+				DestinationResource: item.DestinationResource,
+				Artifacts:           skips,
+			})
+		}
+		if len(copies) == 0 {
+			continue
+		}
+		item.Operation = "copy"
+		item.Artifacts = copies
+		// the size of chart is unknown until it is downloaded
+		if resource.Type != model.ResourceTypeChart && srcRegistry != nil {
+			item.Size = calculateSize(ctx, srcRegistry, resource.Metadata.Repository.Name, item.Artifacts)
+		}
+		plan.Copies = append(plan.Copies, item)
+		plan.TotalSize += item.Size
+	}
+	return plan, nil
+}
+
+// split the artifacts of the resource into the ones to be copied and the ones to be skipped as the
+// transfer does: the artifact is skipped if the same one already exists in the destination registry
+// or the one with the same name exists but the "override" isn't enabled. The artifact is planned to
+// be copied if its existence can't be checked
+func planExistence(ctx context.Context, srcAdapter, dstAdapter adp.Adapter, src, dst *model.Resource) ([]*repctlmodel.PlanArtifact, []*repctlmodel.PlanArtifact) {
+	logger := log.GetLogger(ctx)
+	srcRepo := src.Metadata.Repository.Name
+	dstRepo := dst.Metadata.Repository.Name
+	var exist func(srcRef, dstRef, digest string) (bool, error)
+	switch src.Type {
+	case model.ResourceTypeChart:
+		registry, ok := dstAdapter.(adp.ChartRegistry)
+		if !ok {
+			return planArtifacts(src), nil
+		}
+		exist = func(_, version, _ string) (bool, error) {
+			if dst.Override {
+				return false, nil
+			}
+			return registry.ChartExist(dstRepo, version)
+		}
+	default:
+		srcRegistry, ok1 := srcAdapter.(adp.ArtifactRegistry)
+		dstRegistry, ok2 := dstAdapter.(adp.ArtifactRegistry)
+		if !ok1 || !ok2 {
+			return planArtifacts(src), nil
+		}
+		exist = func(srcRef, dstRef, digest string) (bool, error) {
+			existing, desc, err := dstRegistry.ManifestExist(dstRepo, dstRef)
+			if err != nil || !existing || !dst.Override {
+				return existing, err
+			}
+			// the artifact with the same name exists and "override" is enabled, skip only when it's the same one
+			if len(digest) == 0 {
+				_, srcDesc, err := srcRegistry.ManifestExist(srcRepo, srcRef)
+				if err != nil {
+					return false, err
+				}
+				if srcDesc != nil {
+					digest = srcDesc.Digest.String()
+				}
+			}
+			return desc != nil && len(digest) > 0 && desc.Digest.String() == digest, nil
+		}
+	}
+	skip := func(srcRef, dstRef, digest string) bool {
+		skipped, err := exist(srcRef, dstRef, digest)
+		if err != nil {
+			// the plan is just for reference, don't break the dry run
+			logger.Warningf("failed to check the existence of %s:%s in the destination registry: %v", dstRepo, dstRef, err)
+			return false
+		}
+		return skipped
+	}
+
+	var copies, skips []*repctlmodel.PlanArtifact
+	srcArtifacts, dstArtifacts := planArtifacts(src), planArtifacts(dst)
+	for i, artifact := range srcArtifacts {
+		// the artifact without tags is referenced by the digest
+		if len(artifact.Tags) == 0 {
+			if skip(artifact.Digest, dstArtifacts[i].Digest, artifact.Digest) {
+				skips = append(skips, artifact)
+			} else {
+				copies = append(copies, artifact)
+			}
+			continue
+		}
+		copied := &repctlmodel.PlanArtifact{Digest: artifact.Digest}
+		skipped := &repctlmodel.PlanArtifact{Digest: artifact.Digest}
+		for j, tag := range artifact.Tags {
+			if skip(tag, dstArtifacts[i].Tags[j], artifact.Digest) {
+				skipped.Tags = append(skipped.Tags, tag)
+			} else {
+				copied.Tags = append(copied.Tags, tag)
+			}
+		}
+		if len(copied.Tags) > 0 {
+			copies = append(copies, copied)
+		}
+		if len(skipped.Tags) > 0 {
+			skips = append(skips, skipped)
+		}
+	}
+	return copies, skips
+}
+
+// convert the artifacts of the resource to the plan artifacts, fallback to vtags
+// if the resource contains no artifacts
+func planArtifacts(resource *model.Resource) []*repctlmodel.PlanArtifact {
+	var artifacts []*repctlmodel.PlanArtifact
+	if resource.Metadata == nil {
+		return artifacts
+	}
+	for _, artifact := range resource.Metadata.Artifacts {
+		artifacts = append(artifacts, &repctlmodel.PlanArtifact{
+			Digest: artifact.Digest,
+			Tags:   artifact.Tags,
+		})
+	}
+	if len(artifacts) > 0 {
+		return artifacts
+	}
+	for _, tag := range resource.Metadata.Vtags {
+		artifacts = append(artifacts, &repctlmodel.PlanArtifact{
+			Tags: []string{tag},
+		})
+	}
+	return artifacts
+}
+
+// calculate the sizes of the artifacts and return the size of the whole resource. The blobs
+// shared by the artifacts are only counted once in the size of the resource as they're
+// pushed only once into the destination repository
+func calculateSize(ctx context.Context, registry adp.ArtifactRegistry, repository string, artifacts []*repctlmodel.PlanArtifact) int64 {
+	logger := log.GetLogger(ctx)
+	all := map[string]int64{}
+	for _, artifact := range artifacts {
+		reference := artifact.Digest
+		if len(reference) == 0 && len(artifact.Tags) > 0 {
+			reference = artifact.Tags[0]
+		}
+		if len(reference) == 0 {
+			continue
+		}
+		blobs := map[string]int64{}
+		if err := collectBlobs(registry, repository, reference, blobs); err != nil {
+			// the size is just for reference, don't break the dry run
+			logger.Warningf("failed to calculate the size of artifact %s:%s: %v", repository, reference, err)
+			continue
+		}
+		for digest, size := range blobs {
+			artifact.Size += size
+			all[digest] = size
+		}
+	}
+	var size int64
+	for _, s := range all {
+		size += s
+	}
+	return size
+}
+
+// collect the manifests and blobs referenced by the artifact into the map with digest as the key and
+// size as the value. The child manifests of index are collected recursively
+func collectBlobs(registry adp.ArtifactRegistry, repository, reference string, blobs map[string]int64) error {
+	manifest, digest, err := registry.PullManifest(repository, reference)
+	if err != nil {
+		return err
+	}
+	if _, exist := blobs[digest]; exist {
+		return nil
+	}
+	_, payload, err := manifest.Payload()
+	if err != nil {
+		return fmt.Errorf("failed to get the payload of manifest %s: %v", digest, err)
+	}
+	blobs[digest] = int64(len(payload))
+	for _, content := range manifest.References() {
+		switch content.MediaType {
+		case v1.MediaTypeImageIndex, manifestlist.MediaTypeManifestList,
+			v1.MediaTypeImageManifest, schema2.MediaTypeManifest,
+			schema1.MediaTypeSignedManifest, schema1.MediaTypeManifest:
+			if err = collectBlobs(registry, repository, content.Digest.String(), blobs); err != nil {
+				return err
+			}
+		// the foreign layers aren't replicated
+		case schema2.MediaTypeForeignLayer:
+			continue
+		default:
+			blobs[content.Digest.String()] = content.Size
+		}
+	}
+	return nil
+}
+
+// plan the sync according to the result of the reconciliation
+func syncPlan(ctx context.Context, policy *repctlmodel.Policy, srcAdapter, dstAdapter adp.Adapter, resources ...*model.Resource) (*repctlmodel.Plan, error) {
+	result, err := reconcile(policy, srcAdapter, dstAdapter, resources...)
+	if err != nil {
+		return nil, err
+	}
+	plan := &repctlmodel.Plan{
+		Copies:    []*repctlmodel.PlanItem{},
+		Deletions: []*repctlmodel.PlanItem{},
+		Skips:     []*repctlmodel.PlanItem{},
+		Conflicts: result.conflicts,
+	}
+	for _, direction := range []struct {
+		operation string
+		items     []*syncItem
+		from, to  *model.Registry
+		adapter   adp.Adapter
+	}{
+		{"sync", result.toDestination, policy.SrcRegistry, policy.DestRegistry, srcAdapter},
+		{"reverse sync", result.toSource, policy.DestRegistry, policy.SrcRegistry, dstAdapter},
+	} {
+		registry, _ := direction.adapter.(adp.ArtifactRegistry)
+		srcResources, dstResources := result.resources(direction.items, direction.from, direction.to)
+		for i, resource := range srcResources {
+			item := &repctlmodel.PlanItem{
+				Operation:           direction.operation,
+				ResourceType:        resource.Type,
+				SourceResource:      getResourceName(resource),
+				DestinationResource: getResourceName(dstResources[i]),
+				Artifacts:           planArtifacts(resource),
+			}
+			if registry != nil {
+				item.Size = calculateSize(ctx, registry, resource.Metadata.Repository.Name, item.Artifacts)
+			}
+			plan.Copies = append(plan.Copies, item)
+			plan.TotalSize += item.Size
+		}
+	}
+	return plan, nil
+}
@@ -0,0 +1,227 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/schema2"
+	repctlmodel "github.com/goharbor/harbor/src/controller/replication/model"
+	"github.com/goharbor/harbor/src/pkg/reg/adapter"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type dryRunTestSuite struct {
+	suite.Suite
+	adapter *mockAdapter
+	policy  *repctlmodel.Policy
+}
+
+func (d *dryRunTestSuite) SetupSuite() {
+	// the factory can be registered only once, return the adapter created by each test
+	factory := &mockFactory{}
+	factory.On("AdapterPattern").Return(nil)
+	factory.On("Create", mock.Anything).Return(func(*model.Registry) adapter.Adapter {
+		return d.adapter
+	}, nil)
+	adapter.RegisterFactory("TEST_FOR_DRY_RUN", factory)
+}
+
+func (d *dryRunTestSuite) SetupTest() {
+	d.adapter = &mockAdapter{}
+	d.adapter.On("Info").Return(&model.RegistryInfo{
+		SupportedResourceTypes: []string{
+			model.ResourceTypeArtifact,
+		},
+	}, nil)
+	d.policy = &repctlmodel.Policy{
+		SrcRegistry: &model.Registry{
+			Type: "TEST_FOR_DRY_RUN",
+		},
+		DestRegistry: &model.Registry{
+			Type: "TEST_FOR_DRY_RUN",
+		},
+		DestNamespace:             "dst",
+		DestNamespaceReplaceCount: 1,
+	}
+}
+
+func (d *dryRunTestSuite) TestCopy() {
+	layer := distribution.Descriptor{
+		MediaType: schema2.MediaTypeLayer,
+		Digest:    digest.FromString("layer"),
+		Size:      100,
+	}
+	manifest1, err := schema2.FromStruct(schema2.Manifest{
+		Versioned: schema2.SchemaVersion,
+		Config: distribution.Descriptor{
+			MediaType: schema2.MediaTypeImageConfig,
+			Digest:    digest.FromString("config1"),
+			Size:      10,
+		},
+		Layers: []distribution.Descriptor{layer},
+	})
+	d.Require().Nil(err)
+	manifest2, err := schema2.FromStruct(schema2.Manifest{
+		Versioned: schema2.SchemaVersion,
+		Config: distribution.Descriptor{
+			MediaType: schema2.MediaTypeImageConfig,
+			Digest:    digest.FromString("config2"),
+			Size:      20,
+		},
+		Layers: []distribution.Descriptor{layer},
+	})
+	d.Require().Nil(err)
+	_, payload1, _ := manifest1.Payload()
+	_, payload2, _ := manifest2.Payload()
+
+	d.adapter.On("FetchArtifacts", mock.Anything).Return([]*model.Resource{
+		{
+			Type: model.ResourceTypeImage,
+			Metadata: &model.ResourceMetadata{
+				Repository: &model.Repository{
+					Name: "library/hello-world",
+				},
+				Artifacts: []*model.Artifact{
+					{
+						Digest: "sha256:1",
+						Tags:   []string{"v1"},
+					},
+					{
+						Digest: "sha256:2",
+						Tags:   []string{"v2"},
+					},
+				},
+			},
+		},
+	}, nil)
+	d.adapter.On("PullManifest", "library/hello-world", "sha256:1").Return(manifest1, "sha256:1", nil)
+	d.adapter.On("PullManifest", "library/hello-world", "sha256:2").Return(manifest2, "sha256:2", nil)
+	d.adapter.On("ManifestExist", "dst/hello-world", mock.Anything).Return(false, nil, nil)
+
+	plan, err := dryRun(context.Background(), d.policy)
+	d.Require().Nil(err)
+	d.Empty(plan.Deletions)
+	d.Empty(plan.Skips)
+	d.Require().Len(plan.Copies, 1)
+	item := plan.Copies[0]
+	d.Equal("copy", item.Operation)
+	d.Equal("library/hello-world [2 item(s) in total]", item.SourceResource)
+	d.Equal("dst/hello-world [2 item(s) in total]", item.DestinationResource)
+	d.Require().Len(item.Artifacts, 2)
+	d.Equal(int64(len(payload1))+10+100, item.Artifacts[0].Size)
+	d.Equal(int64(len(payload2))+20+100, item.Artifacts[1].Size)
+	// the shared layer is only counted once
+	d.Equal(int64(len(payload1)+len(payload2))+10+20+100, item.Size)
+	d.Equal(item.Size, plan.TotalSize)
+	// nothing is pushed
+	d.adapter.AssertNotCalled(d.T(), "PrepareForPush", mock.Anything)
+}
+
+func (d *dryRunTestSuite) TestSkip() {
+	d.adapter.On("FetchArtifacts", mock.Anything).Return([]*model.Resource{
+		{
+			Type: model.ResourceTypeImage,
+			Metadata: &model.ResourceMetadata{
+				Repository: &model.Repository{
+					Name: "library/hello-world",
+				},
+				Artifacts: []*model.Artifact{
+					{
+						Digest: "sha256:1",
+						Tags:   []string{"v1", "latest"},
+					},
+					{
+						Digest: "sha256:2",
+						Tags:   []string{"v2"},
+					},
+				},
+			},
+		},
+	}, nil)
+	manifest, err := schema2.FromStruct(schema2.Manifest{
+		Versioned: schema2.SchemaVersion,
+		Config: distribution.Descriptor{
+			MediaType: schema2.MediaTypeImageConfig,
+			Digest:    digest.FromString("config"),
+			Size:      10,
+		},
+	})
+	d.Require().Nil(err)
+	d.adapter.On("PullManifest", "library/hello-world", "sha256:1").Return(manifest, "sha256:1", nil)
+	// "v1" is the same artifact, "latest" doesn't exist and "v2" points to another artifact
+	d.adapter.On("ManifestExist", "dst/hello-world", "v1").Return(true, &distribution.Descriptor{Digest: "sha256:1"}, nil)
+	d.adapter.On("ManifestExist", "dst/hello-world", "latest").Return(false, nil, nil)
+	d.adapter.On("ManifestExist", "dst/hello-world", "v2").Return(true, &distribution.Descriptor{Digest: "sha256:3"}, nil)
+
+	// the "override" isn't enabled
+	plan, err := dryRun(context.Background(), d.policy)
+	d.Require().Nil(err)
+	d.Require().Len(plan.Copies, 1)
+	d.Require().Len(plan.Copies[0].Artifacts, 1)
+	d.Equal("sha256:1", plan.Copies[0].Artifacts[0].Digest)
+	d.Equal([]string{"latest"}, plan.Copies[0].Artifacts[0].Tags)
+	d.Equal(plan.Copies[0].Size, plan.TotalSize)
+	d.Require().Len(plan.Skips, 1)
+	d.Equal("skip", plan.Skips[0].Operation)
+	d.Require().Len(plan.Skips[0].Artifacts, 2)
+	d.Equal([]string{"v1"}, plan.Skips[0].Artifacts[0].Tags)
+	d.Equal([]string{"v2"}, plan.Skips[0].Artifacts[1].Tags)
+
+	// the "override" is enabled, only the same artifact is skipped
+	d.policy.Override = true
+	d.adapter.On("PullManifest", "library/hello-world", "sha256:2").Return(manifest, "sha256:2", nil)
+	plan, err = dryRun(context.Background(), d.policy)
+	d.Require().Nil(err)
+	d.Require().Len(plan.Copies, 1)
+	d.Require().Len(plan.Copies[0].Artifacts, 2)
+	d.Equal([]string{"latest"}, plan.Copies[0].Artifacts[0].Tags)
+	d.Equal([]string{"v2"}, plan.Copies[0].Artifacts[1].Tags)
+	d.Require().Len(plan.Skips, 1)
+	d.Require().Len(plan.Skips[0].Artifacts, 1)
+	d.Equal([]string{"v1"}, plan.Skips[0].Artifacts[0].Tags)
+}
+
+func (d *dryRunTestSuite) TestDeletion() {
+	plan, err := dryRun(context.Background(), d.policy, &model.Resource{
+		Type: model.ResourceTypeImage,
+		Metadata: &model.ResourceMetadata{
+			Repository: &model.Repository{
+				Name: "library/hello-world",
+			},
+			Artifacts: []*model.Artifact{
+				{
+					Tags: []string{"latest"},
+				},
+			},
+		},
+		Deleted:     true,
+		IsDeleteTag: true,
+	})
+	d.Require().Nil(err)
+	d.Empty(plan.Copies)
+	d.Require().Len(plan.Deletions, 1)
+	d.Equal("tag deletion", plan.Deletions[0].Operation)
+	d.Equal(int64(0), plan.TotalSize)
+}
+
+func TestDryRunTestSuite(t *testing.T) {
+	suite.Run(t, &dryRunTestSuite{})
+}
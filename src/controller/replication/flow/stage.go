@@ -16,16 +16,21 @@ package flow
 
 import (
 	"fmt"
-	"github.com/goharbor/harbor/src/lib/errors"
 	"path"
+	"regexp"
 	"strings"
 
+	"github.com/docker/distribution/reference"
 	repctlmodel "github.com/goharbor/harbor/src/controller/replication/model"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	adp "github.com/goharbor/harbor/src/pkg/reg/adapter"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
 )
 
+var tagRe = regexp.MustCompile(fmt.Sprintf("^%s$", reference.TagRegexp))
+
 // get/create the source registry, destination registry, source adapter and destination adapter
 func initialize(policy *repctlmodel.Policy) (adp.Adapter, adp.Adapter, error) {
 	var srcAdapter, dstAdapter adp.Adapter
@@ -126,8 +131,14 @@ func assembleSourceResources(resources []*model.Resource,
 func assembleDestinationResources(resources []*model.Resource,
 	policy *repctlmodel.Policy, dstRepoComponentPathType string) ([]*model.Resource, error) {
 	var result []*model.Resource
+	// the destination artifact -> the source artifact, used to detect the mapping conflicts
+	mapping := map[string]*mappedSource{}
 	for _, resource := range resources {
-		name, err := replaceNamespace(resource.Metadata.Repository.Name, policy.DestNamespace, policy.DestNamespaceReplaceCount, dstRepoComponentPathType)
+		name, repoRewritten, err := rewriteRepository(resource.Metadata.Repository.Name, policy, dstRepoComponentPathType)
+		if err != nil {
+			return nil, err
+		}
+		vtags, artifacts, tagRewritten, err := rewriteTags(resource, policy.RewriteRules)
 		if err != nil {
 			return nil, err
 		}
@@ -144,8 +155,11 @@ func assembleDestinationResources(resources []*model.Resource,
 				Name:     name,
 				Metadata: resource.Metadata.Repository.Metadata,
 			},
-			Vtags:     resource.Metadata.Vtags,
-			Artifacts: resource.Metadata.Artifacts,
+			Vtags:     vtags,
+			Artifacts: artifacts,
+		}
+		if err = detectConflicts(mapping, resource, res, repoRewritten || tagRewritten); err != nil {
+			return nil, err
 		}
 		result = append(result, res)
 	}
@@ -153,6 +167,120 @@ func assembleDestinationResources(resources []*model.Resource,
 	return result, nil
 }
 
+// rewrite the repository name by the rewrite rules of the policy, fallback to
+// replace the namespace if no rule matches. Returns whether the name is rewritten by a rule
+func rewriteRepository(repository string, policy *repctlmodel.Policy, dstRepoComponentPathType string) (string, bool, error) {
+	name, matched, err := policy.RewriteRules.Rewrite(model.RewriteRuleTypeRepository, repository, repository)
+	if err != nil {
+		return "", false, err
+	}
+	if !matched {
+		name, err = replaceNamespace(repository, policy.DestNamespace, policy.DestNamespaceReplaceCount, dstRepoComponentPathType)
+		return name, false, err
+	}
+	if !lib.RepositoryNameRe.MatchString(name) {
+		return "", false, errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("the repository %q is rewritten to an invalid name %q", repository, name)
+	}
+	if err = validatePathComponents(name, dstRepoComponentPathType); err != nil {
+		return "", false, err
+	}
+	return name, true, nil
+}
+
+// rewrite the tags by the rewrite rules and return the new vtags and artifacts and whether any tag
+// is rewritten by a rule, the source resource keeps unchanged. The versions of charts aren't rewritten
+// as they're part of the chart content
+func rewriteTags(resource *model.Resource, rules model.RewriteRules) ([]string, []*model.Artifact, bool, error) {
+	vtags, artifacts := resource.Metadata.Vtags, resource.Metadata.Artifacts
+	if resource.Type == model.ResourceTypeChart {
+		return vtags, artifacts, false, nil
+	}
+	hasTagRule := false
+	for _, rule := range rules {
+		if rule != nil && rule.Type == model.RewriteRuleTypeTag {
+			hasTagRule = true
+			break
+		}
+	}
+	if !hasTagRule {
+		return vtags, artifacts, false, nil
+	}
+
+	repository := resource.Metadata.Repository.Name
+	rewritten := false
+	rewrite := func(tags []string) ([]string, error) {
+		var result []string
+		for _, tag := range tags {
+			t, matched, err := rules.Rewrite(model.RewriteRuleTypeTag, tag, repository)
+			if err != nil {
+				return nil, err
+			}
+			rewritten = rewritten || matched
+			if !tagRe.MatchString(t) {
+				return nil, errors.New(nil).WithCode(errors.BadRequestCode).
+					WithMessage("the tag %q of repository %q is rewritten to an invalid tag %q", tag, repository, t)
+			}
+			result = append(result, t)
+		}
+		return result, nil
+	}
+
+	var err error
+	if vtags, err = rewrite(vtags); err != nil {
+		return nil, nil, false, err
+	}
+	artifacts = nil
+	for _, artifact := range resource.Metadata.Artifacts {
+		tags, err := rewrite(artifact.Tags)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		artifacts = append(artifacts, &model.Artifact{
+			Type:   artifact.Type,
+			Digest: artifact.Digest,
+			Labels: artifact.Labels,
+			Tags:   tags,
+		})
+	}
+	return vtags, artifacts, rewritten, nil
+}
+
+// the source artifact which is replicated to a destination one
+type mappedSource struct {
+	name      string
+	rewritten bool
+}
+
+// detect whether the different source artifacts are replicated to the same destination one.
+// Only the conflicts caused by the rewrite rules are reported, the ones caused by the flattening
+// of the destination namespace are kept as before and the latter copy overrides the former
+func detectConflicts(mapping map[string]*mappedSource, src, dst *model.Resource, rewritten bool) error {
+	srcRepo := src.Metadata.Repository.Name
+	dstRepo := dst.Metadata.Repository.Name
+	detect := func(srcTags, dstTags []string) error {
+		for i := range srcTags {
+			s := srcRepo + ":" + srcTags[i]
+			d := dstRepo + ":" + dstTags[i]
+			if existing, exist := mapping[d]; exist && existing.name != s && (existing.rewritten || rewritten) {
+				return errors.New(nil).WithCode(errors.BadRequestCode).
+					WithMessage("conflict: both %s and %s are replicated to %s", existing.name, s, d)
+			}
+			mapping[d] = &mappedSource{name: s, rewritten: rewritten}
+		}
+		return nil
+	}
+	if err := detect(src.Metadata.Vtags, dst.Metadata.Vtags); err != nil {
+		return err
+	}
+	for i, artifact := range src.Metadata.Artifacts {
+		if err := detect(artifact.Tags, dst.Metadata.Artifacts[i].Tags); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // do the prepare work for pushing/uploading the resources: create the namespace or repository
 func prepareForPush(adapter adp.Adapter, resources []*model.Resource) error {
 	if err := adapter.PrepareForPush(resources); err != nil {
@@ -220,20 +348,28 @@ func replaceNamespace(repository string, namespace string, replaceCount int8, ds
 
 	name := srcRepoPathComponents[srcLength-1] // the last part of the repository path components, we'll keep it as the same with the source
 	dstRepo := path.Join(dstRepoPrefix, name)
+	if err := validatePathComponents(dstRepo, dstRepoComponentPathType); err != nil {
+		return "", err
+	}
+
+	return dstRepo, nil
+}
+
+// make sure the count of the path components of the repository is supported by the destination registry
+func validatePathComponents(dstRepo string, dstRepoComponentPathType string) error {
 	dstRepoPathComponents := strings.Split(dstRepo, "/")
 	dstLength := len(dstRepoPathComponents)
 	switch dstRepoComponentPathType {
 	case model.RepositoryPathComponentTypeOnlyTwo:
 		if dstLength != 2 {
-			return "", errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("the destination repository %q contains %d path components %v, but the destination registry only supports 2",
+			return errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("the destination repository %q contains %d path components %v, but the destination registry only supports 2",
 				dstRepo, dstLength, dstRepoPathComponents)
 		}
 	case model.RepositoryPathComponentTypeAtLeastTwo:
 		if dstLength < 2 {
-			return "", errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("the destination repository %q contains only %d path components %v, but the destination registry requires at least 2",
+			return errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("the destination repository %q contains only %d path components %v, but the destination registry requires at least 2",
 				dstRepo, dstLength, dstRepoPathComponents)
 		}
 	}
-
-	return dstRepo, nil
+	return nil
 }
@@ -119,6 +119,88 @@ func (s *stageTestSuite) TestAssembleDestinationResources() {
 	s.Equal("latest", res[0].Metadata.Vtags[0])
 }
 
+func (s *stageTestSuite) TestAssembleDestinationResourcesWithRewriteRules() {
+	resources := []*model.Resource{
+		{
+			Type: model.ResourceTypeImage,
+			Metadata: &model.ResourceMetadata{
+				Repository: &model.Repository{
+					Name: "library/hello-world",
+				},
+				Artifacts: []*model.Artifact{
+					{
+						Digest: "sha256:1",
+						Tags:   []string{"v1", "latest"},
+					},
+				},
+			},
+		},
+		{
+			Type: model.ResourceTypeImage,
+			Metadata: &model.ResourceMetadata{
+				Repository: &model.Repository{
+					Name: "test/hello-world",
+				},
+				Vtags: []string{"v1"},
+			},
+		},
+	}
+	policy := &repctlmodel.Policy{
+		DestRegistry:              &model.Registry{},
+		DestNamespace:             "test",
+		DestNamespaceReplaceCount: -1,
+		RewriteRules: model.RewriteRules{
+			{
+				Type:        model.RewriteRuleTypeRepository,
+				Pattern:     "library/(.*)",
+				Replacement: "mirror/dockerhub/$1",
+			},
+			{
+				Type:        model.RewriteRuleTypeTag,
+				Kind:        model.RewriteRuleKindTemplate,
+				Pattern:     "v.*",
+				Replacement: "{{.Name}}-mirrored",
+			},
+		},
+	}
+	res, err := assembleDestinationResources(resources, policy, "")
+	s.Require().Nil(err)
+	s.Require().Len(res, 2)
+	s.Equal("mirror/dockerhub/hello-world", res[0].Metadata.Repository.Name)
+	s.Equal([]string{"v1-mirrored", "latest"}, res[0].Metadata.Artifacts[0].Tags)
+	s.Equal("sha256:1", res[0].Metadata.Artifacts[0].Digest)
+	// fallback to the destination namespace
+	s.Equal("test/hello-world", res[1].Metadata.Repository.Name)
+	s.Equal([]string{"v1-mirrored"}, res[1].Metadata.Vtags)
+	// the source resources keep unchanged
+	s.Equal([]string{"v1", "latest"}, resources[0].Metadata.Artifacts[0].Tags)
+	s.Equal([]string{"v1"}, resources[1].Metadata.Vtags)
+
+	// invalid tag
+	policy.RewriteRules[1].Replacement = "{{.Name}}/mirrored"
+	_, err = assembleDestinationResources(resources, policy, "")
+	s.NotNil(err)
+
+	// conflict: "library/hello-world:v1" and "test/hello-world:v1" are both replicated to "test/hello-world:v1"
+	policy.RewriteRules = model.RewriteRules{
+		{
+			Type:        model.RewriteRuleTypeRepository,
+			Pattern:     "library/(.*)",
+			Replacement: "test/$1",
+		},
+	}
+	_, err = assembleDestinationResources(resources, policy, "")
+	s.NotNil(err)
+
+	// no conflict reported when both are flattened to the same destination namespace without rewrite rules
+	policy.RewriteRules = nil
+	res, err = assembleDestinationResources(resources, policy, "")
+	s.Require().Nil(err)
+	s.Require().Len(res, 2)
+	s.Equal("test/hello-world", res[0].Metadata.Repository.Name)
+	s.Equal("test/hello-world", res[1].Metadata.Repository.Name)
+}
+
 func (s *stageTestSuite) TestReplaceNamespace() {
 	// empty namespace
 	var (
@@ -0,0 +1,416 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	repctlmodel "github.com/goharbor/harbor/src/controller/replication/model"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/log"
+	adp "github.com/goharbor/harbor/src/pkg/reg/adapter"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+// the registries of the sync policy
+const (
+	syncSideSource      = "source"
+	syncSideDestination = "destination"
+)
+
+type syncFlow struct {
+	executionID  int64
+	policy       *repctlmodel.Policy
+	executionMgr task.ExecutionManager
+	taskMgr      task.Manager
+	resources    []*model.Resource
+}
+
+// NewSyncFlow returns an instance of the sync flow which reconciles the artifacts between the
+// source registry and the destination registry in both directions. If the parameter "resources"
+// is provided, only the repositories and tags of the resources are reconciled
+func NewSyncFlow(executionID int64, policy *repctlmodel.Policy, resources ...*model.Resource) Flow {
+	return &syncFlow{
+		executionMgr: task.ExecMgr,
+		taskMgr:      task.Mgr,
+		executionID:  executionID,
+		policy:       policy,
+		resources:    resources,
+	}
+}
+
+func (s *syncFlow) Run(ctx context.Context) error {
+	logger := log.GetLogger(ctx)
+	srcAdapter, dstAdapter, err := initialize(s.policy)
+	if err != nil {
+		return err
+	}
+	result, err := reconcile(s.policy, srcAdapter, dstAdapter, s.resources...)
+	if err != nil {
+		return err
+	}
+
+	isStopped, err := s.isExecutionStopped(ctx)
+	if err != nil {
+		return err
+	}
+	if isStopped {
+		logger.Debugf("the execution %d is stopped, stop the flow", s.executionID)
+		return nil
+	}
+
+	// record the conflicts into the execution
+	if len(result.conflicts) > 0 {
+		if err = s.executionMgr.UpdateExtraAttrs(ctx, s.executionID, map[string]interface{}{
+			"conflicts": result.conflicts,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if len(result.toDestination) == 0 && len(result.toSource) == 0 {
+		// nothing to be synced, mark the execution as done directly
+		message := "no resources need to be synced"
+		if len(result.conflicts) > 0 {
+			message = fmt.Sprintf("%d conflict(s) detected and reported", len(result.conflicts))
+		}
+		if err := s.executionMgr.MarkDone(ctx, s.executionID, message); err != nil {
+			logger.Errorf("failed to mark done for the execution %d: %v", s.executionID, err)
+		}
+		return nil
+	}
+
+	copier := &copyFlow{
+		executionID:  s.executionID,
+		policy:       s.policy,
+		executionMgr: s.executionMgr,
+		taskMgr:      s.taskMgr,
+	}
+	// sync from the source registry to the destination registry
+	if len(result.toDestination) > 0 {
+		srcResources, dstResources := result.resources(result.toDestination, s.policy.SrcRegistry, s.policy.DestRegistry)
+		if err = prepareForPush(dstAdapter, dstResources); err != nil {
+			return err
+		}
+		if isLocal(s.policy.DestRegistry) {
+			MarkSynced(s.policy.ID, dstResources...)
+		}
+		if err = copier.createTasks(ctx, "sync", srcResources, dstResources, s.policy.Speed, s.policy.BandwidthSchedule); err != nil {
+			return err
+		}
+	}
+	// sync from the destination registry to the source registry
+	if len(result.toSource) > 0 {
+		srcResources, dstResources := result.resources(result.toSource, s.policy.DestRegistry, s.policy.SrcRegistry)
+		if err = prepareForPush(srcAdapter, dstResources); err != nil {
+			return err
+		}
+		if isLocal(s.policy.SrcRegistry) {
+			MarkSynced(s.policy.ID, dstResources...)
+		}
+		if err = copier.createTasks(ctx, "reverse sync", srcResources, dstResources, s.policy.Speed, s.policy.BandwidthSchedule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *syncFlow) isExecutionStopped(ctx context.Context) (bool, error) {
+	execution, err := s.executionMgr.Get(ctx, s.executionID)
+	if err != nil {
+		return false, err
+	}
+	return execution.Status == job.StoppedStatus.String(), nil
+}
+
+// the artifacts of one repository to be synced
+type syncItem struct {
+	repository *model.Repository
+	artifacts  []*model.Artifact
+	// whether to override the different artifact with the same tag
+	override bool
+}
+
+// the artifacts of the registry indexed by repository
+type syncIndex struct {
+	repositories map[string]*model.Repository
+	// repository -> tag -> artifact
+	tags map[string]map[string]*model.Artifact
+	// repository -> digest -> artifact
+	digests map[string]map[string]*model.Artifact
+}
+
+func newSyncIndex(resources []*model.Resource) *syncIndex {
+	index := &syncIndex{
+		repositories: map[string]*model.Repository{},
+		tags:         map[string]map[string]*model.Artifact{},
+		digests:      map[string]map[string]*model.Artifact{},
+	}
+	for _, resource := range resources {
+		if resource == nil || resource.Metadata == nil || resource.Metadata.Repository == nil {
+			continue
+		}
+		repository := resource.Metadata.Repository.Name
+		if _, exist := index.repositories[repository]; !exist {
+			index.repositories[repository] = resource.Metadata.Repository
+			index.tags[repository] = map[string]*model.Artifact{}
+			index.digests[repository] = map[string]*model.Artifact{}
+		}
+		for _, artifact := range resource.Metadata.Artifacts {
+			for _, tag := range artifact.Tags {
+				index.tags[repository][tag] = artifact
+			}
+			if len(artifact.Digest) > 0 {
+				index.digests[repository][artifact.Digest] = artifact
+			}
+		}
+	}
+	return index
+}
+
+// syncResult is the result of the reconciliation
+type syncResult struct {
+	toDestination []*syncItem
+	toSource      []*syncItem
+	conflicts     []*repctlmodel.SyncConflict
+}
+
+// resources builds the source and destination resources of the copy tasks
+func (s *syncResult) resources(items []*syncItem, from, to *model.Registry) ([]*model.Resource, []*model.Resource) {
+	var srcResources, dstResources []*model.Resource
+	for _, item := range items {
+		srcResources = append(srcResources, &model.Resource{
+			Type:     model.ResourceTypeArtifact,
+			Registry: from,
+			Metadata: &model.ResourceMetadata{
+				Repository: item.repository,
+				Artifacts:  item.artifacts,
+			},
+		})
+		dstResources = append(dstResources, &model.Resource{
+			Type:     model.ResourceTypeArtifact,
+			Registry: to,
+			Metadata: &model.ResourceMetadata{
+				Repository: item.repository,
+				Artifacts:  item.artifacts,
+			},
+			Override: item.override,
+		})
+	}
+	return srcResources, dstResources
+}
+
+// reconcile the artifacts of the source and destination registries. If the parameter "resources"
+// is provided, only the repositories and tags of the resources are reconciled
+func reconcile(policy *repctlmodel.Policy, srcAdapter, dstAdapter adp.Adapter, resources ...*model.Resource) (*syncResult, error) {
+	filters := syncFilters(policy.Filters, resources...)
+	srcResources, err := fetchSyncResources(srcAdapter, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch artifacts from the source registry: %v", err)
+	}
+	dstResources, err := fetchSyncResources(dstAdapter, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch artifacts from the destination registry: %v", err)
+	}
+	src, dst := newSyncIndex(srcResources), newSyncIndex(dstResources)
+
+	repositories := map[string]*model.Repository{}
+	names := map[string]struct{}{}
+	for name, repository := range dst.repositories {
+		repositories[name] = repository
+		names[name] = struct{}{}
+	}
+	for name, repository := range src.repositories {
+		repositories[name] = repository
+		names[name] = struct{}{}
+	}
+
+	result := &syncResult{}
+	for _, name := range sortedKeys(names) {
+		toDestination := &syncItem{repository: repositories[name]}
+		toSource := &syncItem{repository: repositories[name]}
+		overrideDestination := &syncItem{repository: repositories[name], override: true}
+		overrideSource := &syncItem{repository: repositories[name], override: true}
+
+		tags := map[string]struct{}{}
+		for tag := range src.tags[name] {
+			tags[tag] = struct{}{}
+		}
+		for tag := range dst.tags[name] {
+			tags[tag] = struct{}{}
+		}
+		for _, tag := range sortedKeys(tags) {
+			srcArt, dstArt := src.tags[name][tag], dst.tags[name][tag]
+			switch {
+			case dstArt == nil:
+				toDestination.artifacts = append(toDestination.artifacts, tagged(srcArt, tag))
+			case srcArt == nil:
+				toSource.artifacts = append(toSource.artifacts, tagged(dstArt, tag))
+			case srcArt.Digest == dstArt.Digest:
+			default:
+				conflict := &repctlmodel.SyncConflict{
+					Repository:        name,
+					Tag:               tag,
+					SourceDigest:      srcArt.Digest,
+					DestinationDigest: dstArt.Digest,
+					Winner:            resolveConflict(policy.ConflictResolution, tag, srcArt, dstArt),
+				}
+				switch conflict.Winner {
+				case syncSideSource:
+					overrideDestination.artifacts = append(overrideDestination.artifacts, tagged(srcArt, tag))
+				case syncSideDestination:
+					overrideSource.artifacts = append(overrideSource.artifacts, tagged(dstArt, tag))
+				}
+				result.conflicts = append(result.conflicts, conflict)
+			}
+		}
+
+		// the untagged artifacts
+		for _, digest := range sortedDigests(src.digests[name]) {
+			if art := src.digests[name][digest]; len(art.Tags) == 0 && dst.digests[name][digest] == nil {
+				toDestination.artifacts = append(toDestination.artifacts, tagged(art, ""))
+			}
+		}
+		for _, digest := range sortedDigests(dst.digests[name]) {
+			if art := dst.digests[name][digest]; len(art.Tags) == 0 && src.digests[name][digest] == nil {
+				toSource.artifacts = append(toSource.artifacts, tagged(art, ""))
+			}
+		}
+
+		for _, item := range []*syncItem{toDestination, overrideDestination} {
+			if len(item.artifacts) > 0 {
+				result.toDestination = append(result.toDestination, item)
+			}
+		}
+		for _, item := range []*syncItem{toSource, overrideSource} {
+			if len(item.artifacts) > 0 {
+				result.toSource = append(result.toSource, item)
+			}
+		}
+	}
+	return result, nil
+}
+
+// build the filters to fetch the artifacts. When the resources are provided, only the repositories
+// and tags of the resources are fetched
+func syncFilters(filters []*model.Filter, resources ...*model.Resource) [][]*model.Filter {
+	if len(resources) == 0 {
+		return [][]*model.Filter{filters}
+	}
+	var result [][]*model.Filter
+	for _, resource := range resources {
+		if resource == nil || resource.Metadata == nil || resource.Metadata.Repository == nil {
+			continue
+		}
+		var tags []string
+		for _, artifact := range resource.Metadata.Artifacts {
+			tags = append(tags, artifact.Tags...)
+		}
+		// the adapter only uses the first name filter to locate the projects, put
+		// the specific repository in front of the filters of policy
+		fs := []*model.Filter{
+			{
+				Type:  model.FilterTypeName,
+				Value: resource.Metadata.Repository.Name,
+			},
+		}
+		// reconcile the whole repository if the artifact is pushed by digest
+		if len(tags) > 0 {
+			value := tags[0]
+			if len(tags) > 1 {
+				value = "{" + strings.Join(tags, ",") + "}"
+			}
+			fs = append(fs, &model.Filter{
+				Type:  model.FilterTypeTag,
+				Value: value,
+			})
+		}
+		result = append(result, append(fs, filters...))
+	}
+	return result
+}
+
+func fetchSyncResources(adapter adp.Adapter, filters [][]*model.Filter) ([]*model.Resource, error) {
+	reg, ok := adapter.(adp.ArtifactRegistry)
+	if !ok {
+		return nil, fmt.Errorf("the adapter doesn't implement the ArtifactRegistry interface")
+	}
+	var resources []*model.Resource
+	for _, fs := range filters {
+		res, err := reg.FetchArtifacts(fs)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, res...)
+	}
+	return resources, nil
+}
+
+// resolve the conflict according to the resolution and return the side which wins,
+// empty means the conflict is only reported
+func resolveConflict(resolution, tag string, srcArt, dstArt *model.Artifact) string {
+	switch resolution {
+	case repctlmodel.ConflictResolutionPreferSource:
+		return syncSideSource
+	case repctlmodel.ConflictResolutionNewest:
+		srcTime, dstTime := srcArt.TagPushTimes[tag], dstArt.TagPushTimes[tag]
+		// the push time isn't recorded by the registry
+		if srcTime.IsZero() || dstTime.IsZero() || srcTime.Equal(dstTime) {
+			return ""
+		}
+		if srcTime.After(dstTime) {
+			return syncSideSource
+		}
+		return syncSideDestination
+	default:
+		return ""
+	}
+}
+
+// return a copy of the artifact which contains only the specified tag, the
+// artifact is copied by digest if the tag is empty
+func tagged(artifact *model.Artifact, tag string) *model.Artifact {
+	art := &model.Artifact{
+		Type:   artifact.Type,
+		Digest: artifact.Digest,
+		Labels: artifact.Labels,
+	}
+	if len(tag) > 0 {
+		art.Tags = []string{tag}
+	}
+	return art
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	var keys []string
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedDigests(artifacts map[string]*model.Artifact) []string {
+	var digests []string
+	for digest := range artifacts {
+		digests = append(digests, digest)
+	}
+	sort.Strings(digests)
+	return digests
+}
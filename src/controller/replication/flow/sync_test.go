@@ -0,0 +1,315 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	repctlmodel "github.com/goharbor/harbor/src/controller/replication/model"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/reg/adapter"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/goharbor/harbor/src/pkg/task"
+	testingTask "github.com/goharbor/harbor/src/testing/pkg/task"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type syncFlowTestSuite struct {
+	suite.Suite
+	srcAdapter *mockAdapter
+	dstAdapter *mockAdapter
+	policy     *repctlmodel.Policy
+}
+
+func (s *syncFlowTestSuite) SetupSuite() {
+	// the factories can be registered only once, return the adapters created by each test
+	srcFactory := &mockFactory{}
+	srcFactory.On("AdapterPattern").Return(nil)
+	srcFactory.On("Create", mock.Anything).Return(func(*model.Registry) adapter.Adapter {
+		return s.srcAdapter
+	}, nil)
+	adapter.RegisterFactory("TEST_FOR_SYNC_FLOW_SRC", srcFactory)
+
+	dstFactory := &mockFactory{}
+	dstFactory.On("AdapterPattern").Return(nil)
+	dstFactory.On("Create", mock.Anything).Return(func(*model.Registry) adapter.Adapter {
+		return s.dstAdapter
+	}, nil)
+	adapter.RegisterFactory("TEST_FOR_SYNC_FLOW_DST", dstFactory)
+}
+
+func (s *syncFlowTestSuite) SetupTest() {
+	s.srcAdapter = &mockAdapter{}
+	s.dstAdapter = &mockAdapter{}
+
+	now := time.Now()
+	s.srcAdapter.On("FetchArtifacts", mock.Anything).Return([]*model.Resource{
+		{
+			Type: model.ResourceTypeArtifact,
+			Metadata: &model.ResourceMetadata{
+				Repository: &model.Repository{
+					Name: "library/hello-world",
+				},
+				Artifacts: []*model.Artifact{
+					{
+						Digest:       "sha256:1",
+						Tags:         []string{"v1"},
+						TagPushTimes: map[string]time.Time{"v1": now.Add(-time.Hour)},
+					},
+					{
+						Digest:       "sha256:2",
+						Tags:         []string{"latest"},
+						TagPushTimes: map[string]time.Time{"latest": now},
+					},
+					{
+						Digest: "sha256:5",
+					},
+				},
+			},
+		},
+	}, nil)
+	s.dstAdapter.On("FetchArtifacts", mock.Anything).Return([]*model.Resource{
+		{
+			Type: model.ResourceTypeArtifact,
+			Metadata: &model.ResourceMetadata{
+				Repository: &model.Repository{
+					Name: "library/hello-world",
+				},
+				Artifacts: []*model.Artifact{
+					{
+						Digest:       "sha256:1",
+						Tags:         []string{"v1"},
+						TagPushTimes: map[string]time.Time{"v1": now.Add(-time.Hour)},
+					},
+					{
+						Digest:       "sha256:3",
+						Tags:         []string{"latest"},
+						TagPushTimes: map[string]time.Time{"latest": now.Add(-time.Minute)},
+					},
+				},
+			},
+		},
+		{
+			Type: model.ResourceTypeArtifact,
+			Metadata: &model.ResourceMetadata{
+				Repository: &model.Repository{
+					Name: "library/busybox",
+				},
+				Artifacts: []*model.Artifact{
+					{
+						Digest: "sha256:4",
+						Tags:   []string{"v2"},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	s.policy = &repctlmodel.Policy{
+		ID: 1,
+		SrcRegistry: &model.Registry{
+			Type: "TEST_FOR_SYNC_FLOW_SRC",
+		},
+		DestRegistry: &model.Registry{
+			ID:   1,
+			Type: "TEST_FOR_SYNC_FLOW_DST",
+		},
+		Sync:               true,
+		ConflictResolution: repctlmodel.ConflictResolutionNewest,
+	}
+}
+
+func (s *syncFlowTestSuite) TestReconcile() {
+	result, err := reconcile(s.policy, s.srcAdapter, s.dstAdapter)
+	s.Require().Nil(err)
+
+	// the conflict is resolved by the newest push
+	s.Require().Len(result.conflicts, 1)
+	s.Equal("library/hello-world", result.conflicts[0].Repository)
+	s.Equal("latest", result.conflicts[0].Tag)
+	s.Equal("sha256:2", result.conflicts[0].SourceDigest)
+	s.Equal("sha256:3", result.conflicts[0].DestinationDigest)
+	s.Equal(syncSideSource, result.conflicts[0].Winner)
+
+	s.Require().Len(result.toDestination, 2)
+	// the untagged artifact
+	s.Equal("library/hello-world", result.toDestination[0].repository.Name)
+	s.False(result.toDestination[0].override)
+	s.Require().Len(result.toDestination[0].artifacts, 1)
+	s.Equal("sha256:5", result.toDestination[0].artifacts[0].Digest)
+	s.Empty(result.toDestination[0].artifacts[0].Tags)
+	// the conflict
+	s.True(result.toDestination[1].override)
+	s.Require().Len(result.toDestination[1].artifacts, 1)
+	s.Equal("sha256:2", result.toDestination[1].artifacts[0].Digest)
+	s.Equal([]string{"latest"}, result.toDestination[1].artifacts[0].Tags)
+
+	s.Require().Len(result.toSource, 1)
+	s.Equal("library/busybox", result.toSource[0].repository.Name)
+	s.False(result.toSource[0].override)
+	s.Require().Len(result.toSource[0].artifacts, 1)
+	s.Equal([]string{"v2"}, result.toSource[0].artifacts[0].Tags)
+
+	// report only
+	s.policy.ConflictResolution = repctlmodel.ConflictResolutionReportOnly
+	result, err = reconcile(s.policy, s.srcAdapter, s.dstAdapter)
+	s.Require().Nil(err)
+	s.Require().Len(result.conflicts, 1)
+	s.Empty(result.conflicts[0].Winner)
+	s.Require().Len(result.toDestination, 1)
+	s.False(result.toDestination[0].override)
+
+	// prefer the destination
+	s.policy.ConflictResolution = repctlmodel.ConflictResolutionPreferSource
+	result, err = reconcile(s.policy, s.srcAdapter, s.dstAdapter)
+	s.Require().Nil(err)
+	s.Require().Len(result.conflicts, 1)
+	s.Equal(syncSideSource, result.conflicts[0].Winner)
+}
+
+func (s *syncFlowTestSuite) TestRun() {
+	s.srcAdapter.On("PrepareForPush", mock.Anything).Return(nil)
+	s.dstAdapter.On("PrepareForPush", mock.Anything).Return(nil)
+
+	execMgr := &testingTask.ExecutionManager{}
+	execMgr.On("Get", mock.Anything, mock.Anything).Return(&task.Execution{
+		Status: job.RunningStatus.String(),
+	}, nil)
+	execMgr.On("UpdateExtraAttrs", mock.Anything, int64(1), mock.Anything).Return(nil)
+
+	taskMgr := &testingTask.Manager{}
+	taskMgr.On("Create", mock.Anything, int64(1), mock.Anything, mock.MatchedBy(func(attrs map[string]interface{}) bool {
+		return attrs["operation"] == "sync"
+	})).Return(int64(1), nil).Twice()
+	taskMgr.On("Create", mock.Anything, int64(1), mock.Anything, mock.MatchedBy(func(attrs map[string]interface{}) bool {
+		return attrs["operation"] == "reverse sync"
+	})).Return(int64(2), nil).Once()
+
+	flow := &syncFlow{
+		executionID:  1,
+		policy:       s.policy,
+		executionMgr: execMgr,
+		taskMgr:      taskMgr,
+	}
+	err := flow.Run(context.Background())
+	s.Require().Nil(err)
+	execMgr.AssertExpectations(s.T())
+	taskMgr.AssertExpectations(s.T())
+	s.srcAdapter.AssertCalled(s.T(), "PrepareForPush", mock.Anything)
+	s.dstAdapter.AssertCalled(s.T(), "PrepareForPush", mock.Anything)
+}
+
+func (s *syncFlowTestSuite) TestDryRun() {
+	// the size is unknown if failed to pull the manifest
+	s.srcAdapter.On("PullManifest", mock.Anything, mock.Anything).Return(nil, "", errors.New("error"))
+	s.dstAdapter.On("PullManifest", mock.Anything, mock.Anything).Return(nil, "", errors.New("error"))
+
+	plan, err := dryRun(context.Background(), s.policy)
+	s.Require().Nil(err)
+	s.Require().Len(plan.Conflicts, 1)
+	s.Empty(plan.Deletions)
+	s.Require().Len(plan.Copies, 3)
+	s.Equal("sync", plan.Copies[0].Operation)
+	s.Equal("sync", plan.Copies[1].Operation)
+	s.Equal("reverse sync", plan.Copies[2].Operation)
+	s.Equal("library/busybox [1 item(s) in total]", plan.Copies[2].SourceResource)
+	s.Equal(int64(0), plan.TotalSize)
+	// nothing is pushed
+	s.srcAdapter.AssertNotCalled(s.T(), "PrepareForPush", mock.Anything)
+	s.dstAdapter.AssertNotCalled(s.T(), "PrepareForPush", mock.Anything)
+}
+
+func (s *syncFlowTestSuite) TestSyncFilters() {
+	filters := []*model.Filter{
+		{
+			Type:  model.FilterTypeName,
+			Value: "library/**",
+		},
+	}
+	// no resources
+	result := syncFilters(filters)
+	s.Require().Len(result, 1)
+	s.Equal(filters, result[0])
+
+	// the artifact pushed by tags
+	result = syncFilters(filters, &model.Resource{
+		Metadata: &model.ResourceMetadata{
+			Repository: &model.Repository{
+				Name: "library/hello-world",
+			},
+			Artifacts: []*model.Artifact{
+				{
+					Digest: "sha256:1",
+					Tags:   []string{"v1", "latest"},
+				},
+			},
+		},
+	})
+	s.Require().Len(result, 1)
+	s.Require().Len(result[0], 3)
+	s.Equal("library/hello-world", result[0][0].Value)
+	s.Equal(model.FilterTypeTag, result[0][1].Type)
+	s.Equal("{v1,latest}", result[0][1].Value)
+
+	// the artifact pushed by digest
+	result = syncFilters(filters, &model.Resource{
+		Metadata: &model.ResourceMetadata{
+			Repository: &model.Repository{
+				Name: "library/hello-world",
+			},
+			Artifacts: []*model.Artifact{
+				{
+					Digest: "sha256:1",
+				},
+			},
+		},
+	})
+	s.Require().Len(result, 1)
+	s.Require().Len(result[0], 2)
+}
+
+func (s *syncFlowTestSuite) TestLocalAsSource() {
+	// local Harbor is the source registry
+	policy := &repctlmodel.Policy{
+		SrcRegistry:  &model.Registry{ID: 0},
+		DestRegistry: &model.Registry{ID: 1},
+		Sync:         true,
+	}
+	s.Equal(policy, localAsSource(policy))
+
+	// local Harbor is the destination registry
+	policy = &repctlmodel.Policy{
+		SrcRegistry:  &model.Registry{ID: 1},
+		DestRegistry: &model.Registry{ID: 0},
+		Sync:         true,
+	}
+	p := localAsSource(policy)
+	s.Equal(int64(0), p.SrcRegistry.ID)
+	s.Equal(int64(1), p.DestRegistry.ID)
+	// the original policy keeps unchanged
+	s.Equal(int64(1), policy.SrcRegistry.ID)
+
+	// not sync policy
+	policy.Sync = false
+	s.Equal(int64(1), localAsSource(policy).SrcRegistry.ID)
+}
+
+func TestSyncFlowTestSuite(t *testing.T) {
+	suite.Run(t, &syncFlowTestSuite{})
+}
@@ -0,0 +1,101 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/cache"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+)
+
+const (
+	// the prefix of the cache key of the artifacts pushed into local Harbor by the sync policy
+	syncedKeyPrefix = "replication:synced"
+	// the marks outlive the copy tasks, the ones of the failed tasks are removed after expiration
+	syncedExpiration = 24 * time.Hour
+)
+
+// syncedKey returns the cache key of the tag or the untagged digest of the repository synced by the policy
+func syncedKey(policyID int64, repository, reference string) string {
+	return fmt.Sprintf("%s:%d:%s:%s", syncedKeyPrefix, policyID, repository, reference)
+}
+
+// MarkSynced marks the artifacts that are going to be pushed into local Harbor by the sync policy,
+// so the push events fired by them don't trigger the same policy to sync them back.
+// Nothing is marked if the default cache isn't initialized
+func MarkSynced(policyID int64, resources ...*model.Resource) {
+	c := cache.Default()
+	if c == nil {
+		return
+	}
+	for _, resource := range resources {
+		if resource.Metadata == nil || resource.Metadata.Repository == nil {
+			continue
+		}
+		repository := resource.Metadata.Repository.Name
+		for _, artifact := range resource.Metadata.Artifacts {
+			references := artifact.Tags
+			if len(references) == 0 {
+				references = []string{artifact.Digest}
+			}
+			for _, reference := range references {
+				if err := c.Save(syncedKey(policyID, repository, reference), artifact.Digest, syncedExpiration); err != nil {
+					log.Warningf("failed to mark the artifact %s:%s synced by the policy %d: %v", repository, reference, policyID, err)
+				}
+			}
+		}
+	}
+}
+
+// IsSynced checks whether the artifacts of the resource are the ones pushed into local Harbor by the sync policy.
+// The marks are consumed, so the later pushes of the same artifacts trigger the policy again
+func IsSynced(policyID int64, resource *model.Resource) bool {
+	c := cache.Default()
+	if c == nil || resource == nil || resource.Metadata == nil || resource.Metadata.Repository == nil ||
+		len(resource.Metadata.Artifacts) == 0 {
+		return false
+	}
+	repository := resource.Metadata.Repository.Name
+	var keys []string
+	for _, artifact := range resource.Metadata.Artifacts {
+		references := artifact.Tags
+		if len(references) == 0 {
+			references = []string{artifact.Digest}
+		}
+		for _, reference := range references {
+			key := syncedKey(policyID, repository, reference)
+			var digest string
+			// the tag must be marked with the same digest, otherwise it's changed by others
+			if err := c.Fetch(key, &digest); err != nil || digest != artifact.Digest {
+				return false
+			}
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		if err := c.Delete(key); err != nil {
+			log.Warningf("failed to delete the synced mark %s: %v", key, err)
+		}
+	}
+	return true
+}
+
+// isLocal checks whether the registry is the local Harbor
+func isLocal(registry *model.Registry) bool {
+	return registry == nil || registry.ID == 0
+}
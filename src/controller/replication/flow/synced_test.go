@@ -0,0 +1,52 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flow
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/lib/cache"
+	_ "github.com/goharbor/harbor/src/lib/cache/memory"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSynced(t *testing.T) {
+	require.Nil(t, cache.Initialize(cache.Memory, ""))
+
+	resource := func(digest string, tags ...string) *model.Resource {
+		return &model.Resource{
+			Type: model.ResourceTypeArtifact,
+			Metadata: &model.ResourceMetadata{
+				Repository: &model.Repository{Name: "library/hello-world"},
+				Artifacts:  []*model.Artifact{{Digest: digest, Tags: tags}},
+			},
+		}
+	}
+
+	MarkSynced(1, resource("sha256:1", "latest", "v1"), resource("sha256:2"))
+	// other policies
+	assert.False(t, IsSynced(2, resource("sha256:1", "latest")))
+	// the tag is pushed with another digest
+	assert.False(t, IsSynced(1, resource("sha256:3", "latest")))
+	// not all the tags are marked
+	assert.False(t, IsSynced(1, resource("sha256:1", "latest", "v2")))
+	assert.True(t, IsSynced(1, resource("sha256:1", "latest", "v1")))
+	assert.True(t, IsSynced(1, resource("sha256:2")))
+	// the marks are consumed
+	assert.False(t, IsSynced(1, resource("sha256:1", "latest", "v1")))
+	assert.False(t, IsSynced(1, resource("sha256:2")))
+}
@@ -17,6 +17,29 @@ type flowController struct {
 	mock.Mock
 }
 
+// DryRun provides a mock function with given fields: ctx, policy, resource
+func (_m *flowController) DryRun(ctx context.Context, policy *model.Policy, resource *regmodel.Resource) (*model.Plan, error) {
+	ret := _m.Called(ctx, policy, resource)
+
+	var r0 *model.Plan
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Policy, *regmodel.Resource) *model.Plan); ok {
+		r0 = rf(ctx, policy, resource)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Plan)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Policy, *regmodel.Resource) error); ok {
+		r1 = rf(ctx, policy, resource)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Start provides a mock function with given fields: ctx, executionID, policy, resource
 func (_m *flowController) Start(ctx context.Context, executionID int64, policy *model.Policy, resource *regmodel.Resource) error {
 	ret := _m.Called(ctx, executionID, policy, resource)
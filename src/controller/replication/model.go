@@ -17,6 +17,7 @@ package replication
 import (
 	"time"
 
+	repctlmodel "github.com/goharbor/harbor/src/controller/replication/model"
 	"github.com/goharbor/harbor/src/pkg/task/dao"
 )
 
@@ -30,6 +31,8 @@ type Execution struct {
 	Trigger       string
 	StartTime     time.Time
 	EndTime       time.Time
+	// the conflicts detected by the sync policy
+	Conflicts []*repctlmodel.SyncConflict
 }
 
 // Task model for replication
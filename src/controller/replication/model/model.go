@@ -28,6 +28,16 @@ import (
 	"github.com/robfig/cron"
 )
 
+// const definitions
+const (
+	// the tag pushed latest wins
+	ConflictResolutionNewest = "newest"
+	// the tag on the source registry wins
+	ConflictResolutionPreferSource = "prefer_source"
+	// report the conflicts without changing anything
+	ConflictResolutionReportOnly = "report_only"
+)
+
 // Policy defines the structure of a replication policy
 type Policy struct {
 	ID                        int64           `json:"id"`
@@ -45,7 +55,18 @@ type Policy struct {
 	Enabled                   bool            `json:"enabled"`
 	CreationTime              time.Time       `json:"creation_time"`
 	UpdateTime                time.Time       `json:"update_time"`
-	Speed                     int32           `json:"speed"`
+	// Speed is the speed limit(kb/s) shared by all the tasks of the policy
+	Speed int32 `json:"speed"`
+	// BandwidthSchedule overrides the Speed during the time windows of the day
+	BandwidthSchedule model.BandwidthSchedule `json:"bandwidth_schedule"`
+	// RewriteRules rewrite the names of the destination repositories and tags, the rewritten
+	// repository name takes precedence over the destination namespace
+	RewriteRules model.RewriteRules `json:"rewrite_rules"`
+	// Sync reconciles the artifacts between the source and destination registries in both directions
+	Sync bool `json:"sync"`
+	// ConflictResolution specifies how to resolve the tag pointing to different digests on
+	// the two registries when syncing, default is "report_only"
+	ConflictResolution string `json:"conflict_resolution"`
 }
 
 // IsScheduledTrigger returns true when the policy is scheduled trigger and enabled
@@ -94,6 +115,27 @@ func (p *Policy) Validate() error {
 		}
 	}
 
+	// valid the rewrite rules
+	if err := p.RewriteRules.Validate(); err != nil {
+		return err
+	}
+
+	// valid the sync settings
+	if p.Sync {
+		if err := p.validateSync(); err != nil {
+			return err
+		}
+	}
+
+	// valid the bandwidth
+	if p.Speed < 0 {
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("the speed cannot be negative")
+	}
+	if err := p.BandwidthSchedule.Validate(); err != nil {
+		return err
+	}
+
 	// valid trigger
 	if p.Trigger != nil {
 		switch p.Trigger.Type {
@@ -115,6 +157,27 @@ func (p *Policy) Validate() error {
 	return nil
 }
 
+func (p *Policy) validateSync() error {
+	// the artifacts are synced back from the destination registry with the same names
+	if len(p.DestNamespace) > 0 || len(p.RewriteRules) > 0 {
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("the destination namespace and rewrite rules aren't supported when syncing")
+	}
+	for _, f := range p.Filters {
+		if f.Type == model.FilterTypeResource && f.Value == model.ResourceTypeChart {
+			return errors.New(nil).WithCode(errors.BadRequestCode).
+				WithMessage("the chart isn't supported when syncing")
+		}
+	}
+	switch p.ConflictResolution {
+	case "", ConflictResolutionNewest, ConflictResolutionPreferSource, ConflictResolutionReportOnly:
+	default:
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("invalid conflict resolution: %s", p.ConflictResolution)
+	}
+	return nil
+}
+
 // From converts the pkg model into the Policy
 func (p *Policy) From(policy *replicationmodel.Policy) error {
 	if policy == nil {
@@ -132,6 +195,8 @@ func (p *Policy) From(policy *replicationmodel.Policy) error {
 	p.CreationTime = policy.CreationTime
 	p.UpdateTime = policy.UpdateTime
 	p.Speed = policy.Speed
+	p.Sync = policy.Sync
+	p.ConflictResolution = policy.ConflictResolution
 
 	if policy.SrcRegistryID > 0 {
 		p.SrcRegistry = &model.Registry{
@@ -158,6 +223,20 @@ func (p *Policy) From(policy *replicationmodel.Policy) error {
 	}
 	p.Trigger = trigger
 
+	// parse bandwidth schedule
+	if len(policy.BandwidthSchedule) > 0 {
+		if err = json.Unmarshal([]byte(policy.BandwidthSchedule), &p.BandwidthSchedule); err != nil {
+			return err
+		}
+	}
+
+	// parse rewrite rules
+	if len(policy.RewriteRules) > 0 {
+		if err = json.Unmarshal([]byte(policy.RewriteRules), &p.RewriteRules); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -176,6 +255,8 @@ func (p *Policy) To() (*replicationmodel.Policy, error) {
 		CreationTime:              p.CreationTime,
 		UpdateTime:                p.UpdateTime,
 		Speed:                     p.Speed,
+		Sync:                      p.Sync,
+		ConflictResolution:        p.ConflictResolution,
 	}
 	if p.SrcRegistry != nil {
 		policy.SrcRegistryID = p.SrcRegistry.ID
@@ -200,6 +281,22 @@ func (p *Policy) To() (*replicationmodel.Policy, error) {
 		policy.Filters = string(filters)
 	}
 
+	if len(p.BandwidthSchedule) > 0 {
+		schedule, err := json.Marshal(p.BandwidthSchedule)
+		if err != nil {
+			return nil, err
+		}
+		policy.BandwidthSchedule = string(schedule)
+	}
+
+	if len(p.RewriteRules) > 0 {
+		rules, err := json.Marshal(p.RewriteRules)
+		if err != nil {
+			return nil, err
+		}
+		policy.RewriteRules = string(rules)
+	}
+
 	return policy, nil
 }
 
@@ -198,6 +198,65 @@ func TestValidate(t *testing.T) {
 	err = policy.Validate()
 	assert.True(errors.IsErr(err, errors.BadRequestCode))
 
+	// the destination namespace isn't supported when syncing
+	policy = &Policy{
+		Name: "policy01",
+		SrcRegistry: &model.Registry{
+			ID: 0,
+		},
+		DestRegistry: &model.Registry{
+			ID: 1,
+		},
+		DestNamespace: "library",
+		Sync:          true,
+	}
+	err = policy.Validate()
+	assert.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// invalid conflict resolution
+	policy = &Policy{
+		Name: "policy01",
+		SrcRegistry: &model.Registry{
+			ID: 0,
+		},
+		DestRegistry: &model.Registry{
+			ID: 1,
+		},
+		Sync:               true,
+		ConflictResolution: "invalid",
+	}
+	err = policy.Validate()
+	assert.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// valid sync policy
+	policy.ConflictResolution = ConflictResolutionNewest
+	assert.Nil(policy.Validate())
+
+	// conflict rewrite rules
+	policy = &Policy{
+		Name: "policy01",
+		SrcRegistry: &model.Registry{
+			ID: 0,
+		},
+		DestRegistry: &model.Registry{
+			ID: 1,
+		},
+		RewriteRules: model.RewriteRules{
+			{
+				Type:        model.RewriteRuleTypeRepository,
+				Pattern:     "library/(.*)",
+				Replacement: "mirror/$1",
+			},
+			{
+				Type:        model.RewriteRuleTypeRepository,
+				Pattern:     "mirror/(.*)",
+				Replacement: "mirror/$1",
+			},
+		},
+	}
+	err = policy.Validate()
+	assert.True(errors.IsErr(err, errors.BadRequestCode))
+
 	// pass
 	policy = &Policy{
 		Name: "policy01",
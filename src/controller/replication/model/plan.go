@@ -0,0 +1,57 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// Plan is the result of the dry run of a replication policy. It lists the resources
+// that would be copied to or deleted from the destination registry
+type Plan struct {
+	Copies    []*PlanItem `json:"copies"`
+	Deletions []*PlanItem `json:"deletions"`
+	// the resources which already exist in the destination registry and would be skipped
+	Skips []*PlanItem `json:"skips"`
+	// the total size of the resources to be copied in bytes
+	TotalSize int64 `json:"total_size"`
+	// the conflicts detected when syncing
+	Conflicts []*SyncConflict `json:"conflicts,omitempty"`
+}
+
+// PlanItem describes the operation that one replication task would perform
+type PlanItem struct {
+	Operation           string          `json:"operation"`
+	ResourceType        string          `json:"resource_type"`
+	SourceResource      string          `json:"source_resource"`
+	DestinationResource string          `json:"destination_resource"`
+	Artifacts           []*PlanArtifact `json:"artifacts"`
+	// the size of the resource in bytes, 0 means unknown
+	Size int64 `json:"size"`
+}
+
+// PlanArtifact describes the artifact that would be copied or deleted
+type PlanArtifact struct {
+	Digest string   `json:"digest"`
+	Tags   []string `json:"tags"`
+	// the size of the artifact in bytes, 0 means unknown
+	Size int64 `json:"size"`
+}
+
+// SyncConflict describes the tag pointing to different digests on the source and destination registries
+type SyncConflict struct {
+	Repository        string `json:"repository"`
+	Tag               string `json:"tag"`
+	SourceDigest      string `json:"source_digest"`
+	DestinationDigest string `json:"destination_digest"`
+	// the registry whose artifact wins: "source" or "destination", empty means the conflict is only reported
+	Winner string `json:"winner,omitempty"`
+}
@@ -57,7 +57,7 @@ func (f *fakeRegistry) DeleteChart(name, version string) error {
 }
 
 func TestFactory(t *testing.T) {
-	tr, err := factory(nil, nil)
+	tr, err := factory(nil, nil, nil)
 	require.Nil(t, err)
 	_, ok := tr.(trans.Transfer)
 	assert.True(t, ok)
@@ -96,7 +96,7 @@ func TestCopy(t *testing.T) {
 		name:    "dest/harbor",
 		version: "0.2.0",
 	}
-	err := transfer.copy(src, dst, true, 0)
+	err := transfer.copy(src, dst, true, nil)
 	assert.Nil(t, err)
 }
 
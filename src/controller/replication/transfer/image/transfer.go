@@ -36,6 +36,7 @@ import (
 
 var (
 	retry      int
+	chunkSize  int64
 	errStopped = errors.New("stopped")
 )
 
@@ -44,6 +45,10 @@ func init() {
 	if retry <= 0 {
 		retry = 5
 	}
+	chunkSize, _ = strconv.ParseInt(os.Getenv("COPY_BLOB_CHUNK_SIZE"), 10, 64)
+	if chunkSize <= 0 {
+		chunkSize = 10 * 1024 * 1024
+	}
 	if err := trans.RegisterFactory(model.ResourceTypeImage, factory); err != nil {
 		log.Errorf("failed to register transfer factory: %v", err)
 	}
@@ -57,22 +62,27 @@ type repository struct {
 	tags       []string
 }
 
-func factory(logger trans.Logger, stopFunc trans.StopFunc) (trans.Transfer, error) {
+func factory(logger trans.Logger, stopFunc trans.StopFunc, sessions trans.SessionStore) (trans.Transfer, error) {
 	return &transfer{
 		logger:    logger,
 		isStopped: stopFunc,
+		sessions:  sessions,
 	}, nil
 }
 
 type transfer struct {
 	logger    trans.Logger
 	isStopped trans.StopFunc
+	sessions  trans.SessionStore
 	src       adapter.ArtifactRegistry
 	dst       adapter.ArtifactRegistry
-	speed     int32
+	// the chunked blob registries are set only when both the source
+	// and destination registries support copying blobs by chunks
+	srcChunked adapter.ChunkedBlobRegistry
+	dstChunked adapter.ChunkedBlobRegistry
 }
 
-func (t *transfer) Transfer(src *model.Resource, dst *model.Resource, speed int32) error {
+func (t *transfer) Transfer(src *model.Resource, dst *model.Resource, limiter trans.Limiter) error {
 	// initialize
 	if err := t.initialize(src, dst); err != nil {
 		return err
@@ -89,7 +99,7 @@ func (t *transfer) Transfer(src *model.Resource, dst *model.Resource, speed int3
 	}
 
 	// copy the repository from source registry to the destination
-	return t.copy(t.convert(src), t.convert(dst), dst.Override, speed)
+	return t.copy(t.convert(src), t.convert(dst), dst.Override, limiter)
 }
 
 func (t *transfer) convert(resource *model.Resource) *repository {
@@ -116,7 +126,7 @@ func (t *transfer) convert(resource *model.Resource) *repository {
 
 func (t *transfer) initialize(src *model.Resource, dst *model.Resource) error {
 	// create client for source registry
-	srcReg, err := createRegistry(src.Registry)
+	srcAdapter, srcReg, err := createRegistry(src.Registry)
 	if err != nil {
 		t.logger.Errorf("failed to create client for source registry: %v", err)
 		return err
@@ -126,7 +136,7 @@ func (t *transfer) initialize(src *model.Resource, dst *model.Resource) error {
 		src.Registry.Type, src.Registry.URL, src.Registry.Insecure)
 
 	// create client for destination registry
-	dstReg, err := createRegistry(dst.Registry)
+	dstAdapter, dstReg, err := createRegistry(dst.Registry)
 	if err != nil {
 		t.logger.Errorf("failed to create client for destination registry: %v", err)
 		return err
@@ -135,23 +145,49 @@ func (t *transfer) initialize(src *model.Resource, dst *model.Resource) error {
 	t.logger.Infof("client for destination registry [type: %s, URL: %s, insecure: %v] created",
 		dst.Registry.Type, dst.Registry.URL, dst.Registry.Insecure)
 
+	srcChunked := t.chunkedBlobRegistry(srcAdapter)
+	dstChunked := t.chunkedBlobRegistry(dstAdapter)
+	if srcChunked != nil && dstChunked != nil {
+		t.srcChunked = srcChunked
+		t.dstChunked = dstChunked
+		t.logger.Infof("both the source and destination registries support copying blobs by chunks, the chunk size is %d bytes", chunkSize)
+	}
+
 	return nil
 }
 
-func createRegistry(reg *model.Registry) (adapter.ArtifactRegistry, error) {
+func createRegistry(reg *model.Registry) (adapter.Adapter, adapter.ArtifactRegistry, error) {
 	factory, err := adapter.GetFactory(reg.Type)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	ad, err := factory.Create(reg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	registry, ok := ad.(adapter.ArtifactRegistry)
 	if !ok {
-		return nil, errors.New("the adapter doesn't implement the \"ArtifactRegistry\" interface")
+		return nil, nil, errors.New("the adapter doesn't implement the \"ArtifactRegistry\" interface")
+	}
+	return ad, registry, nil
+}
+
+// returns the chunked blob registry if the adapter advertises the support of copying blobs by chunks,
+// otherwise returns nil and the blobs are copied as a whole
+func (t *transfer) chunkedBlobRegistry(ad adapter.Adapter) adapter.ChunkedBlobRegistry {
+	registry, ok := ad.(adapter.ChunkedBlobRegistry)
+	if !ok {
+		return nil
 	}
-	return registry, nil
+	info, err := ad.Info()
+	if err != nil {
+		t.logger.Warningf("failed to get the information of the registry, copy blobs as a whole: %v", err)
+		return nil
+	}
+	if !info.SupportedCopyByChunk {
+		return nil
+	}
+	return registry
 }
 
 func (t *transfer) shouldStop() bool {
@@ -162,18 +198,14 @@ func (t *transfer) shouldStop() bool {
 	return isStopped
 }
 
-func (t *transfer) copy(src *repository, dst *repository, override bool, speed int32) error {
+func (t *transfer) copy(src *repository, dst *repository, override bool, limiter trans.Limiter) error {
 	srcRepo := src.repository
 	dstRepo := dst.repository
 	t.logger.Infof("copying %s:[%s](source registry) to %s:[%s](destination registry)...",
 		srcRepo, strings.Join(src.tags, ","), dstRepo, strings.Join(dst.tags, ","))
-	if speed > 0 {
-		t.logger.Infof("limit network speed at %d kb/s", speed)
-	}
-
 	var err error
 	for i := range src.tags {
-		if e := t.copyArtifact(srcRepo, src.tags[i], dstRepo, dst.tags[i], override, speed); e != nil {
+		if e := t.copyArtifact(srcRepo, src.tags[i], dstRepo, dst.tags[i], override, limiter); e != nil {
 			if e == errStopped {
 				return nil
 			}
@@ -192,7 +224,7 @@ func (t *transfer) copy(src *repository, dst *repository, override bool, speed i
 	return nil
 }
 
-func (t *transfer) copyArtifact(srcRepo, srcRef, dstRepo, dstRef string, override bool, speed int32) error {
+func (t *transfer) copyArtifact(srcRepo, srcRef, dstRepo, dstRef string, override bool, limiter trans.Limiter) error {
 	t.logger.Infof("copying %s:%s(source registry) to %s:%s(destination registry)...",
 		srcRepo, srcRef, dstRepo, dstRef)
 	// pull the manifest from the source registry
@@ -226,7 +258,7 @@ func (t *transfer) copyArtifact(srcRepo, srcRef, dstRepo, dstRef string, overrid
 
 	// copy contents between the source and destination registries
 	for _, content := range manifest.References() {
-		if err = t.copyContent(content, srcRepo, dstRepo, speed); err != nil {
+		if err = t.copyContent(content, srcRepo, dstRepo, limiter); err != nil {
 			return err
 		}
 	}
@@ -242,7 +274,7 @@ func (t *transfer) copyArtifact(srcRepo, srcRef, dstRepo, dstRef string, overrid
 }
 
 // copy the content from source registry to destination according to its media type
-func (t *transfer) copyContent(content distribution.Descriptor, srcRepo, dstRepo string, speed int32) error {
+func (t *transfer) copyContent(content distribution.Descriptor, srcRepo, dstRepo string, limiter trans.Limiter) error {
 	digest := content.Digest.String()
 	switch content.MediaType {
 	// when the media type of pulled manifest is index,
@@ -251,7 +283,7 @@ func (t *transfer) copyContent(content distribution.Descriptor, srcRepo, dstRepo
 		v1.MediaTypeImageManifest, schema2.MediaTypeManifest,
 		schema1.MediaTypeSignedManifest, schema1.MediaTypeManifest:
 		// as using digest as the reference, so set the override to true directly
-		return t.copyArtifact(srcRepo, digest, dstRepo, digest, true, speed)
+		return t.copyArtifact(srcRepo, digest, dstRepo, digest, true, limiter)
 	// handle foreign layer
 	case schema2.MediaTypeForeignLayer:
 		t.logger.Infof("the layer %s is a foreign layer, skip", digest)
@@ -260,15 +292,15 @@ func (t *transfer) copyContent(content distribution.Descriptor, srcRepo, dstRepo
 	// the media type of the layer or config can be "application/octet-stream",
 	// schema1.MediaTypeManifestLayer, schema2.MediaTypeLayer, schema2.MediaTypeImageConfig
 	default:
-		return t.copyBlobWithRetry(srcRepo, dstRepo, digest, content.Size, speed)
+		return t.copyBlobWithRetry(srcRepo, dstRepo, digest, content.Size, limiter)
 	}
 }
 
-func (t *transfer) copyBlobWithRetry(srcRepo, dstRepo, digest string, sizeFromDescriptor int64, speed int32) error {
+func (t *transfer) copyBlobWithRetry(srcRepo, dstRepo, digest string, sizeFromDescriptor int64, limiter trans.Limiter) error {
 	var err error
 	for i, backoff := 1, 2*time.Second; i <= retry; i, backoff = i+1, backoff*2 {
 		t.logger.Infof("copying the blob %s(the %dth running)...", digest, i)
-		if err = t.copyBlob(srcRepo, dstRepo, digest, sizeFromDescriptor, speed); err == nil {
+		if err = t.copyBlob(srcRepo, dstRepo, digest, sizeFromDescriptor, limiter); err == nil {
 			t.logger.Infof("copy the blob %s completed", digest)
 			return nil
 		}
@@ -283,7 +315,7 @@ func (t *transfer) copyBlobWithRetry(srcRepo, dstRepo, digest string, sizeFromDe
 
 // copy the layer or artifact config from the source registry to destination
 // the size parameter is taken from manifests.
-func (t *transfer) copyBlob(srcRepo, dstRepo, digest string, sizeFromDescriptor int64, speed int32) error {
+func (t *transfer) copyBlob(srcRepo, dstRepo, digest string, sizeFromDescriptor int64, limiter trans.Limiter) error {
 	if t.shouldStop() {
 		return errStopped
 	}
@@ -311,13 +343,34 @@ func (t *transfer) copyBlob(srcRepo, dstRepo, digest string, sizeFromDescriptor
 		return nil
 	}
 
+	if t.srcChunked != nil && t.dstChunked != nil && sizeFromDescriptor > 0 {
+		err = t.copyBlobByChunk(srcRepo, dstRepo, digest, sizeFromDescriptor, limiter)
+		if _, ok := err.(*firstChunkError); !ok {
+			return err
+		}
+		// the registries advertise the support of copying by chunks but fail on the range or chunk requests,
+		// fall back to copying the blob as a whole
+		t.logger.Warningf("failed to copy the first chunk of the blob %s, copy it as a whole: %v", digest, err)
+		if err = t.copyWholeBlob(srcRepo, dstRepo, digest, sizeFromDescriptor, limiter); err != nil {
+			return err
+		}
+		t.logger.Warning("the blobs are copied as a whole from now on")
+		t.srcChunked, t.dstChunked = nil, nil
+		return nil
+	}
+
+	return t.copyWholeBlob(srcRepo, dstRepo, digest, sizeFromDescriptor, limiter)
+}
+
+// copy the blob as a whole
+func (t *transfer) copyWholeBlob(srcRepo, dstRepo, digest string, sizeFromDescriptor int64, limiter trans.Limiter) error {
 	size, data, err := t.src.PullBlob(srcRepo, digest)
 	if err != nil {
 		t.logger.Errorf("failed to pulling the blob %s: %v", digest, err)
 		return err
 	}
-	if speed > 0 {
-		data = trans.NewReader(data, speed)
+	if limiter != nil {
+		data = trans.NewReader(data, limiter)
 	}
 	defer data.Close()
 	// get size 0 from PullBlob, use size from distribution.Descriptor instead.
@@ -335,6 +388,111 @@ func (t *transfer) copyBlob(srcRepo, dstRepo, digest string, sizeFromDescriptor
 	return nil
 }
 
+// firstChunkError is returned when the first chunk of the blob fails to be copied
+type firstChunkError struct {
+	error
+}
+
+// copy the blob by chunks, the upload session is saved after each chunk is pushed,
+// so the copy can be resumed from where it stopped when retried
+func (t *transfer) copyBlobByChunk(srcRepo, dstRepo, digest string, size int64, limiter trans.Limiter) error {
+	var (
+		start    int64
+		location string
+	)
+	if session := t.getSession(digest); session != nil {
+		// the offset persisted may be behind the registry if the last chunk failed in the middle,
+		// so always get the real offset from the registry
+		loc, offset, err := t.dstChunked.GetBlobUploadStatus(session.Location)
+		switch {
+		case err != nil:
+			t.logger.Warningf("failed to get the status of the upload session of the blob %s, copy it from the beginning: %v", digest, err)
+		case offset >= size:
+			t.logger.Warningf("the upload session of the blob %s has received %d bytes but isn't completed, copy it from the beginning", digest, offset)
+		default:
+			start, location = offset, loc
+			t.logger.Infof("resume copying the blob %s from the offset %d", digest, start)
+		}
+	}
+
+	for start < size {
+		if t.shouldStop() {
+			return errStopped
+		}
+		end := start + chunkSize - 1
+		if end > size-1 {
+			end = size - 1
+		}
+
+		_, data, err := t.srcChunked.PullBlobChunk(srcRepo, digest, size, start, end)
+		if err != nil {
+			t.logger.Errorf("failed to pulling the blob %s, range %d-%d: %v", digest, start, end, err)
+			if start == 0 {
+				return &firstChunkError{err}
+			}
+			return err
+		}
+		if limiter != nil {
+			data = trans.NewReader(data, limiter)
+		}
+		var endRange int64
+		location, endRange, err = t.dstChunked.PushBlobChunk(dstRepo, digest, size, data, start, end, location)
+		data.Close()
+		if err != nil {
+			t.logger.Errorf("failed to pushing the blob %s, range %d-%d: %v", digest, start, end, err)
+			if start == 0 {
+				return &firstChunkError{err}
+			}
+			return err
+		}
+		t.logger.Debugf("the range %d-%d of the blob %s pushed", start, endRange, digest)
+		start = endRange + 1
+
+		if start < size {
+			t.saveSession(&trans.BlobUploadSession{
+				Digest:   digest,
+				Location: location,
+				Offset:   start,
+			})
+		}
+	}
+
+	t.deleteSession(digest)
+	return nil
+}
+
+// the failures of persisting the upload sessions only lose the ability to resume,
+// so they are logged rather than returned
+func (t *transfer) getSession(digest string) *trans.BlobUploadSession {
+	if t.sessions == nil {
+		return nil
+	}
+	session, err := t.sessions.Get(digest)
+	if err != nil {
+		t.logger.Warningf("failed to get the upload session of the blob %s: %v", digest, err)
+		return nil
+	}
+	return session
+}
+
+func (t *transfer) saveSession(session *trans.BlobUploadSession) {
+	if t.sessions == nil {
+		return
+	}
+	if err := t.sessions.Save(session); err != nil {
+		t.logger.Warningf("failed to save the upload session of the blob %s: %v", session.Digest, err)
+	}
+}
+
+func (t *transfer) deleteSession(digest string) {
+	if t.sessions == nil {
+		return
+	}
+	if err := t.sessions.Delete(digest); err != nil {
+		t.logger.Warningf("failed to delete the upload session of the blob %s: %v", digest, err)
+	}
+}
+
 func (t *transfer) pullManifest(repository, reference string) (
 	distribution.Manifest, string, error) {
 	if t.shouldStop() {
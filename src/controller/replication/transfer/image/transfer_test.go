@@ -16,6 +16,7 @@ package image
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"io/ioutil"
 	"testing"
@@ -104,7 +105,7 @@ func (f *fakeRegistry) MountBlob(srcRepository, digest, dstRepository string) er
 }
 
 func TestFactory(t *testing.T) {
-	tr, err := factory(nil, nil)
+	tr, err := factory(nil, nil, nil)
 	require.Nil(t, err)
 	_, ok := tr.(trans.Transfer)
 	assert.True(t, ok)
@@ -144,7 +145,7 @@ func TestCopy(t *testing.T) {
 		repository: "destination",
 		tags:       []string{"b1", "b2"},
 	}
-	err := tr.copy(src, dst, true, 0)
+	err := tr.copy(src, dst, true, nil)
 	require.Nil(t, err)
 }
 
@@ -163,3 +164,132 @@ func TestDelete(t *testing.T) {
 	err := tr.delete(repo)
 	require.Nil(t, err)
 }
+
+type fakeChunkedRegistry struct {
+	fakeRegistry
+	blob     []byte
+	uploaded []byte
+	// the count of the chunks pushed before failing, negative means never fail
+	failAfter int
+	completed bool
+	// the registry doesn't support pulling the range of the blob
+	rangeUnsupported bool
+}
+
+func (f *fakeChunkedRegistry) PullBlobChunk(repository, digest string, blobSize, start, end int64) (int64, io.ReadCloser, error) {
+	if f.rangeUnsupported {
+		return 0, nil, errors.New("the registry doesn't support pulling the range")
+	}
+	return end - start + 1, ioutil.NopCloser(bytes.NewReader(f.blob[start : end+1])), nil
+}
+
+func (f *fakeChunkedRegistry) PushBlobChunk(repository, digest string, blobSize int64, chunk io.Reader, start, end int64, location string) (string, int64, error) {
+	if f.failAfter == 0 {
+		return "", 0, errors.New("network error")
+	}
+	f.failAfter--
+	if start != int64(len(f.uploaded)) {
+		return "", 0, errors.New("range not satisfiable")
+	}
+	data, err := ioutil.ReadAll(chunk)
+	if err != nil {
+		return "", 0, err
+	}
+	f.uploaded = append(f.uploaded, data...)
+	if end == blobSize-1 {
+		f.completed = true
+		return "", end, nil
+	}
+	return "location", end, nil
+}
+
+func (f *fakeChunkedRegistry) GetBlobUploadStatus(location string) (string, int64, error) {
+	return "location", int64(len(f.uploaded)), nil
+}
+
+type fakeSessionStore struct {
+	sessions map[string]*trans.BlobUploadSession
+}
+
+func (f *fakeSessionStore) Get(digest string) (*trans.BlobUploadSession, error) {
+	return f.sessions[digest], nil
+}
+
+func (f *fakeSessionStore) Save(session *trans.BlobUploadSession) error {
+	f.sessions[session.Digest] = session
+	return nil
+}
+
+func (f *fakeSessionStore) Delete(digest string) error {
+	delete(f.sessions, digest)
+	return nil
+}
+
+func TestCopyBlobByChunk(t *testing.T) {
+	chunkSize = 2
+	defer func() { chunkSize = 10 * 1024 * 1024 }()
+
+	blob := []byte("abcdefg")
+	src := &fakeChunkedRegistry{blob: blob, failAfter: -1}
+	dst := &fakeChunkedRegistry{failAfter: 2}
+	sessions := &fakeSessionStore{sessions: map[string]*trans.BlobUploadSession{}}
+	tr := &transfer{
+		logger:     log.DefaultLogger(),
+		isStopped:  func() bool { return false },
+		sessions:   sessions,
+		src:        src,
+		dst:        dst,
+		srcChunked: src,
+		dstChunked: dst,
+	}
+
+	// fails after 2 chunks pushed, the session is persisted
+	err := tr.copyBlob("source", "destination", "digest", int64(len(blob)), nil)
+	require.NotNil(t, err)
+	assert.False(t, dst.completed)
+	require.NotNil(t, sessions.sessions["digest"])
+	assert.Equal(t, int64(4), sessions.sessions["digest"].Offset)
+
+	// resume from the persisted session
+	dst.failAfter = -1
+	err = tr.copyBlob("source", "destination", "digest", int64(len(blob)), nil)
+	require.Nil(t, err)
+	assert.True(t, dst.completed)
+	assert.Equal(t, blob, dst.uploaded)
+	assert.Nil(t, sessions.sessions["digest"])
+}
+
+func TestCopyBlobByChunkFallback(t *testing.T) {
+	chunkSize = 2
+	defer func() { chunkSize = 10 * 1024 * 1024 }()
+
+	blob := []byte("abcdefg")
+	newTransfer := func(src, dst *fakeChunkedRegistry) *transfer {
+		return &transfer{
+			logger:     log.DefaultLogger(),
+			isStopped:  func() bool { return false },
+			sessions:   &fakeSessionStore{sessions: map[string]*trans.BlobUploadSession{}},
+			src:        src,
+			dst:        dst,
+			srcChunked: src,
+			dstChunked: dst,
+		}
+	}
+
+	// the source registry doesn't support pulling the range
+	src := &fakeChunkedRegistry{blob: blob, failAfter: -1, rangeUnsupported: true}
+	dst := &fakeChunkedRegistry{failAfter: -1}
+	tr := newTransfer(src, dst)
+	require.Nil(t, tr.copyBlob("source", "destination", "digest", int64(len(blob)), nil))
+	assert.Empty(t, dst.uploaded)
+	// the blobs are copied as a whole afterwards
+	assert.Nil(t, tr.srcChunked)
+	assert.Nil(t, tr.dstChunked)
+
+	// the destination registry fails on the first chunk
+	src = &fakeChunkedRegistry{blob: blob, failAfter: -1}
+	dst = &fakeChunkedRegistry{failAfter: 0}
+	tr = newTransfer(src, dst)
+	require.Nil(t, tr.copyBlob("source", "destination", "digest", int64(len(blob)), nil))
+	assert.Nil(t, tr.dstChunked)
+}
@@ -1,16 +1,19 @@
 package transfer
 
 import (
-	"fmt"
 	"io"
+	"sync"
 	"time"
 
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/gomodule/redigo/redis"
 	"golang.org/x/time/rate"
 )
 
 type reader struct {
 	reader  io.ReadCloser
-	limiter *rate.Limiter
+	limiter Limiter
 }
 
 type RateOpts struct {
@@ -19,12 +22,46 @@ type RateOpts struct {
 
 const KBRATE = 1024 / 8
 
-// NewReader returns a Reader that is rate limited
-func NewReader(r io.ReadCloser, kb int32) io.ReadCloser {
-	l := rate.NewLimiter(rate.Limit(kb*KBRATE), 1000*1024)
+// the burst of the local limiter in bytes
+const localBurst = 1000 * 1024
+
+// the shared limiter reserves the bytes could be transferred in the duration from Redis
+// at one time to reduce the round trips, the reserved bytes are consumed locally
+const sharedQuantum = 100 * time.Millisecond
+
+// reserve the bytes with the generic cell rate algorithm, the "theoretical arrival time"
+// after the last reservation is stored in the key.
+// KEYS[1]: the key, ARGV[1]: current time in microseconds,
+// ARGV[2]: the cost of the reserved bytes in microseconds, ARGV[3]: the burst in microseconds
+// returns the microseconds to wait before transferring the reserved bytes
+var reserveScript = redis.NewScript(1, `
+local now = tonumber(ARGV[1])
+local cost = tonumber(ARGV[2])
+local burst = tonumber(ARGV[3])
+local tat = tonumber(redis.call("GET", KEYS[1]))
+if not tat or tat < now then
+	tat = now
+end
+local delay = tat - now - burst
+if delay < 0 then
+	delay = 0
+end
+tat = tat + cost
+redis.call("SET", KEYS[1], string.format("%d", tat), "PX", math.ceil((tat - now) / 1000) + 1000)
+return delay
+`)
+
+// Limiter limits the rate of the data transferred
+type Limiter interface {
+	// Reserve n bytes and return the duration to wait before transferring them
+	Reserve(n int) time.Duration
+}
+
+// NewReader returns a Reader that is rate limited by the limiter
+func NewReader(r io.ReadCloser, limiter Limiter) io.ReadCloser {
 	return &reader{
 		reader:  r,
-		limiter: l,
+		limiter: limiter,
 	}
 }
 
@@ -33,16 +70,169 @@ func (r *reader) Read(buf []byte) (int, error) {
 	if n <= 0 {
 		return n, err
 	}
-	now := time.Now()
-	rv := r.limiter.ReserveN(now, n)
-	if !rv.OK() {
-		return 0, fmt.Errorf("exceeds limiter's burst")
-	}
-	delay := rv.DelayFrom(now)
-	time.Sleep(delay)
+	time.Sleep(r.limiter.Reserve(n))
 	return n, err
 }
 
 func (r *reader) Close() error {
 	return r.reader.Close()
 }
+
+// returns the rate limit in bytes/s at the specified time, 0 means unlimited
+func bytesPerSecond(speed int32, schedule model.BandwidthSchedule, t time.Time) float64 {
+	kb := schedule.SpeedAt(speed, t)
+	if kb <= 0 {
+		return 0
+	}
+	return float64(kb) * KBRATE
+}
+
+// NewLimiter returns a limiter that limits the rate of the data transferred through it
+// to the speed(kb/s), the speed is overridden by the schedule during its time windows,
+// 0 means unlimited
+func NewLimiter(speed int32, schedule model.BandwidthSchedule) Limiter {
+	return &localLimiter{
+		speed:    speed,
+		schedule: schedule,
+		now:      time.Now,
+	}
+}
+
+type localLimiter struct {
+	speed    int32
+	schedule model.BandwidthSchedule
+	limiter  *rate.Limiter
+	lock     sync.Mutex
+	now      func() time.Time
+}
+
+func (l *localLimiter) Reserve(n int) time.Duration {
+	now := l.now()
+	bps := bytesPerSecond(l.speed, l.schedule, now)
+	if bps <= 0 {
+		return 0
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.limiter == nil {
+		l.limiter = rate.NewLimiter(rate.Limit(bps), localBurst)
+	} else if l.limiter.Limit() != rate.Limit(bps) {
+		// the speed is changed by the schedule
+		l.limiter.SetLimitAt(now, rate.Limit(bps))
+	}
+	rv := l.limiter.ReserveN(now, n)
+	if !rv.OK() {
+		// exceeds the burst, wait the duration that the bytes take
+		return time.Duration(float64(n) / bps * float64(time.Second))
+	}
+	return rv.DelayFrom(now)
+}
+
+// NewSharedLimiter returns a limiter that limits the aggregate rate of the data transferred through
+// all the limiters with the same key, the limiters in different processes are coordinated through Redis.
+// The speed and schedule are same as "NewLimiter". The rate is limited only in the current process when
+// the Redis is unavailable
+func NewSharedLimiter(pool *redis.Pool, key string, speed int32, schedule model.BandwidthSchedule) Limiter {
+	local := NewLimiter(speed, schedule)
+	if pool == nil {
+		return local
+	}
+	return &sharedLimiter{
+		pool:     pool,
+		key:      key,
+		speed:    speed,
+		schedule: schedule,
+		local:    local,
+		now:      time.Now,
+	}
+}
+
+type sharedLimiter struct {
+	pool     *redis.Pool
+	key      string
+	speed    int32
+	schedule model.BandwidthSchedule
+	local    Limiter
+	lock     sync.Mutex
+	// the bytes reserved from Redis but not consumed
+	credit int
+	// whether the last reservation from Redis failed, used to avoid flooding the log
+	failed bool
+	now    func() time.Time
+}
+
+func (s *sharedLimiter) Reserve(n int) time.Duration {
+	now := s.now()
+	bps := bytesPerSecond(s.speed, s.schedule, now)
+	if bps <= 0 {
+		return 0
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.credit >= n {
+		s.credit -= n
+		return 0
+	}
+
+	quantum := int(bps * sharedQuantum.Seconds())
+	if quantum < n {
+		quantum = n
+	}
+	delay, err := s.reserve(now, quantum, bps)
+	if err != nil {
+		if !s.failed {
+			log.Warningf("failed to reserve bandwidth from %s, limit the rate in the current process: %v", s.key, err)
+			s.failed = true
+		}
+		return s.local.Reserve(n)
+	}
+	s.failed = false
+	s.credit += quantum - n
+	return delay
+}
+
+func (s *sharedLimiter) reserve(now time.Time, n int, bps float64) (time.Duration, error) {
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	cost := int64(float64(n) / bps * 1e6)
+	delay, err := redis.Int64(reserveScript.Do(conn, s.key, now.UnixNano()/1e3, cost, sharedQuantum.Microseconds()))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(delay) * time.Microsecond, nil
+}
+
+// NewMultiLimiter returns a limiter that limits the rate by all the limiters, the
+// strictest one takes effect. The nil limiters are ignored and nil is returned if
+// no limiter is provided
+func NewMultiLimiter(limiters ...Limiter) Limiter {
+	var ls multiLimiter
+	for _, limiter := range limiters {
+		if limiter != nil {
+			ls = append(ls, limiter)
+		}
+	}
+	switch len(ls) {
+	case 0:
+		return nil
+	case 1:
+		return ls[0]
+	default:
+		return ls
+	}
+}
+
+type multiLimiter []Limiter
+
+func (m multiLimiter) Reserve(n int) time.Duration {
+	var delay time.Duration
+	for _, limiter := range m {
+		if d := limiter.Reserve(n); d > delay {
+			delay = d
+		}
+	}
+	return delay
+}
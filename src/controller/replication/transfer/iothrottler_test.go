@@ -0,0 +1,70 @@
+package transfer
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakedLimiter struct {
+	delay    time.Duration
+	reserved int
+}
+
+func (f *fakedLimiter) Reserve(n int) time.Duration {
+	f.reserved += n
+	return f.delay
+}
+
+func TestReader(t *testing.T) {
+	limiter := &fakedLimiter{}
+	r := NewReader(ioutil.NopCloser(bytes.NewReader([]byte("hello"))), limiter)
+	data, err := ioutil.ReadAll(r)
+	require.Nil(t, err)
+	assert.Equal(t, "hello", string(data))
+	assert.Equal(t, 5, limiter.reserved)
+	assert.Nil(t, r.Close())
+}
+
+func TestLocalLimiter(t *testing.T) {
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.Local)
+	limiter := NewLimiter(8, model.BandwidthSchedule{
+		{Start: "00:00", End: "06:00", Speed: 0},
+	}).(*localLimiter)
+	limiter.now = func() time.Time { return now }
+
+	// 8 kb/s = 1024 bytes/s, the burst is consumed first
+	assert.Equal(t, time.Duration(0), limiter.Reserve(localBurst))
+	assert.Equal(t, time.Second, limiter.Reserve(1024))
+
+	// unlimited in the window
+	now = time.Date(2021, 1, 2, 1, 0, 0, 0, time.Local)
+	assert.Equal(t, time.Duration(0), limiter.Reserve(1024*1024*1024))
+
+	// unlimited
+	assert.Equal(t, time.Duration(0), NewLimiter(0, nil).Reserve(1024*1024*1024))
+}
+
+func TestSharedLimiterWithoutRedis(t *testing.T) {
+	_, ok := NewSharedLimiter(nil, "key", 8, nil).(*localLimiter)
+	assert.True(t, ok)
+}
+
+func TestMultiLimiter(t *testing.T) {
+	assert.Nil(t, NewMultiLimiter())
+	assert.Nil(t, NewMultiLimiter(nil, nil))
+
+	l1 := &fakedLimiter{delay: time.Second}
+	assert.Equal(t, l1, NewMultiLimiter(nil, l1))
+
+	l2 := &fakedLimiter{delay: 2 * time.Second}
+	limiter := NewMultiLimiter(l1, l2)
+	assert.Equal(t, 2*time.Second, limiter.Reserve(10))
+	assert.Equal(t, 10, l1.reserved)
+	assert.Equal(t, 10, l2.reserved)
+}
@@ -26,15 +26,18 @@ var (
 )
 
 // Factory creates a specific Transfer. The "Logger" is used
-// to log the processing messages and the "StopFunc"
+// to log the processing messages, the "StopFunc"
 // can be used to check whether the task has been stopped
-// during the processing progress
-type Factory func(Logger, StopFunc) (Transfer, error)
+// during the processing progress and the "SessionStore"
+// is used to persist the progress of the blob uploads, it
+// can be nil if the progress needn't be persisted
+type Factory func(Logger, StopFunc, SessionStore) (Transfer, error)
 
 // Transfer defines an interface used to transfer the source
-// resource to the destination
+// resource to the destination, the rate of the data transferred
+// is limited by the "Limiter" if it isn't nil
 type Transfer interface {
-	Transfer(src *model.Resource, dst *model.Resource, speed int32) error
+	Transfer(src *model.Resource, dst *model.Resource, limiter Limiter) error
 }
 
 // Logger defines an interface for logging
@@ -61,6 +64,26 @@ type Logger interface {
 // process is stopped
 type StopFunc func() bool
 
+// BlobUploadSession records the progress of uploading a blob by chunks
+type BlobUploadSession struct {
+	Digest string `json:"digest"`
+	// the location of the upload session on the destination registry
+	Location string `json:"location"`
+	// the count of the bytes that have been uploaded
+	Offset int64 `json:"offset"`
+}
+
+// SessionStore persists the blob upload sessions, so that the uploads
+// can be resumed when the transfer is retried
+type SessionStore interface {
+	// Get the upload session of the specified blob, nil is returned if no session found
+	Get(digest string) (*BlobUploadSession, error)
+	// Save the upload session
+	Save(session *BlobUploadSession) error
+	// Delete the upload session of the specified blob
+	Delete(digest string) error
+}
+
 // RegisterFactory registers one transfer factory to the registry
 func RegisterFactory(name string, factory Factory) error {
 	if len(name) == 0 {
@@ -21,7 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var fakedFactory Factory = func(Logger, StopFunc) (Transfer, error) {
+var fakedFactory Factory = func(Logger, StopFunc, SessionStore) (Transfer, error) {
 	return nil, nil
 }
 
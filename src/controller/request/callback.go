@@ -0,0 +1,60 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
+)
+
+const (
+	// ExpireCallback is the name of the callback function which expires the stale requests
+	ExpireCallback = "PROJECT_REQUEST_EXPIRE"
+	// VendorTypeExpire is the vendor type of the schedule which expires the stale requests
+	VendorTypeExpire = "PROJECT_REQUEST_EXPIRE"
+	// the TTL of the requests is in hours, so checking every 10 minutes is accurate enough
+	expireCron = "0 */10 * * * *"
+)
+
+func init() {
+	if err := scheduler.RegisterCallbackFunc(ExpireCallback, expireCallback); err != nil {
+		log.Fatalf("failed to register the callback function %s: %v", ExpireCallback, err)
+	}
+}
+
+func expireCallback(ctx context.Context, param string) error {
+	count, err := Ctl.Expire(ctx)
+	if err != nil {
+		return err
+	}
+	log.Debugf("%d project request(s) expired", count)
+	return nil
+}
+
+// EnsureExpireSchedule creates the schedule which expires the stale requests periodically if it doesn't exist
+func EnsureExpireSchedule(ctx context.Context) error {
+	schedules, err := scheduler.Sched.ListSchedules(ctx, q.New(q.KeyWords{"VendorType": VendorTypeExpire}))
+	if err != nil {
+		return err
+	}
+	if len(schedules) > 0 {
+		return nil
+	}
+	_, err = scheduler.Sched.Schedule(ctx, VendorTypeExpire, 0, "Custom", expireCron, ExpireCallback, nil, nil)
+	return err
+}
@@ -16,10 +16,31 @@ package request
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar"
+	"github.com/goharbor/harbor/src/common"
 	event "github.com/goharbor/harbor/src/controller/event/metadata"
 	"github.com/goharbor/harbor/src/controller/event/operator"
+	"github.com/goharbor/harbor/src/controller/member"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/quota"
+	"github.com/goharbor/harbor/src/controller/registry"
+	"github.com/goharbor/harbor/src/controller/retention"
+	"github.com/goharbor/harbor/src/core/auth"
+	"github.com/goharbor/harbor/src/lib/config"
+	cfgModels "github.com/goharbor/harbor/src/lib/config/models"
 	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/project/metadata"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/quota/types"
+	"github.com/goharbor/harbor/src/pkg/retention/policy"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 	"github.com/goharbor/harbor/src/pkg/user"
+	"github.com/goharbor/harbor/src/pkg/usergroup"
 
 	_ "github.com/goharbor/harbor/src/controller/event/operator"
 	"github.com/goharbor/harbor/src/lib/errors"
@@ -31,11 +52,21 @@ import (
 	"github.com/goharbor/harbor/src/pkg/request/models"
 )
 
+// SystemOperator is the operator recorded in the histories of the transitions made by the system, e.g. expiration
+const SystemOperator = "system"
+
 var (
 	// Ctl is a global project controller instance
 	Ctl = NewController()
+
+	// the functions to onboard the users and groups from the external auth server, mocked in UT
+	searchAndOnBoardUser  = auth.SearchAndOnBoardUser
+	searchAndOnBoardGroup = auth.SearchAndOnBoardGroup
 )
 
+// the days to retain the images of the proxy cache project since last pull
+const defaultDaysToRetentionForProxyCacheProject = 7
+
 // Request alias to models.Request
 type Request = models.Request
 
@@ -55,26 +86,58 @@ type Controller interface {
 	GetByName(ctx context.Context, requestName string, options ...Option) (*models.Request, error)
 	// List list requests
 	List(ctx context.Context, query *q.Query, options ...Option) ([]*models.Request, error)
-	// Approve the request
-	Approve(ctx context.Context, project *models.Request) error
-	// Reject the request
+	// Approve the request, the project and its quota are created in the same transaction
+	// as the request state update. It returns the ID of the project, approving an already
+	// approved request returns the existing project.
+	Approve(ctx context.Context, project *models.Request) (int64, error)
+	// AutoApprove approves the pending request when it matches one of the auto-approval rules, the groupIDs are
+	// the usergroups which the requester belongs to. It returns the name of the matched rule, empty when none of
+	// the rules matches the request.
+	AutoApprove(ctx context.Context, project *models.Request, groupIDs []int) (string, error)
+	// Reject the request with the comment of it
 	Reject(ctx context.Context, project *models.Request) error
+	// Cancel the request, it's used by the requester to withdraw the pending request
+	Cancel(ctx context.Context, project *models.Request) error
+	// Expire marks the pending requests which live longer than the TTL as expired, returns the count of them
+	Expire(ctx context.Context) (int64, error)
+	// CountHistories returns the total count of the state transitions of the request according to the query
+	CountHistories(ctx context.Context, requestID int64, query *q.Query) (int64, error)
+	// ListHistories list the state transitions of the request according to the query
+	ListHistories(ctx context.Context, requestID int64, query *q.Query) ([]*models.History, error)
 }
 
 // NewController creates an instance of the default project controller
 func NewController() Controller {
 	return &controller{
-		requestMgr: request.Mgr,
-		userMgr:    user.Mgr,
+		requestMgr:   request.Mgr,
+		userMgr:      user.Mgr,
+		projectCtl:   project.Ctl,
+		quotaCtl:     quota.Ctl,
+		memberCtl:    member.NewController(),
+		registryCtl:  registry.Ctl,
+		retentionCtl: retention.Ctl,
+		metaMgr:      metadata.Mgr,
+		groupMgr:     usergroup.Mgr,
 	}
 }
 
 type controller struct {
-	requestMgr request.Manager
-	userMgr    user.Manager
+	requestMgr   request.Manager
+	userMgr      user.Manager
+	projectCtl   project.Controller
+	quotaCtl     quota.Controller
+	memberCtl    member.Controller
+	registryCtl  registry.Controller
+	retentionCtl retention.Controller
+	metaMgr      metadata.Manager
+	groupMgr     usergroup.Manager
 }
 
 func (c *controller) Create(ctx context.Context, request *models.Request) (int64, error) {
+	if err := c.validateSettings(ctx, request); err != nil {
+		return 0, err
+	}
+
 	var requestID int64
 	h := func(ctx context.Context) (err error) {
 		requestID, err = c.requestMgr.Create(ctx, request)
@@ -88,6 +151,15 @@ func (c *controller) Create(ctx context.Context, request *models.Request) (int64
 		return 0, err
 	}
 
+	e := &event.CreateRequestEventMetadata{
+		RequestID:    requestID,
+		Project:      request.Name,
+		OwnerID:      request.OwnerID,
+		Requester:    request.OwnerName,
+		StorageQuota: request.StorageQuota,
+		Operator:     operator.FromContext(ctx),
+	}
+	notification.AddEvent(ctx, e)
 	return requestID, nil
 }
 
@@ -96,7 +168,7 @@ func (c *controller) Count(ctx context.Context, query *q.Query) (int64, error) {
 }
 
 func (c *controller) Delete(ctx context.Context, id int64) error {
-	_, err := c.Get(ctx, id)
+	r, err := c.Get(ctx, id, WithOwner())
 	if err != nil {
 		return err
 	}
@@ -105,6 +177,15 @@ func (c *controller) Delete(ctx context.Context, id int64) error {
 		return err
 	}
 
+	e := &event.DeleteRequestEventMetadata{
+		RequestID:    r.RequestID,
+		Project:      r.Name,
+		OwnerID:      r.OwnerID,
+		Requester:    r.OwnerName,
+		StorageQuota: r.StorageQuota,
+		Operator:     operator.FromContext(ctx),
+	}
+	notification.AddEvent(ctx, e)
 	return nil
 }
 
@@ -166,42 +247,618 @@ func (c *controller) List(ctx context.Context, query *q.Query, options ...Option
 	return requests, nil
 }
 
-func (c *controller) Approve(ctx context.Context, p *models.Request) error {
-	if err := c.requestMgr.Approve(ctx, p); err != nil {
-		return err
+func (c *controller) Approve(ctx context.Context, p *models.Request) (int64, error) {
+	return c.approve(ctx, p, "")
+}
+
+func (c *controller) AutoApprove(ctx context.Context, p *models.Request, groupIDs []int) (string, error) {
+	rules, err := config.ProjectRequestApprovalRules(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get the auto-approval rules of project request")
+	}
+	if len(rules) == 0 {
+		return "", nil
+	}
+
+	groups, err := c.groupNames(ctx, groupIDs)
+	if err != nil {
+		return "", err
+	}
+
+	storageQuota, err := requestedStorageQuota(ctx, p)
+	if err != nil {
+		return "", err
+	}
+
+	for _, rule := range rules {
+		// the rules are validated when the configuration is updated, this guards the ones set otherwise
+		if err := rule.Validate(); err != nil {
+			log.G(ctx).Warningf("skip the invalid auto-approval rule: %v", err)
+			continue
+		}
+		matched, err := c.match(ctx, rule, p, groups, storageQuota)
+		if err != nil {
+			return "", err
+		}
+		if !matched {
+			continue
+		}
+
+		if _, err := c.approve(ctx, p, rule.Name); err != nil {
+			return "", err
+		}
+		return rule.Name, nil
+	}
+
+	return "", nil
+}
+
+// approve the request, the rule is the name of the auto-approval rule and it's empty when approved manually
+func (c *controller) approve(ctx context.Context, p *models.Request, rule string) (int64, error) {
+	var (
+		projectID       int64
+		alreadyApproved bool
+		expired         bool
+	)
+	h := func(ctx context.Context) error {
+		// reload and lock the request in the transaction, so the concurrent calls are serialized
+		// and only the first one creates the project
+		r, err := c.requestMgr.GetForUpdate(ctx, p.RequestID)
+		if err != nil {
+			return err
+		}
+
+		if r.IsApproved == models.Approved {
+			proj, err := c.projectCtl.Get(ctx, r.Name)
+			if err != nil {
+				return err
+			}
+			projectID = proj.ProjectID
+			alreadyApproved = true
+			return nil
+		}
+
+		if expired = isStale(ctx, r); expired {
+			return c.expire(ctx, r)
+		}
+
+		if !r.IsPending() {
+			return errors.PreconditionFailedError(nil).WithMessage("the request %s is %s", r.Name, models.StateName(r.IsApproved))
+		}
+
+		projectID, err = c.projectCtl.Create(ctx, &project.Project{
+			Name:       r.Name,
+			OwnerID:    r.OwnerID,
+			RegistryID: r.RegistryID,
+			Metadata:   projectMetadata(r),
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := c.createQuota(ctx, projectID, r.StorageQuota); err != nil {
+			return err
+		}
+
+		if err := c.addMembers(ctx, projectID, r.Members); err != nil {
+			return err
+		}
+
+		if r.RegistryID != 0 {
+			if err := c.createProxyCacheRetention(ctx, projectID); err != nil {
+				return err
+			}
+		}
+
+		r.Comment = p.Comment
+		if rule != "" {
+			r.Comment = fmt.Sprintf("approved automatically by rule %s", rule)
+		}
+		p.Comment = r.Comment
+		return c.transit(ctx, r, models.Approved, rule)
 	}
+
+	if err := orm.WithTransaction(h)(orm.SetTransactionOpNameToContext(ctx, "tx-approve-request")); err != nil {
+		return 0, err
+	}
+
+	if expired {
+		p.IsApproved = models.Expired
+		return 0, errors.PreconditionFailedError(nil).WithMessage("the request %s is expired", p.Name)
+	}
+
+	p.IsApproved = models.Approved
+	if alreadyApproved {
+		log.G(ctx).Debugf("the request %s is already approved, project id is %d", p.Name, projectID)
+		return projectID, nil
+	}
+
 	owner, err := c.userMgr.Get(ctx, p.OwnerID)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	e := &event.ApproveRequestEventMetadata{
-		Project:  p.Name,
-		OwnerID:  owner.UserID,
-		Operator: operator.FromContext(ctx),
+		RequestID:    p.RequestID,
+		ProjectID:    projectID,
+		Project:      p.Name,
+		OwnerID:      owner.UserID,
+		Requester:    owner.Username,
+		StorageQuota: p.StorageQuota,
+		Reason:       p.Comment,
+		Operator:     operator.FromContext(ctx),
 	}
 	notification.AddEvent(ctx, e)
-	return nil
+	return projectID, nil
 }
 
 func (c *controller) Reject(ctx context.Context, p *models.Request) error {
-	if err := c.requestMgr.Reject(ctx, p); err != nil {
+	if err := c.close(ctx, p, models.Rejected); err != nil {
 		return err
 	}
+
 	owner, err := c.userMgr.Get(ctx, p.OwnerID)
 	if err != nil {
 		return err
 	}
 
 	e := &event.RejectRequestEventMetadata{
-		Project:  p.Name,
-		OwnerID:  owner.UserID,
-		Operator: operator.FromContext(ctx),
+		RequestID:    p.RequestID,
+		Project:      p.Name,
+		OwnerID:      owner.UserID,
+		Requester:    owner.Username,
+		StorageQuota: p.StorageQuota,
+		Reason:       p.Comment,
+		Operator:     operator.FromContext(ctx),
 	}
 	notification.AddEvent(ctx, e)
 	return nil
 }
 
+func (c *controller) Cancel(ctx context.Context, p *models.Request) error {
+	return c.close(ctx, p, models.Cancelled)
+}
+
+func (c *controller) Expire(ctx context.Context) (int64, error) {
+	ttl := config.ProjectRequestTTL(ctx)
+	if ttl <= 0 {
+		return 0, nil
+	}
+
+	var count int64
+	h := func(ctx context.Context) error {
+		query := q.New(q.KeyWords{
+			"is_approved":   models.NotDetermined,
+			"creation_time": &q.Range{Max: time.Now().Add(-time.Duration(ttl) * time.Hour)},
+		})
+		requests, err := c.requestMgr.List(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range requests {
+			// lock the request, it may be approved or closed by a concurrent call
+			r, err = c.requestMgr.GetForUpdate(ctx, r.RequestID)
+			if err != nil {
+				return err
+			}
+			if !r.IsPending() {
+				continue
+			}
+			if err := c.expire(ctx, r); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	}
+
+	if err := orm.WithTransaction(h)(orm.SetTransactionOpNameToContext(ctx, "tx-expire-request")); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (c *controller) CountHistories(ctx context.Context, requestID int64, query *q.Query) (int64, error) {
+	query = q.MustClone(query)
+	query.Keywords["request_id"] = requestID
+	return c.requestMgr.CountHistories(ctx, query)
+}
+
+func (c *controller) ListHistories(ctx context.Context, requestID int64, query *q.Query) ([]*models.History, error) {
+	query = q.MustClone(query)
+	query.Keywords["request_id"] = requestID
+	return c.requestMgr.ListHistories(ctx, query)
+}
+
+// close transits the pending request to the final state which doesn't create project
+func (c *controller) close(ctx context.Context, p *models.Request, state int) error {
+	var expired bool
+	h := func(ctx context.Context) error {
+		r, err := c.requestMgr.GetForUpdate(ctx, p.RequestID)
+		if err != nil {
+			return err
+		}
+
+		if expired = isStale(ctx, r); expired {
+			return c.expire(ctx, r)
+		}
+
+		if !r.IsPending() {
+			return errors.PreconditionFailedError(nil).WithMessage("the request %s is %s", r.Name, models.StateName(r.IsApproved))
+		}
+
+		r.Comment = p.Comment
+		return c.transit(ctx, r, state, "")
+	}
+
+	if err := orm.WithTransaction(h)(orm.SetTransactionOpNameToContext(ctx, "tx-close-request")); err != nil {
+		return err
+	}
+
+	if expired {
+		p.IsApproved = models.Expired
+		return errors.PreconditionFailedError(nil).WithMessage("the request %s is expired", p.Name)
+	}
+
+	p.IsApproved = state
+	return nil
+}
+
+func (c *controller) expire(ctx context.Context, r *models.Request) error {
+	r.Comment = fmt.Sprintf("expired after %d hours without decision", config.ProjectRequestTTL(ctx))
+	return c.transit(ctx, r, models.Expired, "")
+}
+
+// transit updates the state of the request and records the transition, it should be called in transaction,
+// the rule is the name of the auto-approval rule which causes the transition
+func (c *controller) transit(ctx context.Context, r *models.Request, state int, rule string) error {
+	from := r.IsApproved
+
+	var err error
+	switch state {
+	case models.Approved:
+		err = c.requestMgr.Approve(ctx, r)
+	case models.Rejected:
+		err = c.requestMgr.Reject(ctx, r)
+	case models.Cancelled:
+		err = c.requestMgr.Cancel(ctx, r)
+	case models.Expired:
+		err = c.requestMgr.Expire(ctx, r)
+	default:
+		err = errors.Errorf("unsupported state %d of request", state)
+	}
+	if err != nil {
+		return err
+	}
+
+	op := operator.FromContext(ctx)
+	// the expiration is done by the system rather than the user who happens to trigger it
+	if state == models.Expired {
+		op = SystemOperator
+	}
+	_, err = c.requestMgr.CreateHistory(ctx, &models.History{
+		RequestID: r.RequestID,
+		FromState: from,
+		ToState:   state,
+		Operator:  op,
+		Comment:   r.Comment,
+		Rule:      rule,
+	})
+	return err
+}
+
+// isStale returns true when the pending request lives longer than the TTL
+func isStale(ctx context.Context, r *models.Request) bool {
+	ttl := config.ProjectRequestTTL(ctx)
+	if ttl <= 0 || !r.IsPending() {
+		return false
+	}
+	return r.CreationTime.Add(time.Duration(ttl) * time.Hour).Before(time.Now())
+}
+
+// validateSettings validates the project settings carried by the request
+func (c *controller) validateSettings(ctx context.Context, r *models.Request) error {
+	for key, value := range r.Metadata {
+		switch key {
+		case proModels.ProMetaPublic, proModels.ProMetaAutoScan,
+			proModels.ProMetaPreventVul, proModels.ProMetaReuseSysCVEAllowlist:
+			v, err := strconv.ParseBool(value)
+			if err != nil {
+				return errors.BadRequestError(nil).WithMessage("invalid value of %s: %s", key, value)
+			}
+			r.Metadata[key] = strconv.FormatBool(v)
+		case proModels.ProMetaSeverity:
+			severity := vuln.ParseSeverityVersion3(strings.ToLower(value))
+			if severity == vuln.Unknown {
+				return errors.BadRequestError(nil).WithMessage("invalid value of %s: %s", key, value)
+			}
+			r.Metadata[key] = strings.ToLower(severity.String())
+		default:
+			return errors.BadRequestError(nil).WithMessage("unsupported project metadata: %s", key)
+		}
+	}
+
+	for _, m := range r.Members {
+		if !isValidRole(m.Role) {
+			return errors.BadRequestError(nil).WithMessage("invalid role %d of the member", m.Role)
+		}
+		if m.UserID <= 0 && m.Username == "" && m.GroupID <= 0 && m.GroupName == "" && m.LdapGroupDN == "" {
+			return errors.BadRequestError(nil).WithMessage("the user or group of the member is required")
+		}
+		if err := c.resolveMember(ctx, m); err != nil {
+			return err
+		}
+	}
+
+	if r.RegistryID != 0 {
+		if r.RegistryID < 0 {
+			return errors.BadRequestError(nil).WithMessage("%d is invalid value of registry_id, it should be greater than 0", r.RegistryID)
+		}
+
+		reg, err := c.registryCtl.Get(ctx, r.RegistryID)
+		if err != nil {
+			return err
+		}
+		permitted := false
+		for _, t := range config.GetPermittedRegistryTypesForProxyCache() {
+			if string(reg.Type) == t {
+				permitted = true
+				break
+			}
+		}
+		if !permitted {
+			return errors.BadRequestError(nil).WithMessage("unsupported registry type %s", string(reg.Type))
+		}
+	}
+
+	return nil
+}
+
+// resolveMember resolves the user or group of the member when the request is submitted, so the missing one is
+// reported to the requester instead of aborting the approval. The user or group found is recorded by its ID,
+// and the external ones are onboarded in the same way as adding the project member
+func (c *controller) resolveMember(ctx context.Context, m *models.Member) error {
+	switch {
+	case m.UserID > 0:
+		u, err := c.userMgr.Get(ctx, m.UserID)
+		if err != nil {
+			return memberError(err, "user %d", m.UserID)
+		}
+		m.Username = u.Username
+	case m.GroupID > 0:
+		g, err := c.groupMgr.Get(ctx, m.GroupID)
+		if err != nil {
+			return memberError(err, "group %d", m.GroupID)
+		}
+		m.GroupName, m.GroupType, m.LdapGroupDN = g.GroupName, g.GroupType, g.LdapGroupDN
+	case m.Username != "":
+		u, err := c.userMgr.GetByName(ctx, m.Username)
+		if err == nil {
+			m.UserID = u.UserID
+			return nil
+		}
+		if !errors.IsNotFoundErr(err) {
+			return err
+		}
+		id, err := searchAndOnBoardUser(ctx, m.Username)
+		if err != nil {
+			return memberError(err, "user %s", m.Username)
+		}
+		m.UserID = id
+	default:
+		query := q.New(q.KeyWords{})
+		key, altName := m.GroupName, ""
+		if m.LdapGroupDN != "" {
+			m.GroupType = common.LDAPGroupType
+			query.Keywords["LdapGroupDN"] = m.LdapGroupDN
+			key, altName = m.LdapGroupDN, m.GroupName
+		} else {
+			query.Keywords["GroupName"] = m.GroupName
+		}
+		if m.GroupType > 0 {
+			query.Keywords["GroupType"] = m.GroupType
+		}
+		groups, err := c.groupMgr.List(ctx, query)
+		if err != nil {
+			return err
+		}
+		if len(groups) > 0 {
+			m.GroupID, m.GroupType = groups[0].ID, groups[0].GroupType
+			return nil
+		}
+		id, err := searchAndOnBoardGroup(ctx, key, altName)
+		if err != nil {
+			return memberError(err, "group %s", key)
+		}
+		m.GroupID = id
+	}
+	return nil
+}
+
+// memberError converts the error of the missing member to the bad request error
+func memberError(err error, format string, v ...interface{}) error {
+	if errors.IsNotFoundErr(err) || err == auth.ErrorGroupNotExist {
+		return errors.BadRequestError(nil).WithMessage("the %s of the member is not found", fmt.Sprintf(format, v...))
+	}
+	return err
+}
+
+// projectMetadata returns the metadata of the project created for the request
+func projectMetadata(r *models.Request) map[string]string {
+	md := map[string]string{}
+	for k, v := range r.Metadata {
+		md[k] = v
+	}
+
+	// populate public metadata as false if it isn't set
+	if _, ok := md[proModels.ProMetaPublic]; !ok {
+		md[proModels.ProMetaPublic] = strconv.FormatBool(false)
+	}
+	return md
+}
+
+// addMembers adds the initial members to the project, the member which already
+// exists(e.g. the owner of the project) is skipped
+func (c *controller) addMembers(ctx context.Context, projectID int64, members []*models.Member) error {
+	for _, m := range members {
+		req := member.Request{
+			ProjectID: projectID,
+			Role:      m.Role,
+			MemberUser: member.User{
+				UserID:   m.UserID,
+				Username: m.Username,
+			},
+			MemberGroup: member.UserGroup{
+				ID:          m.GroupID,
+				GroupName:   m.GroupName,
+				GroupType:   m.GroupType,
+				LdapGroupDN: m.LdapGroupDN,
+			},
+		}
+		if _, err := c.memberCtl.Create(ctx, projectID, req); err != nil {
+			if errors.IsConflictErr(err) {
+				log.G(ctx).Debugf("the member %+v already exists in project %d, skip it", m, projectID)
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// createProxyCacheRetention creates the default retention policy for the proxy cache project
+func (c *controller) createProxyCacheRetention(ctx context.Context, projectID int64) error {
+	plc := policy.WithNDaysSinceLastPull(projectID, defaultDaysToRetentionForProxyCacheProject)
+	retentionID, err := c.retentionCtl.CreateRetention(ctx, plc)
+	if err != nil {
+		return err
+	}
+	md := map[string]string{"retention_id": strconv.FormatInt(retentionID, 10)}
+	return c.metaMgr.Add(ctx, projectID, md)
+}
+
+func isValidRole(role int) bool {
+	switch role {
+	case common.RoleProjectAdmin,
+		common.RoleMaintainer,
+		common.RoleDeveloper,
+		common.RoleGuest,
+		common.RoleLimitedGuest:
+		return true
+	default:
+		return false
+	}
+}
+
+// match returns true when the request matches all the restrictions of the auto-approval rule
+func (c *controller) match(ctx context.Context, rule *cfgModels.ProjectRequestApprovalRule, r *models.Request,
+	groups map[string]struct{}, storageQuota int64) (bool, error) {
+	if len(rule.Groups) > 0 {
+		member := false
+		for _, g := range rule.Groups {
+			if _, ok := groups[g]; ok {
+				member = true
+				break
+			}
+		}
+		if !member {
+			return false, nil
+		}
+	}
+
+	if rule.ProjectNamePattern != "" {
+		matched, err := doublestar.Match(rule.ProjectNamePattern, r.Name)
+		if err != nil {
+			log.G(ctx).Warningf("invalid project name pattern %s of the auto-approval rule %s: %v", rule.ProjectNamePattern, rule.Name, err)
+			return false, nil
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+
+	if rule.MaxStorageQuota > 0 {
+		if storageQuota == types.UNLIMITED || storageQuota > rule.MaxStorageQuota {
+			return false, nil
+		}
+	}
+
+	if rule.MaxProjectsPerUser > 0 {
+		count, err := c.projectCtl.Count(ctx, q.New(q.KeyWords{"owner_id": r.OwnerID}))
+		if err != nil {
+			return false, err
+		}
+		if count >= rule.MaxProjectsPerUser {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// groupNames returns the names of the usergroups
+func (c *controller) groupNames(ctx context.Context, groupIDs []int) (map[string]struct{}, error) {
+	names := map[string]struct{}{}
+	if len(groupIDs) == 0 {
+		return names, nil
+	}
+
+	var ids []interface{}
+	for _, id := range groupIDs {
+		ids = append(ids, id)
+	}
+	groups, err := c.groupMgr.List(ctx, q.New(q.KeyWords{"ID": &q.OrList{Values: ids}}))
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		names[g.GroupName] = struct{}{}
+	}
+	return names, nil
+}
+
+// requestedStorageQuota returns the storage quota of the project created for the request
+func requestedStorageQuota(ctx context.Context, r *models.Request) (int64, error) {
+	if r.StorageQuota != 0 {
+		return r.StorageQuota, nil
+	}
+	if !config.QuotaPerProjectEnable(ctx) {
+		return types.UNLIMITED, nil
+	}
+
+	setting, err := config.QuotaSetting(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get quota setting")
+	}
+	return setting.StoragePerProject, nil
+}
+
+// createQuota creates the storage quota for the project, when the storage quota is not specified
+// in the request the global storage per project setting is used
+func (c *controller) createQuota(ctx context.Context, projectID int64, storageQuota int64) error {
+	if storageQuota == 0 {
+		if !config.QuotaPerProjectEnable(ctx) {
+			return nil
+		}
+
+		setting, err := config.QuotaSetting(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to get quota setting")
+		}
+		storageQuota = setting.StoragePerProject
+	}
+
+	referenceID := quota.ReferenceID(projectID)
+	hardLimits := types.ResourceList{types.ResourceStorage: storageQuota}
+	if _, err := c.quotaCtl.Create(ctx, quota.ProjectReference, referenceID, hardLimits); err != nil {
+		return errors.Wrap(err, "failed to create quota for project")
+	}
+
+	return nil
+}
+
 func (c *controller) assembleRequests(ctx context.Context, requests models.Requests, options ...Option) error {
 	opts := newOptions(options...)
 
@@ -18,16 +18,29 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/goharbor/harbor/src/common"
+	usermodels "github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/controller/member"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/core/auth"
+	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
+	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
 	"github.com/goharbor/harbor/src/pkg/request/models"
-	usermodels "github.com/goharbor/harbor/src/pkg/user/models"
+	groupmodels "github.com/goharbor/harbor/src/pkg/usergroup/model"
+	membertesting "github.com/goharbor/harbor/src/testing/controller/member"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	quotatesting "github.com/goharbor/harbor/src/testing/controller/quota"
 	ormtesting "github.com/goharbor/harbor/src/testing/lib/orm"
 	"github.com/goharbor/harbor/src/testing/mock"
 	"github.com/goharbor/harbor/src/testing/pkg/request"
 	"github.com/goharbor/harbor/src/testing/pkg/user"
+	"github.com/goharbor/harbor/src/testing/pkg/usergroup"
+	testifymock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -35,6 +48,12 @@ type ControllerTestSuite struct {
 	suite.Suite
 }
 
+func (suite *ControllerTestSuite) SetupTest() {
+	config.InitWithSettings(map[string]interface{}{
+		common.ProjectRequestTTL: 24,
+	})
+}
+
 func (suite *ControllerTestSuite) TestCreate() {
 	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
 	mgr := &request.Manager{}
@@ -64,6 +83,114 @@ func (suite *ControllerTestSuite) TestCreate() {
 	}
 }
 
+func (suite *ControllerTestSuite) TestCreateWithSettings() {
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+	mgr := &request.Manager{}
+	mgr.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+
+	c := controller{requestMgr: mgr}
+
+	{
+		r := &models.Request{OwnerID: 1, Metadata: map[string]string{"public": "True", "severity": "HIGH"}}
+		_, err := c.Create(ctx, r)
+		suite.Nil(err)
+		suite.Equal("true", r.Metadata["public"])
+		suite.Equal("high", r.Metadata["severity"])
+	}
+
+	{
+		_, err := c.Create(ctx, &models.Request{OwnerID: 1, Metadata: map[string]string{"auto_scan": "yes"}})
+		suite.True(errors.IsErr(err, errors.BadRequestCode))
+	}
+
+	{
+		_, err := c.Create(ctx, &models.Request{OwnerID: 1, Metadata: map[string]string{"retention_id": "1"}})
+		suite.True(errors.IsErr(err, errors.BadRequestCode))
+	}
+
+	{
+		_, err := c.Create(ctx, &models.Request{OwnerID: 1, Members: []*models.Member{{Role: 100, Username: "dev"}}})
+		suite.True(errors.IsErr(err, errors.BadRequestCode))
+	}
+
+	{
+		_, err := c.Create(ctx, &models.Request{OwnerID: 1, Members: []*models.Member{{Role: common.RoleDeveloper}}})
+		suite.True(errors.IsErr(err, errors.BadRequestCode))
+	}
+
+	{
+		_, err := c.Create(ctx, &models.Request{OwnerID: 1, RegistryID: -1})
+		suite.True(errors.IsErr(err, errors.BadRequestCode))
+	}
+
+	mgr.AssertNumberOfCalls(suite.T(), "Create", 1)
+}
+
+func (suite *ControllerTestSuite) TestCreateWithMembers() {
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+	onBoardUser, onBoardGroup := searchAndOnBoardUser, searchAndOnBoardGroup
+	defer func() { searchAndOnBoardUser, searchAndOnBoardGroup = onBoardUser, onBoardGroup }()
+	searchAndOnBoardUser = func(ctx context.Context, username string) (int, error) {
+		if username == "ldap-user" {
+			return 5, nil
+		}
+		return 0, errors.NotFoundError(nil)
+	}
+	searchAndOnBoardGroup = func(ctx context.Context, groupKey, altGroupName string) (int, error) {
+		if groupKey == "cn=ldap-group" {
+			return 6, nil
+		}
+		return 0, auth.ErrorGroupNotExist
+	}
+
+	mgr := &request.Manager{}
+	mgr.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	userMgr := &user.Manager{}
+	userMgr.On("Get", mock.Anything, 2).Return(&usermodels.User{UserID: 2, Username: "dev"}, nil)
+	userMgr.On("Get", mock.Anything, 3).Return(nil, errors.NotFoundError(nil))
+	userMgr.On("GetByName", mock.Anything, "dev").Return(&usermodels.User{UserID: 2, Username: "dev"}, nil)
+	userMgr.On("GetByName", mock.Anything, mock.Anything).Return(nil, errors.NotFoundError(nil))
+	groupMgr := &usergroup.Manager{}
+	groupMgr.On("Get", mock.Anything, 4).Return(&groupmodels.UserGroup{ID: 4, GroupName: "qa", GroupType: 3}, nil)
+	groupMgr.On("List", mock.Anything, testifymock.MatchedBy(func(query *q.Query) bool {
+		return query.Keywords["GroupName"] == "qa"
+	})).Return([]*groupmodels.UserGroup{{ID: 4, GroupName: "qa", GroupType: 3}}, nil)
+	groupMgr.On("List", mock.Anything, mock.Anything).Return([]*groupmodels.UserGroup{}, nil)
+
+	c := controller{requestMgr: mgr, userMgr: userMgr, groupMgr: groupMgr}
+
+	{
+		r := &models.Request{OwnerID: 1, Members: []*models.Member{
+			{Role: common.RoleDeveloper, UserID: 2},
+			{Role: common.RoleDeveloper, Username: "dev"},
+			{Role: common.RoleDeveloper, Username: "ldap-user"},
+			{Role: common.RoleGuest, GroupID: 4},
+			{Role: common.RoleGuest, GroupName: "qa"},
+			{Role: common.RoleGuest, LdapGroupDN: "cn=ldap-group"},
+		}}
+		_, err := c.Create(ctx, r)
+		suite.Require().Nil(err)
+		suite.Equal("dev", r.Members[0].Username)
+		suite.Equal(2, r.Members[1].UserID)
+		suite.Equal(5, r.Members[2].UserID)
+		suite.Equal("qa", r.Members[3].GroupName)
+		suite.Equal(4, r.Members[4].GroupID)
+		suite.Equal(6, r.Members[5].GroupID)
+		suite.Equal(common.LDAPGroupType, r.Members[5].GroupType)
+	}
+
+	// the missing users and groups are rejected at submission
+	for _, m := range []*models.Member{
+		{Role: common.RoleDeveloper, UserID: 3},
+		{Role: common.RoleDeveloper, Username: "nobody"},
+		{Role: common.RoleGuest, GroupName: "nobody"},
+	} {
+		_, err := c.Create(ctx, &models.Request{OwnerID: 1, Members: []*models.Member{m}})
+		suite.True(errors.IsErr(err, errors.BadRequestCode))
+	}
+	mgr.AssertNumberOfCalls(suite.T(), "Create", 1)
+}
+
 func (suite *ControllerTestSuite) TestGetByName() {
 	ctx := context.TODO()
 
@@ -135,6 +262,305 @@ func (suite *ControllerTestSuite) TestWithOwner() {
 	}
 }
 
+func (suite *ControllerTestSuite) TestApprove() {
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+
+	{
+		// pending request, project and quota are created
+		mgr := &request.Manager{}
+		mgr.On("GetForUpdate", mock.Anything, int64(1)).Return(&models.Request{RequestID: 1, OwnerID: 2, Name: "tmaxcloud", StorageQuota: 1024, CreationTime: time.Now()}, nil)
+		mgr.On("Approve", mock.Anything, mock.Anything).Return(nil)
+		mgr.On("CreateHistory", mock.Anything, testifymock.MatchedBy(func(h *models.History) bool {
+			return h.FromState == models.NotDetermined && h.ToState == models.Approved && h.Comment == "lgtm"
+		})).Return(int64(1), nil)
+		projectCtl := &projecttesting.Controller{}
+		projectCtl.On("Create", mock.Anything, mock.Anything).Return(int64(3), nil)
+		quotaCtl := &quotatesting.Controller{}
+		quotaCtl.On("Create", mock.Anything, "project", "3", mock.Anything).Return(int64(1), nil)
+		userMgr := &user.Manager{}
+		userMgr.On("Get", mock.Anything, 2).Return(&usermodels.User{UserID: 2, Username: "dev"}, nil)
+
+		c := controller{requestMgr: mgr, userMgr: userMgr, projectCtl: projectCtl, quotaCtl: quotaCtl}
+		req := &models.Request{RequestID: 1, OwnerID: 2, Name: "tmaxcloud", Comment: "lgtm"}
+		projectID, err := c.Approve(ctx, req)
+		suite.Nil(err)
+		suite.Equal(int64(3), projectID)
+		suite.Equal(models.Approved, req.IsApproved)
+		mgr.AssertExpectations(suite.T())
+		quotaCtl.AssertExpectations(suite.T())
+	}
+
+	{
+		// already approved request returns the existing project
+		mgr := &request.Manager{}
+		mgr.On("GetForUpdate", mock.Anything, int64(1)).Return(&models.Request{RequestID: 1, OwnerID: 2, Name: "tmaxcloud", IsApproved: models.Approved}, nil)
+		projectCtl := &projecttesting.Controller{}
+		projectCtl.On("Get", mock.Anything, "tmaxcloud").Return(&project.Project{ProjectID: 3, Name: "tmaxcloud"}, nil)
+
+		c := controller{requestMgr: mgr, projectCtl: projectCtl}
+		projectID, err := c.Approve(ctx, &models.Request{RequestID: 1, OwnerID: 2, Name: "tmaxcloud"})
+		suite.Nil(err)
+		suite.Equal(int64(3), projectID)
+		projectCtl.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+	}
+
+	{
+		// failed to create the quota, the request is not approved
+		mgr := &request.Manager{}
+		mgr.On("GetForUpdate", mock.Anything, int64(1)).Return(&models.Request{RequestID: 1, OwnerID: 2, Name: "tmaxcloud", StorageQuota: 1024, CreationTime: time.Now()}, nil)
+		projectCtl := &projecttesting.Controller{}
+		projectCtl.On("Create", mock.Anything, mock.Anything).Return(int64(3), nil)
+		quotaCtl := &quotatesting.Controller{}
+		quotaCtl.On("Create", mock.Anything, "project", "3", mock.Anything).Return(int64(0), fmt.Errorf("oops"))
+
+		c := controller{requestMgr: mgr, projectCtl: projectCtl, quotaCtl: quotaCtl}
+		req := &models.Request{RequestID: 1, OwnerID: 2, Name: "tmaxcloud"}
+		_, err := c.Approve(ctx, req)
+		suite.Error(err)
+		suite.Equal(models.NotDetermined, req.IsApproved)
+		mgr.AssertNotCalled(suite.T(), "Approve", mock.Anything, mock.Anything)
+	}
+}
+
+func (suite *ControllerTestSuite) TestApproveWithSettings() {
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+
+	mgr := &request.Manager{}
+	mgr.On("GetForUpdate", mock.Anything, int64(1)).Return(&models.Request{
+		RequestID:    1,
+		OwnerID:      2,
+		Name:         "tmaxcloud",
+		StorageQuota: 1024,
+		CreationTime: time.Now(),
+		Metadata:     map[string]string{"auto_scan": "true"},
+		Members: []*models.Member{
+			{Role: common.RoleDeveloper, Username: "dev"},
+			{Role: common.RoleGuest, GroupName: "qa", GroupType: 1},
+		},
+	}, nil)
+	mgr.On("Approve", mock.Anything, mock.Anything).Return(nil)
+	mgr.On("CreateHistory", mock.Anything, mock.Anything).Return(int64(1), nil)
+	projectCtl := &projecttesting.Controller{}
+	projectCtl.On("Create", mock.Anything, testifymock.MatchedBy(func(p *project.Project) bool {
+		return p.Metadata["auto_scan"] == "true" && p.Metadata["public"] == "false"
+	})).Return(int64(3), nil)
+	quotaCtl := &quotatesting.Controller{}
+	quotaCtl.On("Create", mock.Anything, "project", "3", mock.Anything).Return(int64(1), nil)
+	memberCtl := &membertesting.Controller{}
+	memberCtl.On("Create", mock.Anything, int64(3), testifymock.MatchedBy(func(req member.Request) bool {
+		return req.Role == common.RoleDeveloper && req.MemberUser.Username == "dev"
+	})).Return(1, nil)
+	memberCtl.On("Create", mock.Anything, int64(3), testifymock.MatchedBy(func(req member.Request) bool {
+		return req.Role == common.RoleGuest && req.MemberGroup.GroupName == "qa"
+	})).Return(0, errors.ConflictError(nil))
+	userMgr := &user.Manager{}
+	userMgr.On("Get", mock.Anything, 2).Return(&usermodels.User{UserID: 2, Username: "dev"}, nil)
+
+	c := controller{requestMgr: mgr, userMgr: userMgr, projectCtl: projectCtl, quotaCtl: quotaCtl, memberCtl: memberCtl}
+	projectID, err := c.Approve(ctx, &models.Request{RequestID: 1, OwnerID: 2, Name: "tmaxcloud"})
+	suite.Nil(err)
+	suite.Equal(int64(3), projectID)
+	projectCtl.AssertExpectations(suite.T())
+	memberCtl.AssertNumberOfCalls(suite.T(), "Create", 2)
+}
+
+func (suite *ControllerTestSuite) TestAutoApprove() {
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+	config.InitWithSettings(map[string]interface{}{
+		common.ProjectRequestApprovalRules: `[
+			{"name": "qa", "groups": ["qa"]},
+			{"name": "dev", "project_name_pattern": "dev-*", "max_storage_quota": 1024, "max_projects_per_user": 2}
+		]`,
+	})
+	defer suite.SetupTest()
+
+	newController := func(projectCount int64) (*controller, *request.Manager, *projecttesting.Controller) {
+		mgr := &request.Manager{}
+		mgr.On("GetForUpdate", mock.Anything, int64(1)).Return(&models.Request{RequestID: 1, OwnerID: 2, Name: "dev-foo", StorageQuota: 1024, CreationTime: time.Now()}, nil)
+		mgr.On("Approve", mock.Anything, mock.Anything).Return(nil)
+		mgr.On("CreateHistory", mock.Anything, testifymock.MatchedBy(func(h *models.History) bool {
+			return h.ToState == models.Approved && h.Rule != ""
+		})).Return(int64(1), nil)
+		projectCtl := &projecttesting.Controller{}
+		projectCtl.On("Count", mock.Anything, mock.Anything).Return(projectCount, nil)
+		projectCtl.On("Create", mock.Anything, mock.Anything).Return(int64(3), nil)
+		quotaCtl := &quotatesting.Controller{}
+		quotaCtl.On("Create", mock.Anything, "project", "3", mock.Anything).Return(int64(1), nil)
+		userMgr := &user.Manager{}
+		userMgr.On("Get", mock.Anything, 2).Return(&usermodels.User{UserID: 2, Username: "dev"}, nil)
+		groupMgr := &usergroup.Manager{}
+		groupMgr.On("List", mock.Anything, mock.Anything).Return([]*groupmodels.UserGroup{{ID: 5, GroupName: "qa"}}, nil)
+		return &controller{requestMgr: mgr, userMgr: userMgr, projectCtl: projectCtl, quotaCtl: quotaCtl, groupMgr: groupMgr}, mgr, projectCtl
+	}
+
+	{
+		// matched by the group
+		c, mgr, _ := newController(0)
+		rule, err := c.AutoApprove(ctx, &models.Request{RequestID: 1, OwnerID: 2, Name: "foo"}, []int{5})
+		suite.Nil(err)
+		suite.Equal("qa", rule)
+		mgr.AssertCalled(suite.T(), "CreateHistory", mock.Anything, testifymock.MatchedBy(func(h *models.History) bool {
+			return h.Rule == "qa"
+		}))
+	}
+
+	{
+		// matched by the project name, storage quota and the count of the projects
+		c, _, _ := newController(1)
+		rule, err := c.AutoApprove(ctx, &models.Request{RequestID: 1, OwnerID: 2, Name: "dev-foo", StorageQuota: 1024}, nil)
+		suite.Nil(err)
+		suite.Equal("dev", rule)
+	}
+
+	{
+		// the project name doesn't match
+		c, mgr, _ := newController(0)
+		rule, err := c.AutoApprove(ctx, &models.Request{RequestID: 1, OwnerID: 2, Name: "foo", StorageQuota: 1024}, nil)
+		suite.Nil(err)
+		suite.Empty(rule)
+		mgr.AssertNotCalled(suite.T(), "Approve", mock.Anything, mock.Anything)
+	}
+
+	{
+		// the storage quota exceeds the limit
+		c, _, projectCtl := newController(0)
+		rule, err := c.AutoApprove(ctx, &models.Request{RequestID: 1, OwnerID: 2, Name: "dev-foo", StorageQuota: -1}, nil)
+		suite.Nil(err)
+		suite.Empty(rule)
+		projectCtl.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+	}
+
+	{
+		// the requester owns too many projects
+		c, _, projectCtl := newController(2)
+		rule, err := c.AutoApprove(ctx, &models.Request{RequestID: 1, OwnerID: 2, Name: "dev-foo", StorageQuota: 1024}, nil)
+		suite.Nil(err)
+		suite.Empty(rule)
+		projectCtl.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+	}
+}
+
+func (suite *ControllerTestSuite) TestAutoApproveWithoutRules() {
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+	mgr := &request.Manager{}
+
+	c := controller{requestMgr: mgr}
+	rule, err := c.AutoApprove(ctx, &models.Request{RequestID: 1, OwnerID: 2, Name: "foo"}, nil)
+	suite.Nil(err)
+	suite.Empty(rule)
+	mgr.AssertNotCalled(suite.T(), "Get", mock.Anything, mock.Anything)
+}
+
+func (suite *ControllerTestSuite) TestAutoApproveSkipInvalidRules() {
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+	config.InitWithSettings(map[string]interface{}{
+		// the rule without any restriction doesn't approve all the requests
+		common.ProjectRequestApprovalRules: `[{"name": "all"}]`,
+	})
+	defer suite.SetupTest()
+	mgr := &request.Manager{}
+
+	c := controller{requestMgr: mgr}
+	rule, err := c.AutoApprove(ctx, &models.Request{RequestID: 1, OwnerID: 2, Name: "foo", StorageQuota: 1024}, nil)
+	suite.Nil(err)
+	suite.Empty(rule)
+	mgr.AssertNotCalled(suite.T(), "Approve", mock.Anything, mock.Anything)
+}
+
+func (suite *ControllerTestSuite) TestApproveExpired() {
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+
+	mgr := &request.Manager{}
+	mgr.On("GetForUpdate", mock.Anything, int64(1)).Return(&models.Request{RequestID: 1, OwnerID: 2, Name: "tmaxcloud", CreationTime: time.Now().Add(-48 * time.Hour)}, nil)
+	mgr.On("Expire", mock.Anything, mock.Anything).Return(nil)
+	mgr.On("CreateHistory", mock.Anything, testifymock.MatchedBy(func(h *models.History) bool {
+		return h.ToState == models.Expired
+	})).Return(int64(1), nil)
+	projectCtl := &projecttesting.Controller{}
+
+	c := controller{requestMgr: mgr, projectCtl: projectCtl}
+	req := &models.Request{RequestID: 1, OwnerID: 2, Name: "tmaxcloud"}
+	_, err := c.Approve(ctx, req)
+	suite.Error(err)
+	suite.True(errors.IsErr(err, errors.PreconditionCode))
+	suite.Equal(models.Expired, req.IsApproved)
+	projectCtl.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+	mgr.AssertExpectations(suite.T())
+}
+
+func (suite *ControllerTestSuite) TestReject() {
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+
+	{
+		mgr := &request.Manager{}
+		mgr.On("GetForUpdate", mock.Anything, int64(1)).Return(&models.Request{RequestID: 1, OwnerID: 2, Name: "tmaxcloud", CreationTime: time.Now()}, nil)
+		mgr.On("Reject", mock.Anything, mock.Anything).Return(nil)
+		mgr.On("CreateHistory", mock.Anything, testifymock.MatchedBy(func(h *models.History) bool {
+			return h.ToState == models.Rejected && h.Comment == "name is reserved"
+		})).Return(int64(1), nil)
+		userMgr := &user.Manager{}
+		userMgr.On("Get", mock.Anything, 2).Return(&usermodels.User{UserID: 2, Username: "dev"}, nil)
+
+		c := controller{requestMgr: mgr, userMgr: userMgr}
+		req := &models.Request{RequestID: 1, OwnerID: 2, Name: "tmaxcloud", Comment: "name is reserved"}
+		suite.Nil(c.Reject(ctx, req))
+		suite.Equal(models.Rejected, req.IsApproved)
+		mgr.AssertExpectations(suite.T())
+	}
+
+	{
+		// the request is already approved
+		mgr := &request.Manager{}
+		mgr.On("GetForUpdate", mock.Anything, int64(1)).Return(&models.Request{RequestID: 1, OwnerID: 2, Name: "tmaxcloud", IsApproved: models.Approved}, nil)
+
+		c := controller{requestMgr: mgr}
+		err := c.Reject(ctx, &models.Request{RequestID: 1, OwnerID: 2, Name: "tmaxcloud"})
+		suite.Error(err)
+		suite.True(errors.IsErr(err, errors.PreconditionCode))
+		mgr.AssertNotCalled(suite.T(), "Reject", mock.Anything, mock.Anything)
+	}
+}
+
+func (suite *ControllerTestSuite) TestCancel() {
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+
+	mgr := &request.Manager{}
+	mgr.On("GetForUpdate", mock.Anything, int64(1)).Return(&models.Request{RequestID: 1, OwnerID: 2, Name: "tmaxcloud", CreationTime: time.Now()}, nil)
+	mgr.On("Cancel", mock.Anything, mock.Anything).Return(nil)
+	mgr.On("CreateHistory", mock.Anything, mock.Anything).Return(int64(1), nil)
+
+	c := controller{requestMgr: mgr}
+	req := &models.Request{RequestID: 1, OwnerID: 2, Name: "tmaxcloud"}
+	suite.Nil(c.Cancel(ctx, req))
+	suite.Equal(models.Cancelled, req.IsApproved)
+	mgr.AssertExpectations(suite.T())
+}
+
+func (suite *ControllerTestSuite) TestExpire() {
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+
+	mgr := &request.Manager{}
+	mgr.On("List", mock.Anything, mock.Anything).Return([]*models.Request{
+		{RequestID: 1, Name: "foo"},
+		{RequestID: 2, Name: "bar"},
+		{RequestID: 3, Name: "baz"},
+	}, nil)
+	mgr.On("GetForUpdate", mock.Anything, int64(1)).Return(&models.Request{RequestID: 1, Name: "foo"}, nil)
+	mgr.On("GetForUpdate", mock.Anything, int64(2)).Return(&models.Request{RequestID: 2, Name: "bar"}, nil)
+	// approved by a concurrent call after listed
+	mgr.On("GetForUpdate", mock.Anything, int64(3)).Return(&models.Request{RequestID: 3, Name: "baz", IsApproved: models.Approved}, nil)
+	mgr.On("Expire", mock.Anything, mock.Anything).Return(nil)
+	mgr.On("CreateHistory", mock.Anything, testifymock.MatchedBy(func(h *models.History) bool {
+		return h.ToState == models.Expired && h.Operator == SystemOperator
+	})).Return(int64(1), nil)
+
+	c := controller{requestMgr: mgr}
+	count, err := c.Expire(ctx)
+	suite.Nil(err)
+	suite.Equal(int64(2), count)
+	mgr.AssertNumberOfCalls(suite.T(), "CreateHistory", 2)
+}
+
 func TestControllerTestSuite(t *testing.T) {
 	suite.Run(t, &ControllerTestSuite{})
 }
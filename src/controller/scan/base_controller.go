@@ -40,11 +40,14 @@ import (
 	sca "github.com/goharbor/harbor/src/pkg/scan"
 	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
 	"github.com/goharbor/harbor/src/pkg/scan/dao/scanner"
+	"github.com/goharbor/harbor/src/pkg/scan/policy"
 	"github.com/goharbor/harbor/src/pkg/scan/postprocessors"
 	"github.com/goharbor/harbor/src/pkg/scan/report"
 	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
 	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
 	"github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/pkg/vex"
 	"github.com/google/uuid"
 )
 
@@ -70,6 +73,8 @@ const (
 func init() {
 	// keep only the latest created 5 scan all execution records
 	task.SetExecutionSweeperCount(VendorTypeScanAll, 5)
+	// keep only the latest created 5 re-scan execution records of each project
+	task.SetExecutionSweeperCount(VendorTypeRescan, 5)
 }
 
 // uuidGenerator is a func template which is for generating UUID.
@@ -112,6 +117,12 @@ type basicController struct {
 	taskMgr task.Manager
 	// Converter for V1 report to V2 report
 	reportConverter postprocessors.NativeScanReportConverter
+	// VEX document manager
+	vexMgr vex.Manager
+	// Re-scan policy manager
+	policyMgr policy.Manager
+	// Scheduler for the re-scan policies
+	sched scheduler.Scheduler
 }
 
 // NewController news a scan API controller
@@ -155,6 +166,12 @@ func NewController() Controller {
 		taskMgr: task.Mgr,
 		// Get the scan V1 to V2 report converters
 		reportConverter: postprocessors.Converter,
+		// Refer to the default VEX document manager
+		vexMgr: vex.Mgr,
+		// Refer to the default re-scan policy manager
+		policyMgr: policy.Mgr,
+		// Refer to the default scheduler
+		sched: scheduler.Sched,
 	}
 }
 
@@ -597,8 +614,18 @@ func (bc *basicController) GetSummary(ctx context.Context, artifact *ar.Artifact
 	}
 
 	summaries := make(map[string]interface{}, len(rps))
+	if len(rps) == 0 {
+		return summaries, nil
+	}
+
+	// the vulnerabilities declared as not affected by the VEX documents are suppressed in the summary
+	suppressions, err := bc.vexMgr.GetSuppressions(ctx, artifact.RepositoryName, artifact.Digest)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, rp := range rps {
-		sum, err := report.GenerateSummary(rp)
+		sum, err := report.GenerateSummary(rp, report.WithSuppressions(suppressions))
 		if err != nil {
 			return nil, err
 		}
@@ -718,6 +745,84 @@ func (bc *basicController) GetVulnerable(ctx context.Context, artifact *ar.Artif
 		return nil, errors.New("no way to get vulnerable for nil artifact")
 	}
 
+	scanStatus, rp, err := bc.getVulnerabilityReport(ctx, artifact)
+	if err != nil {
+		return nil, err
+	}
+
+	vulnerable := &Vulnerable{
+		ScanStatus: scanStatus,
+	}
+
+	if !vulnerable.IsScanSuccess() || rp == nil {
+		return vulnerable, nil
+	}
+
+	if vuls := rp.GetVulnerabilityItemList().Items(); len(vuls) > 0 {
+		vulnerable.VulnerabilitiesCount = len(vuls)
+
+		var severity vuln.Severity
+
+		for _, v := range vuls {
+			if allowlist.Contains(v.ID) {
+				// Append the by passed CVEs specified in the allowlist
+				vulnerable.CVEBypassed = append(vulnerable.CVEBypassed, v.ID)
+
+				vulnerable.VulnerabilitiesCount--
+
+				continue
+			}
+
+			if v.Suppressed {
+				// Append the suppressed CVEs declared as not affected by the VEX documents
+				vulnerable.CVESuppressed = append(vulnerable.CVESuppressed, v.ID)
+
+				vulnerable.VulnerabilitiesCount--
+
+				continue
+			}
+
+			if severity == "" || v.Severity.Code() > severity.Code() {
+				severity = v.Severity
+			}
+		}
+
+		if severity != "" {
+			vulnerable.Severity = &severity
+		}
+	}
+
+	return vulnerable, nil
+}
+
+// DiffReports ...
+func (bc *basicController) DiffReports(ctx context.Context, base, target *ar.Artifact) (*vuln.ReportDiff, error) {
+	if base == nil || target == nil {
+		return nil, errors.New("no way to diff reports for nil artifact")
+	}
+
+	reports := make([]*vuln.Report, 2)
+	for i, art := range []*ar.Artifact{base, target} {
+		scanStatus, rp, err := bc.getVulnerabilityReport(ctx, art)
+		if err != nil {
+			return nil, err
+		}
+
+		if scanStatus != job.SuccessStatus.String() {
+			return nil, errors.New(nil).WithCode(errors.PreconditionCode).
+				WithMessage("the artifact %s@%s isn't scanned successfully, scan status: %s", art.RepositoryName, art.Digest, scanStatus)
+		}
+
+		reports[i] = rp
+	}
+
+	return vuln.Diff(reports[0], reports[1]), nil
+}
+
+// getVulnerabilityReport returns the merged scan status and the vulnerability report of the artifact,
+// the report is nil when the scan isn't success, and the vulnerabilities declared as not affected
+// by the VEX documents are marked as suppressed in the report.
+func (bc *basicController) getVulnerabilityReport(ctx context.Context, artifact *ar.Artifact) (string, *vuln.Report, error) {
 	var (
 		mimeType string
 		reports  []*scan.Report
@@ -725,7 +830,7 @@ func (bc *basicController) GetVulnerable(ctx context.Context, artifact *ar.Artif
 	for _, m := range []string{v1.MimeTypeNativeReport, v1.MimeTypeGenericVulnerabilityReport} {
 		rps, err := bc.GetReport(ctx, artifact, []string{m})
 		if err != nil {
-			return nil, err
+			return "", nil, err
 		}
 
 		if len(rps) == 0 {
@@ -738,7 +843,7 @@ func (bc *basicController) GetVulnerable(ctx context.Context, artifact *ar.Artif
 	}
 
 	if len(reports) == 0 {
-		return nil, errors.NotFoundError(nil).WithMessage("report not found")
+		return "", nil, errors.NotFoundError(nil).WithMessage("report not found")
 	}
 
 	scanStatus := reports[0].Status
@@ -746,54 +851,41 @@ func (bc *basicController) GetVulnerable(ctx context.Context, artifact *ar.Artif
 		scanStatus = vuln.MergeScanStatus(scanStatus, report.Status)
 	}
 
-	vulnerable := &Vulnerable{
-		ScanStatus: scanStatus,
-	}
-
-	if !vulnerable.IsScanSuccess() {
-		return vulnerable, nil
+	if scanStatus != job.SuccessStatus.String() {
+		return scanStatus, nil, nil
 	}
 
 	raw, err := report.Reports(reports).ResolveData(mimeType)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
 
 	if raw == nil {
-		return vulnerable, nil
+		return scanStatus, nil, nil
 	}
 
 	rp, ok := raw.(*vuln.Report)
 	if !ok {
-		return nil, errors.Errorf("type mismatch: expect *vuln.Report but got %s", reflect.TypeOf(raw).String())
+		return "", nil, errors.Errorf("type mismatch: expect *vuln.Report but got %s", reflect.TypeOf(raw).String())
 	}
 
-	if vuls := rp.GetVulnerabilityItemList().Items(); len(vuls) > 0 {
-		vulnerable.VulnerabilitiesCount = len(vuls)
-
-		var severity vuln.Severity
-
-		for _, v := range vuls {
-			if allowlist.Contains(v.ID) {
-				// Append the by passed CVEs specified in the allowlist
-				vulnerable.CVEBypassed = append(vulnerable.CVEBypassed, v.ID)
-
-				vulnerable.VulnerabilitiesCount--
-
-				continue
-			}
+	suppressions, err := bc.vexMgr.GetSuppressions(ctx, artifact.RepositoryName, artifact.Digest)
+	if err != nil {
+		return "", nil, err
+	}
+	rp.Suppress(suppressions)
 
-			if severity == "" || v.Severity.Code() > severity.Code() {
-				severity = v.Severity
-			}
-		}
+	return scanStatus, rp, nil
+}
 
-		if severity != "" {
-			vulnerable.Severity = &severity
-		}
-	}
+// CountVulnerableArtifacts ...
+func (bc *basicController) CountVulnerableArtifacts(ctx context.Context, query *q.Query) (int64, error) {
+	return bc.manager.CountVulnerableArtifacts(ctx, query)
+}
 
-	return vulnerable, nil
+// ListVulnerableArtifacts ...
+func (bc *basicController) ListVulnerableArtifacts(ctx context.Context, query *q.Query) ([]*scan.VulnerableArtifact, error) {
+	return bc.manager.ListVulnerableArtifacts(ctx, query)
 }
 
 // makeRobotAccount creates a robot account based on the arguments for scanning.
@@ -1016,20 +1108,25 @@ func (bc *basicController) assembleReports(ctx context.Context, reports ...*scan
 		}
 	}
 
-	for _, report := range reports {
-		if task, ok := reportUUIDToTasks[report.UUID]; ok {
-			report.Status = task.Status
-			report.StartTime = task.StartTime
-			report.EndTime = task.EndTime
+	for _, rp := range reports {
+		if task, ok := reportUUIDToTasks[rp.UUID]; ok {
+			rp.Status = task.Status
+			rp.StartTime = task.StartTime
+			rp.EndTime = task.EndTime
 		} else {
-			report.Status = job.ErrorStatus.String()
+			rp.Status = job.ErrorStatus.String()
+		}
+
+		// the SBOM report is stored as it is, no need to complete it with the vulnerability records
+		if report.IsSBOMMimeType(rp.MimeType) {
+			continue
 		}
 
-		completeReport, err := bc.reportConverter.FromRelationalSchema(ctx, report.UUID, report.Digest, report.Report)
+		completeReport, err := bc.reportConverter.FromRelationalSchema(ctx, rp.UUID, rp.Digest, rp.Report)
 		if err != nil {
 			return err
 		}
-		report.Report = completeReport
+		rp.Report = completeReport
 	}
 
 	return nil
@@ -48,6 +48,7 @@ import (
 	postprocessorstesting "github.com/goharbor/harbor/src/testing/pkg/scan/postprocessors"
 	reporttesting "github.com/goharbor/harbor/src/testing/pkg/scan/report"
 	tasktesting "github.com/goharbor/harbor/src/testing/pkg/task"
+	vextesting "github.com/goharbor/harbor/src/testing/pkg/vex"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -72,6 +73,16 @@ type ControllerTestSuite struct {
 	ar              artifact.Controller
 	c               Controller
 	reportConverter *postprocessorstesting.ScanReportV1ToV2Converter
+	vexMgr          *vextesting.Manager
+}
+
+// rawReportConverter returns the report data as it is
+type rawReportConverter struct {
+	postprocessorstesting.ScanReportV1ToV2Converter
+}
+
+func (c *rawReportConverter) FromRelationalSchema(ctx context.Context, reportUUID string, artifactDigest string, reportData string) (string, error) {
+	return reportData, nil
 }
 
 // TestController is the entry point of ControllerTestSuite.
@@ -265,6 +276,9 @@ func (suite *ControllerTestSuite) SetupSuite() {
 
 	suite.taskMgr = &tasktesting.Manager{}
 
+	suite.vexMgr = &vextesting.Manager{}
+	mock.OnAnything(suite.vexMgr, "GetSuppressions").Return(nil, nil)
+
 	suite.c = &basicController{
 		manager: mgr,
 		ar:      suite.ar,
@@ -291,6 +305,7 @@ func (suite *ControllerTestSuite) SetupSuite() {
 		execMgr:         suite.execMgr,
 		taskMgr:         suite.taskMgr,
 		reportConverter: &postprocessorstesting.ScanReportV1ToV2Converter{},
+		vexMgr:          suite.vexMgr,
 	}
 }
 
@@ -426,6 +441,109 @@ func (suite *ControllerTestSuite) TestScanControllerGetSummary() {
 	assert.Equal(suite.T(), 1, len(sum))
 }
 
+// TestScanControllerGetSummaryWithSuppressions ...
+func (suite *ControllerTestSuite) TestScanControllerGetSummaryWithSuppressions() {
+	mock.OnAnything(suite.ar, "Walk").Return(nil).Run(func(args mock.Arguments) {
+		walkFn := args.Get(2).(func(*artifact.Artifact) error)
+		walkFn(suite.artifact)
+	}).Once()
+	mock.OnAnything(suite.taskMgr, "List").Return([]*task.Task{
+		{ExtraAttrs: suite.makeExtraAttrs("rp-uuid-001"), Status: "Success"},
+	}, nil).Once()
+
+	vexMgr := &vextesting.Manager{}
+	vexMgr.On("GetSuppressions", mock.Anything, suite.artifact.RepositoryName, suite.artifact.Digest).
+		Return(vuln.Suppressions{"2019-0980-0909": {Justification: "vulnerable_code_not_present"}}, nil)
+	c := *suite.c.(*basicController)
+	c.vexMgr = vexMgr
+	c.manager = suite.makeReportManager()
+	c.reportConverter = &rawReportConverter{}
+
+	sum, err := c.GetSummary(context.TODO(), suite.artifact, []string{v1.MimeTypeNativeReport})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 1, len(sum))
+
+	nativeSum, ok := sum[v1.MimeTypeNativeReport].(*vuln.NativeReportSummary)
+	require.True(suite.T(), ok)
+	suite.Equal(0, nativeSum.Summary.Total)
+	suite.Equal(1, nativeSum.Summary.Suppressed)
+	suite.Equal(vuln.None, nativeSum.Severity)
+}
+
+// TestScanControllerGetVulnerable ...
+func (suite *ControllerTestSuite) TestScanControllerGetVulnerable() {
+	mock.OnAnything(suite.ar, "Walk").Return(nil).Run(func(args mock.Arguments) {
+		walkFn := args.Get(2).(func(*artifact.Artifact) error)
+		walkFn(suite.artifact)
+	}).Once()
+	mock.OnAnything(suite.taskMgr, "List").Return([]*task.Task{
+		{ExtraAttrs: suite.makeExtraAttrs("rp-uuid-001"), Status: "Success"},
+	}, nil).Once()
+
+	vexMgr := &vextesting.Manager{}
+	vexMgr.On("GetSuppressions", mock.Anything, suite.artifact.RepositoryName, suite.artifact.Digest).
+		Return(vuln.Suppressions{"2019-0980-0909": {Justification: "vulnerable_code_not_present"}}, nil)
+	c := *suite.c.(*basicController)
+	c.vexMgr = vexMgr
+	c.manager = suite.makeReportManager()
+	c.reportConverter = &rawReportConverter{}
+
+	vulnerable, err := c.GetVulnerable(context.TODO(), suite.artifact, nil)
+	require.NoError(suite.T(), err)
+	suite.Equal(0, vulnerable.VulnerabilitiesCount)
+	suite.Nil(vulnerable.Severity)
+	suite.Equal([]string{"2019-0980-0909"}, vulnerable.CVESuppressed)
+}
+
+// TestScanControllerDiffReports ...
+func (suite *ControllerTestSuite) TestScanControllerDiffReports() {
+	mock.OnAnything(suite.ar, "Walk").Return(nil).Run(func(args mock.Arguments) {
+		walkFn := args.Get(2).(func(*artifact.Artifact) error)
+		walkFn(args.Get(1).(*artifact.Artifact))
+	}).Twice()
+	mock.OnAnything(suite.taskMgr, "List").Return([]*task.Task{
+		{ExtraAttrs: suite.makeExtraAttrs("rp-uuid-001", "rp-uuid-002"), Status: "Success"},
+	}, nil).Twice()
+
+	target := &artifact.Artifact{}
+	target.Type = suite.artifact.Type
+	target.ProjectID = suite.artifact.ProjectID
+	target.ManifestMediaType = suite.artifact.ManifestMediaType
+	target.RepositoryName = "library/photon-base"
+	target.Digest = "digest-code-2"
+
+	rawReport, err := json.Marshal(&vuln.Report{
+		Vulnerabilities: []*vuln.VulnerabilityItem{
+			{ID: "2019-0980-0909", Package: "dpkg", Version: "0.9.2", Severity: vuln.Critical},
+			{ID: "2020-1234-5678", Package: "openssl", Version: "1.1.1k", Severity: vuln.Medium},
+		},
+	})
+	suite.Require().NoError(err)
+
+	mgr := suite.makeReportManager()
+	mgr.On("GetBy", mock.Anything, target.Digest, suite.registration.UUID, []string{v1.MimeTypeNativeReport}).Return([]*scan.Report{
+		{
+			UUID:             "rp-uuid-002",
+			Digest:           target.Digest,
+			RegistrationUUID: "uuid001",
+			MimeType:         v1.MimeTypeNativeReport,
+			Report:           string(rawReport),
+		},
+	}, nil)
+	c := *suite.c.(*basicController)
+	c.manager = mgr
+	c.reportConverter = &rawReportConverter{}
+
+	diff, err := c.DiffReports(context.TODO(), suite.artifact, target)
+	suite.Require().NoError(err)
+	suite.Require().Len(diff.Added, 1)
+	suite.Equal("2020-1234-5678", diff.Added[0].ID)
+	suite.Empty(diff.Removed)
+	suite.Require().Len(diff.SeverityChanged, 1)
+	suite.Equal(vuln.High, diff.SeverityChanged[0].PreviousSeverity)
+	suite.Equal(vuln.Critical, diff.SeverityChanged[0].Severity)
+}
+
 // TestScanControllerGetScanLog ...
 func (suite *ControllerTestSuite) TestScanControllerGetScanLog() {
 	mock.OnAnything(suite.taskMgr, "List").Return([]*task.Task{
@@ -565,6 +683,41 @@ func (suite *ControllerTestSuite) TestDeleteReports() {
 	suite.Error(suite.c.DeleteReports(context.TODO(), "digest"))
 }
 
+func (suite *ControllerTestSuite) TestListVulnerableArtifacts() {
+	query := q.New(q.KeyWords{"cve_id": "CVE-2021-44228"})
+	suite.reportMgr.On("CountVulnerableArtifacts", context.TODO(), query).Return(int64(1), nil).Once()
+	suite.reportMgr.On("ListVulnerableArtifacts", context.TODO(), query).Return([]*scan.VulnerableArtifact{
+		{Digest: "digest", CVEID: "CVE-2021-44228", Package: "log4j-core", PackageVersion: "2.14.1"},
+	}, nil).Once()
+
+	count, err := suite.c.CountVulnerableArtifacts(context.TODO(), query)
+	suite.NoError(err)
+	suite.Equal(int64(1), count)
+
+	artifacts, err := suite.c.ListVulnerableArtifacts(context.TODO(), query)
+	suite.NoError(err)
+	suite.Len(artifacts, 1)
+	suite.Equal("log4j-core", artifacts[0].Package)
+}
+
+// makeReportManager returns a report manager which returns the fresh copy of the raw report
+func (suite *ControllerTestSuite) makeReportManager() *reporttesting.Manager {
+	mgr := &reporttesting.Manager{}
+	mgr.On("GetBy", mock.Anything, suite.artifact.Digest, suite.registration.UUID, []string{v1.MimeTypeNativeReport}).Return([]*scan.Report{
+		{
+			ID:               11,
+			UUID:             "rp-uuid-001",
+			Digest:           "digest-code",
+			RegistrationUUID: "uuid001",
+			MimeType:         v1.MimeTypeNativeReport,
+			Status:           "Success",
+			Report:           suite.rawReport,
+		},
+	}, nil)
+
+	return mgr
+}
+
 func (suite *ControllerTestSuite) makeExtraAttrs(reportUUIDs ...string) map[string]interface{} {
 	b, _ := json.Marshal(map[string]interface{}{reportUUIDsKey: reportUUIDs})
 
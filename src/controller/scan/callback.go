@@ -16,6 +16,7 @@ package scan
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/event/metadata"
@@ -31,6 +32,8 @@ import (
 const (
 	// ScanAllCallback the scheduler callback name of the scan all
 	ScanAllCallback = "scanAll"
+	// RescanCallback the scheduler callback name of the project re-scan policy
+	RescanCallback = "rescan"
 )
 
 var (
@@ -45,11 +48,19 @@ func init() {
 		log.Fatalf("failed to register the callback for the scan all schedule, error %v", err)
 	}
 
+	if err := scheduler.RegisterCallbackFunc(RescanCallback, rescanCallback); err != nil {
+		log.Fatalf("failed to register the callback for the re-scan policy schedule, error %v", err)
+	}
+
 	// NOTE: the vendor type of execution for the scan job trigger by the scan all is VendorTypeScanAll
 	if err := task.RegisterTaskStatusChangePostFunc(VendorTypeScanAll, scanTaskStatusChange); err != nil {
 		log.Fatalf("failed to register the task status change post for the scan all job, error %v", err)
 	}
 
+	if err := task.RegisterTaskStatusChangePostFunc(VendorTypeRescan, scanTaskStatusChange); err != nil {
+		log.Fatalf("failed to register the task status change post for the re-scan job, error %v", err)
+	}
+
 	if err := task.RegisterTaskStatusChangePostFunc(job.ImageScanJob, scanTaskStatusChange); err != nil {
 		log.Fatalf("failed to register the task status change post for the scan job, error %v", err)
 	}
@@ -60,6 +71,16 @@ func scanAllCallback(ctx context.Context, param string) error {
 	return err
 }
 
+func rescanCallback(ctx context.Context, param string) error {
+	var projectID int64
+	if err := json.Unmarshal([]byte(param), &projectID); err != nil {
+		return err
+	}
+
+	_, err := scanCtl.Rescan(ctx, projectID, task.ExecutionTriggerSchedule, true)
+	return err
+}
+
 func scanTaskStatusChange(ctx context.Context, taskID int64, status string) (err error) {
 	logger := log.G(ctx).WithFields(log.Fields{"task_id": taskID, "status": status})
 
@@ -19,8 +19,10 @@ import (
 
 	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/q"
 	allowlist "github.com/goharbor/harbor/src/pkg/allowlist/models"
 	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+	"github.com/goharbor/harbor/src/pkg/scan/policy"
 	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 )
 
@@ -30,6 +32,7 @@ type Vulnerable struct {
 	ScanStatus           string
 	Severity             *vuln.Severity
 	CVEBypassed          []string
+	CVESuppressed        []string
 }
 
 // IsScanSuccess returns true when the artifact scanned success
@@ -125,4 +128,82 @@ type Controller interface {
 	//      *Vulnerable : the vulnerable
 	//     error        : non nil error if any errors occurred
 	GetVulnerable(ctx context.Context, artifact *artifact.Artifact, allowlist allowlist.CVESet) (*Vulnerable, error)
+
+	// DiffReports compares the vulnerability reports of the two artifacts
+	//
+	//   Arguments:
+	//     ctx context.Context          : the context for this method
+	//     base *artifact.Artifact      : the artifact compared with
+	//     target *artifact.Artifact    : the artifact to compare
+	//
+	//   Returns:
+	//     *vuln.ReportDiff : the vulnerabilities added, removed and changed in severity in the target artifact
+	//     error  : non nil error if any errors occurred
+	DiffReports(ctx context.Context, base, target *artifact.Artifact) (*vuln.ReportDiff, error)
+
+	// CountVulnerableArtifacts counts the artifacts containing the vulnerabilities matching the query
+	//
+	//   Arguments:
+	//     ctx context.Context : the context for this method
+	//     query *q.Query      : the query of the vulnerabilities
+	//
+	//   Returns:
+	//     int64  : the count of the vulnerable artifacts
+	//     error  : non nil error if any errors occurred
+	CountVulnerableArtifacts(ctx context.Context, query *q.Query) (int64, error)
+
+	// ListVulnerableArtifacts lists the artifacts containing the vulnerabilities matching the query
+	//
+	//   Arguments:
+	//     ctx context.Context : the context for this method
+	//     query *q.Query      : the query of the vulnerabilities
+	//
+	//   Returns:
+	//     []*scan.VulnerableArtifact : the vulnerable artifacts
+	//     error  : non nil error if any errors occurred
+	ListVulnerableArtifacts(ctx context.Context, query *q.Query) ([]*scan.VulnerableArtifact, error)
+
+	// GetRescanPolicy gets the re-scan policy of the project
+	//
+	//   Arguments:
+	//     ctx context.Context : the context for this method
+	//     projectID int64     : the ID of the project
+	//
+	//   Returns:
+	//     *policy.Policy : the re-scan policy
+	//     error  : non nil error if any errors occurred
+	GetRescanPolicy(ctx context.Context, projectID int64) (*policy.Policy, error)
+
+	// SetRescanPolicy creates or updates the re-scan policy of the project and schedules it
+	//
+	//   Arguments:
+	//     ctx context.Context : the context for this method
+	//     p *policy.Policy    : the re-scan policy
+	//
+	//   Returns:
+	//     error  : non nil error if any errors occurred
+	SetRescanPolicy(ctx context.Context, p *policy.Policy) error
+
+	// DeleteRescanPolicy deletes the re-scan policy of the project, its schedule and executions
+	//
+	//   Arguments:
+	//     ctx context.Context : the context for this method
+	//     projectID int64     : the ID of the project
+	//
+	//   Returns:
+	//     error  : non nil error if any errors occurred
+	DeleteRescanPolicy(ctx context.Context, projectID int64) error
+
+	// Rescan re-scans the stale artifacts of the project according to its re-scan policy
+	//
+	//   Arguments:
+	//     ctx context.Context : the context for this method
+	//     projectID int64     : the ID of the project
+	//     trigger string      : the trigger mode
+	//     async bool          : submit the scan jobs asynchronously
+	//
+	//   Returns:
+	//     int64  : the ID of the re-scan execution
+	//     error  : non nil error if any errors occurred
+	Rescan(ctx context.Context, projectID int64, trigger string, async bool) (int64, error)
 }
@@ -0,0 +1,367 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver"
+	ar "github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/lib/retry"
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scanner"
+	"github.com/goharbor/harbor/src/pkg/scan/policy"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+)
+
+const (
+	// VendorTypeRescan is the vendor type of the executions and schedules of the project re-scan policies,
+	// the vendor ID is the project ID
+	VendorTypeRescan = "RESCAN"
+)
+
+// rescanCheckInterval is the interval to check whether the count of the running scan jobs
+// is under the limit of the re-scan policy
+var rescanCheckInterval = 10 * time.Second
+
+// GetRescanPolicy ...
+func (bc *basicController) GetRescanPolicy(ctx context.Context, projectID int64) (*policy.Policy, error) {
+	return bc.policyMgr.GetByProject(ctx, projectID)
+}
+
+// SetRescanPolicy ...
+func (bc *basicController) SetRescanPolicy(ctx context.Context, p *policy.Policy) error {
+	if p == nil {
+		return errors.New("nil re-scan policy")
+	}
+
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	previous, err := bc.policyMgr.GetByProject(ctx, p.ProjectID)
+	if err != nil && !errors.IsNotFoundErr(err) {
+		return err
+	}
+
+	if previous != nil {
+		p.ID = previous.ID
+		p.CreationTime = previous.CreationTime
+		err = bc.policyMgr.Update(ctx, p)
+	} else {
+		p.ID, err = bc.policyMgr.Create(ctx, p)
+	}
+	if err != nil {
+		return err
+	}
+
+	// always re-create the schedule as the cron may be changed
+	if err := bc.sched.UnScheduleByVendor(ctx, VendorTypeRescan, p.ProjectID); err != nil {
+		return err
+	}
+
+	if !p.Enabled {
+		return nil
+	}
+
+	_, err = bc.sched.Schedule(ctx, VendorTypeRescan, p.ProjectID, "Custom", p.Cron, RescanCallback, p.ProjectID, nil)
+	return err
+}
+
+// DeleteRescanPolicy ...
+func (bc *basicController) DeleteRescanPolicy(ctx context.Context, projectID int64) error {
+	if err := bc.sched.UnScheduleByVendor(ctx, VendorTypeRescan, projectID); err != nil {
+		return err
+	}
+
+	if err := bc.execMgr.DeleteByVendor(ctx, VendorTypeRescan, projectID); err != nil {
+		return err
+	}
+
+	return bc.policyMgr.DeleteByProject(ctx, projectID)
+}
+
+// Rescan ...
+func (bc *basicController) Rescan(ctx context.Context, projectID int64, trigger string, async bool) (int64, error) {
+	p, err := bc.policyMgr.GetByProject(ctx, projectID)
+	if err != nil {
+		return 0, err
+	}
+
+	query := q.New(q.KeyWords{"VendorType": VendorTypeRescan, "VendorID": projectID})
+	executions, err := bc.execMgr.List(ctx, query.First(q.NewSort("StartTime", true)))
+	if err != nil {
+		return 0, err
+	}
+
+	if len(executions) > 0 && executions[0].IsOnGoing() {
+		return 0, errors.ConflictError(nil).WithMessage("a previous re-scan of project %d is ongoing", projectID)
+	}
+
+	executionID, err := bc.execMgr.Create(ctx, VendorTypeRescan, projectID, trigger)
+	if err != nil {
+		return 0, err
+	}
+
+	if async {
+		go func(ctx context.Context) {
+			// if async, this is running in another goroutine ensure the execution exists in db
+			err := retry.Retry(func() error {
+				_, err := bc.execMgr.Get(ctx, executionID)
+				return err
+			})
+			if err != nil {
+				log.Errorf("failed to get the execution %d for the re-scan of project %d", executionID, projectID)
+				return
+			}
+
+			bc.startRescan(ctx, p, executionID)
+		}(bc.makeCtx())
+	} else {
+		if err := bc.startRescan(ctx, p, executionID); err != nil {
+			return 0, err
+		}
+	}
+
+	return executionID, nil
+}
+
+func (bc *basicController) startRescan(ctx context.Context, p *policy.Policy, executionID int64) error {
+	r, err := bc.sc.GetRegistrationByProject(ctx, p.ProjectID)
+	if err != nil {
+		return bc.markRescanError(ctx, executionID, fmt.Sprintf("failed to get the scanner of project %d: %v", p.ProjectID, err))
+	}
+
+	if r == nil || r.Disabled {
+		return bc.markRescanError(ctx, executionID, fmt.Sprintf("no available scanner for project %d", p.ProjectID))
+	}
+
+	summary := struct {
+		TotalCount    int `json:"total_count"`
+		StaleCount    int `json:"stale_count"`
+		SubmitCount   int `json:"submit_count"`
+		ConflictCount int `json:"conflict_count"`
+		UnknowCount   int `json:"unknow_count"`
+	}{}
+
+	query := q.New(q.KeyWords{"ProjectID": p.ProjectID})
+	if p.PulledWithinDays > 0 {
+		query.Keywords["PullTime"] = &q.Range{Min: time.Now().AddDate(0, 0, -p.PulledWithinDays)}
+	}
+
+	for artifact := range ar.Iterator(ctx, 50, query, nil) {
+		summary.TotalCount++
+
+		stale, err := bc.isScanStale(ctx, p, r, artifact)
+		if err != nil {
+			log.Errorf("failed to check the scan reports of artifact %s@%s, error %v", artifact.RepositoryName, artifact.Digest, err)
+			summary.UnknowCount++
+			continue
+		}
+
+		if !stale {
+			continue
+		}
+
+		summary.StaleCount++
+
+		stopped, err := bc.waitForRescanSlot(ctx, executionID, p.MaxConcurrentJobs)
+		if err != nil {
+			log.Errorf("failed to check the running scan jobs of the execution %d, error %v", executionID, err)
+			break
+		}
+
+		if stopped {
+			log.Infof("the re-scan execution %d of project %d is stopped", executionID, p.ProjectID)
+			break
+		}
+
+		scan := func(ctx context.Context) error {
+			return bc.Scan(ctx, artifact, WithExecutionID(executionID))
+		}
+
+		if err := orm.WithTransaction(scan)(orm.SetTransactionOpNameToContext(bc.makeCtx(), "tx-start-rescan")); err != nil {
+			// Just logged
+			log.Errorf("failed to re-scan artifact %s, error %v", artifact, err)
+
+			if errors.IsConflictErr(err) {
+				summary.ConflictCount++
+			} else {
+				summary.UnknowCount++
+			}
+		} else {
+			summary.SubmitCount++
+		}
+	}
+
+	extraAttrs := map[string]interface{}{"summary": summary}
+	if err := bc.execMgr.UpdateExtraAttrs(ctx, executionID, extraAttrs); err != nil {
+		log.Errorf("failed to set the summary info for the re-scan execution, error: %v", err)
+		return err
+	}
+
+	if summary.SubmitCount > 0 { // at least one artifact submitted to the job service
+		return nil
+	}
+
+	message := fmt.Sprintf("%d artifact(s) found, %d of them are stale, but no scan job submitted to the job service", summary.TotalCount, summary.StaleCount)
+	if summary.UnknowCount > 0 {
+		return bc.markRescanError(ctx, executionID, fmt.Sprintf("%s, internal error happened for %d of them", message, summary.UnknowCount))
+	}
+
+	if err := bc.execMgr.MarkDone(ctx, executionID, message); err != nil {
+		log.Errorf("failed to mark the execution %d to be done, error: %v", executionID, err)
+		return err
+	}
+
+	return nil
+}
+
+func (bc *basicController) markRescanError(ctx context.Context, executionID int64, message string) error {
+	if err := bc.execMgr.MarkError(ctx, executionID, message); err != nil {
+		log.Errorf("failed to mark the execution %d to be error, error: %v", executionID, err)
+		return err
+	}
+
+	return nil
+}
+
+// waitForRescanSlot waits until the count of the running scan jobs of the execution is under the limit,
+// returns true when the execution is stopped
+func (bc *basicController) waitForRescanSlot(ctx context.Context, executionID int64, maxConcurrentJobs int) (bool, error) {
+	query := q.New(q.KeyWords{
+		"ExecutionID": executionID,
+		"Status": &q.OrList{Values: []interface{}{
+			job.PendingStatus.String(),
+			job.ScheduledStatus.String(),
+			job.RunningStatus.String(),
+		}},
+	})
+
+	for {
+		execution, err := bc.execMgr.Get(ctx, executionID)
+		if err != nil {
+			return false, err
+		}
+
+		if execution.Status == job.StoppedStatus.String() {
+			return true, nil
+		}
+
+		count, err := bc.taskMgr.Count(ctx, query)
+		if err != nil {
+			return false, err
+		}
+
+		if count < int64(maxConcurrentJobs) {
+			return false, nil
+		}
+
+		time.Sleep(rescanCheckInterval)
+	}
+}
+
+// isScanStale checks whether the artifact should be re-scanned according to the policy,
+// the artifact never scanned is treated as stale
+func (bc *basicController) isScanStale(ctx context.Context, p *policy.Policy, r *scanner.Registration, artifact *ar.Artifact) (bool, error) {
+	artifacts, scannable, err := bc.collectScanningArtifacts(ctx, r, artifact)
+	if err != nil {
+		return false, err
+	}
+
+	if !scannable {
+		return false, nil
+	}
+
+	var reports []*scan.Report
+	for _, a := range artifacts {
+		rps, err := bc.manager.GetBy(bc.cloneCtx(ctx), a.Digest, r.UUID, []string{v1.MimeTypeNativeReport, v1.MimeTypeGenericVulnerabilityReport})
+		if err != nil {
+			return false, err
+		}
+
+		if len(rps) == 0 {
+			return true, nil
+		}
+
+		reports = append(reports, rps...)
+	}
+
+	var scannerVersion string
+	if r.Metadata != nil && r.Metadata.Scanner != nil {
+		scannerVersion = r.Metadata.Scanner.Version
+	}
+
+	staleTime := time.Now().AddDate(0, 0, -p.StaleDays)
+	stale := false
+	for _, rp := range reports {
+		t, err := bc.getScanTask(ctx, rp.UUID)
+		if err != nil {
+			if !errors.IsNotFoundErr(err) {
+				return false, err
+			}
+
+			// the task may be swept, the report is stale enough
+			stale = true
+			continue
+		}
+
+		if !job.Status(t.Status).Final() {
+			// a previous scan process is ongoing
+			return false, nil
+		}
+
+		if p.StaleDays > 0 && t.EndTime.Before(staleTime) {
+			stale = true
+		}
+
+		if p.OutdatedScanner && isOutdatedScanner(rp, scannerVersion) {
+			stale = true
+		}
+	}
+
+	return stale, nil
+}
+
+// isOutdatedScanner checks whether the report is generated by an older version of the scanner
+func isOutdatedScanner(rp *scan.Report, scannerVersion string) bool {
+	if len(scannerVersion) == 0 || len(rp.Report) == 0 {
+		return false
+	}
+
+	data := struct {
+		Scanner *v1.Scanner `json:"scanner"`
+	}{}
+	if err := json.Unmarshal([]byte(rp.Report), &data); err != nil || data.Scanner == nil {
+		return false
+	}
+
+	current, err1 := semver.NewVersion(scannerVersion)
+	previous, err2 := semver.NewVersion(data.Scanner.Version)
+	if err1 != nil || err2 != nil {
+		// not semantic versions, treat any different version as outdated
+		return data.Scanner.Version != scannerVersion
+	}
+
+	return previous.LessThan(current)
+}
@@ -0,0 +1,233 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scanner"
+	"github.com/goharbor/harbor/src/pkg/scan/policy"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	"github.com/goharbor/harbor/src/pkg/task"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	"github.com/goharbor/harbor/src/testing/mock"
+	policytesting "github.com/goharbor/harbor/src/testing/pkg/scan/policy"
+	reporttesting "github.com/goharbor/harbor/src/testing/pkg/scan/report"
+	schedulertesting "github.com/goharbor/harbor/src/testing/pkg/scheduler"
+	tasktesting "github.com/goharbor/harbor/src/testing/pkg/task"
+	"github.com/stretchr/testify/suite"
+)
+
+type RescanTestSuite struct {
+	suite.Suite
+
+	ar        *artifacttesting.Controller
+	execMgr   *tasktesting.ExecutionManager
+	taskMgr   *tasktesting.Manager
+	reportMgr *reporttesting.Manager
+	policyMgr *policytesting.Manager
+	sched     *schedulertesting.Scheduler
+
+	registration *scanner.Registration
+	artifact     *artifact.Artifact
+	c            *basicController
+}
+
+func (suite *RescanTestSuite) SetupTest() {
+	suite.ar = &artifacttesting.Controller{}
+	suite.execMgr = &tasktesting.ExecutionManager{}
+	suite.taskMgr = &tasktesting.Manager{}
+	suite.reportMgr = &reporttesting.Manager{}
+	suite.policyMgr = &policytesting.Manager{}
+	suite.sched = &schedulertesting.Scheduler{}
+
+	suite.registration = &scanner.Registration{
+		UUID: "uuid001",
+		Metadata: &v1.ScannerAdapterMetadata{
+			Scanner: &v1.Scanner{Name: "Trivy", Vendor: "Aqua Security", Version: "v0.20.1"},
+			Capabilities: []*v1.ScannerCapability{{
+				ConsumesMimeTypes: []string{v1.MimeTypeDockerArtifact},
+				ProducesMimeTypes: []string{v1.MimeTypeNativeReport},
+			}},
+		},
+	}
+
+	suite.artifact = &artifact.Artifact{}
+	suite.artifact.Type = "IMAGE"
+	suite.artifact.ProjectID = 1
+	suite.artifact.RepositoryName = "library/photon"
+	suite.artifact.Digest = "digest-code"
+	suite.artifact.ManifestMediaType = v1.MimeTypeDockerArtifact
+
+	suite.c = &basicController{
+		ar:        suite.ar,
+		manager:   suite.reportMgr,
+		execMgr:   suite.execMgr,
+		taskMgr:   suite.taskMgr,
+		policyMgr: suite.policyMgr,
+		sched:     suite.sched,
+		cloneCtx:  func(ctx context.Context) context.Context { return ctx },
+	}
+
+	mock.OnAnything(suite.ar, "Walk").Return(nil).Run(func(args mock.Arguments) {
+		walkFn := args.Get(2).(func(*artifact.Artifact) error)
+		walkFn(args.Get(1).(*artifact.Artifact))
+	})
+}
+
+func (suite *RescanTestSuite) TestSetRescanPolicy() {
+	p := &policy.Policy{
+		ProjectID:         1,
+		Enabled:           true,
+		Cron:              "0 0 2 * * *",
+		StaleDays:         7,
+		MaxConcurrentJobs: 5,
+	}
+
+	// create
+	suite.policyMgr.On("GetByProject", mock.Anything, int64(1)).Return(nil, errors.NotFoundError(nil)).Once()
+	suite.policyMgr.On("Create", mock.Anything, p).Return(int64(10), nil).Once()
+	suite.sched.On("UnScheduleByVendor", mock.Anything, VendorTypeRescan, int64(1)).Return(nil)
+	suite.sched.On("Schedule", mock.Anything, VendorTypeRescan, int64(1), "Custom", p.Cron, RescanCallback, int64(1), mock.Anything).Return(int64(1), nil).Once()
+	suite.Require().Nil(suite.c.SetRescanPolicy(context.TODO(), p))
+	suite.Equal(int64(10), p.ID)
+
+	// disable
+	p.Enabled = false
+	suite.policyMgr.On("GetByProject", mock.Anything, int64(1)).Return(&policy.Policy{ID: 10, ProjectID: 1}, nil).Once()
+	suite.policyMgr.On("Update", mock.Anything, p).Return(nil).Once()
+	suite.Require().Nil(suite.c.SetRescanPolicy(context.TODO(), p))
+
+	suite.policyMgr.AssertExpectations(suite.T())
+	suite.sched.AssertNumberOfCalls(suite.T(), "UnScheduleByVendor", 2)
+	suite.sched.AssertNumberOfCalls(suite.T(), "Schedule", 1)
+
+	// invalid
+	err := suite.c.SetRescanPolicy(context.TODO(), &policy.Policy{ProjectID: 1, MaxConcurrentJobs: 1})
+	suite.True(errors.IsErr(err, errors.BadRequestCode))
+}
+
+func (suite *RescanTestSuite) TestDeleteRescanPolicy() {
+	suite.sched.On("UnScheduleByVendor", mock.Anything, VendorTypeRescan, int64(1)).Return(nil).Once()
+	suite.execMgr.On("DeleteByVendor", mock.Anything, VendorTypeRescan, int64(1)).Return(nil).Once()
+	suite.policyMgr.On("DeleteByProject", mock.Anything, int64(1)).Return(nil).Once()
+	suite.Nil(suite.c.DeleteRescanPolicy(context.TODO(), 1))
+	suite.sched.AssertExpectations(suite.T())
+	suite.execMgr.AssertExpectations(suite.T())
+	suite.policyMgr.AssertExpectations(suite.T())
+}
+
+func (suite *RescanTestSuite) TestRescanConflict() {
+	suite.policyMgr.On("GetByProject", mock.Anything, int64(1)).Return(&policy.Policy{ID: 10, ProjectID: 1}, nil).Once()
+	mock.OnAnything(suite.execMgr, "List").Return([]*task.Execution{{ID: 1, Status: job.RunningStatus.String()}}, nil).Once()
+
+	_, err := suite.c.Rescan(context.TODO(), 1, task.ExecutionTriggerManual, true)
+	suite.True(errors.IsConflictErr(err))
+	suite.execMgr.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RescanTestSuite) TestIsScanStale() {
+	report := &scan.Report{UUID: "rp-uuid-001", Report: `{"scanner":{"name":"Trivy","version":"v0.19.2"}}`}
+	onGetReports := func(reports ...*scan.Report) {
+		suite.reportMgr.On("GetBy", mock.Anything, suite.artifact.Digest, suite.registration.UUID, mock.Anything).Return(reports, nil).Once()
+	}
+	onGetTask := func(status string, endTime time.Time) {
+		mock.OnAnything(suite.taskMgr, "List").Return([]*task.Task{{Status: status, EndTime: endTime}}, nil).Once()
+	}
+
+	staleDays := &policy.Policy{StaleDays: 7}
+	outdatedScanner := &policy.Policy{OutdatedScanner: true}
+
+	// never scanned
+	onGetReports()
+	stale, err := suite.c.isScanStale(context.TODO(), staleDays, suite.registration, suite.artifact)
+	suite.Require().Nil(err)
+	suite.True(stale)
+
+	// scanned recently
+	onGetReports(report)
+	onGetTask(job.SuccessStatus.String(), time.Now().Add(-time.Hour))
+	stale, err = suite.c.isScanStale(context.TODO(), staleDays, suite.registration, suite.artifact)
+	suite.Require().Nil(err)
+	suite.False(stale)
+
+	// scanned long ago
+	onGetReports(report)
+	onGetTask(job.SuccessStatus.String(), time.Now().AddDate(0, 0, -8))
+	stale, err = suite.c.isScanStale(context.TODO(), staleDays, suite.registration, suite.artifact)
+	suite.Require().Nil(err)
+	suite.True(stale)
+
+	// scanned by an older scanner
+	onGetReports(report)
+	onGetTask(job.SuccessStatus.String(), time.Now().Add(-time.Hour))
+	stale, err = suite.c.isScanStale(context.TODO(), outdatedScanner, suite.registration, suite.artifact)
+	suite.Require().Nil(err)
+	suite.True(stale)
+
+	// scanning
+	onGetReports(report)
+	onGetTask(job.RunningStatus.String(), time.Time{})
+	stale, err = suite.c.isScanStale(context.TODO(), outdatedScanner, suite.registration, suite.artifact)
+	suite.Require().Nil(err)
+	suite.False(stale)
+}
+
+func (suite *RescanTestSuite) TestWaitForRescanSlot() {
+	interval := rescanCheckInterval
+	rescanCheckInterval = time.Millisecond
+	defer func() { rescanCheckInterval = interval }()
+
+	mock.OnAnything(suite.execMgr, "Get").Return(&task.Execution{ID: 1, Status: job.RunningStatus.String()}, nil).Twice()
+	mock.OnAnything(suite.taskMgr, "Count").Return(int64(2), nil).Once()
+	mock.OnAnything(suite.taskMgr, "Count").Return(int64(1), nil).Once()
+	stopped, err := suite.c.waitForRescanSlot(context.TODO(), 1, 2)
+	suite.Require().Nil(err)
+	suite.False(stopped)
+
+	mock.OnAnything(suite.execMgr, "Get").Return(&task.Execution{ID: 1, Status: job.StoppedStatus.String()}, nil).Once()
+	stopped, err = suite.c.waitForRescanSlot(context.TODO(), 1, 2)
+	suite.Require().Nil(err)
+	suite.True(stopped)
+}
+
+func (suite *RescanTestSuite) TestIsOutdatedScanner() {
+	cases := []struct {
+		report  string
+		version string
+		outdate bool
+	}{
+		{`{"scanner":{"version":"v0.19.2"}}`, "v0.20.1", true},
+		{`{"scanner":{"version":"v0.20.1"}}`, "v0.20.1", false},
+		{`{"scanner":{"version":"v0.21.0"}}`, "v0.20.1", false},
+		{`{"scanner":{"version":"dev"}}`, "v0.20.1", true},
+		{`{}`, "v0.20.1", false},
+		{`{"scanner":{"version":"v0.19.2"}}`, "", false},
+	}
+
+	for _, c := range cases {
+		suite.Equal(c.outdate, isOutdatedScanner(&scan.Report{Report: c.report}, c.version), c.report)
+	}
+}
+
+func TestRescanTestSuite(t *testing.T) {
+	suite.Run(t, &RescanTestSuite{})
+}
@@ -37,6 +37,7 @@ import (
 	_ "github.com/goharbor/harbor/src/controller/event/handler"
 	"github.com/goharbor/harbor/src/controller/health"
 	"github.com/goharbor/harbor/src/controller/registry"
+	"github.com/goharbor/harbor/src/controller/request"
 	"github.com/goharbor/harbor/src/core/api"
 	_ "github.com/goharbor/harbor/src/core/auth/authproxy"
 	_ "github.com/goharbor/harbor/src/core/auth/db"
@@ -223,6 +224,9 @@ func main() {
 
 	health.RegisterHealthCheckers()
 	registerScanners(orm.Context())
+	if err := request.EnsureExpireSchedule(orm.Context()); err != nil {
+		log.Errorf("failed to schedule the expiration of project requests: %v", err)
+	}
 
 	closing := make(chan struct{})
 	done := make(chan struct{})
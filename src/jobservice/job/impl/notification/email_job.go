@@ -0,0 +1,122 @@
+package notification
+
+import (
+	"net/mail"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/utils/email"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+// the timeout in seconds to connect the email server
+const emailTimeout = 60
+
+// EmailJob implements the job interface, which send notification by email.
+type EmailJob struct {
+	logger logger.Interface
+}
+
+// MaxFails returns that how many times this job can fail.
+func (ej *EmailJob) MaxFails() (result uint) {
+	// Default max fails count is 10, and its max retry interval is around 3h
+	// Large enough to ensure most situations can notify successfully
+	result = 10
+	if maxFails, exist := os.LookupEnv(maxFails); exist {
+		mf, err := strconv.ParseUint(maxFails, 10, 32)
+		if err != nil {
+			logger.Warningf("Fetch email job maxFails error: %s", err.Error())
+			return result
+		}
+		result = uint(mf)
+	}
+	return result
+}
+
+// MaxCurrency is implementation of same method in Interface.
+func (ej *EmailJob) MaxCurrency() uint {
+	return 0
+}
+
+// ShouldRetry ...
+func (ej *EmailJob) ShouldRetry() bool {
+	return true
+}
+
+// Validate implements the interface in job/Interface
+func (ej *EmailJob) Validate(params job.Parameters) error {
+	if params == nil {
+		// Params are required
+		return errors.New("missing parameter of email job")
+	}
+
+	for _, name := range []string{"to", "subject", "text_body", "html_body"} {
+		value, ok := params[name]
+		if !ok {
+			return errors.Errorf("missing job parameter '%s'", name)
+		}
+		if _, ok := value.(string); !ok {
+			return errors.Errorf("malformed job parameter '%s', expecting string but got %s", name, reflect.TypeOf(value).String())
+		}
+	}
+	return nil
+}
+
+// Run implements the interface in job/Interface
+func (ej *EmailJob) Run(ctx job.Context, params job.Parameters) error {
+	ej.logger = ctx.GetLogger()
+
+	err := ej.execute(ctx, params)
+	if err != nil {
+		ej.logger.Error(err)
+	}
+	return err
+}
+
+// execute email job
+func (ej *EmailJob) execute(ctx job.Context, params map[string]interface{}) error {
+	cfgMgr, ok := config.FromContext(ctx.SystemContext())
+	if !ok {
+		return errors.New("failed to get config manager")
+	}
+	sysCtx := ctx.SystemContext()
+	host := cfgMgr.Get(sysCtx, common.EmailHost).GetString()
+	if len(host) == 0 {
+		return errors.New("the email server is not configured")
+	}
+	addr := strings.Join([]string{host, strconv.Itoa(cfgMgr.Get(sysCtx, common.EmailPort).GetInt())}, ":")
+
+	// the recipients are comma separated, e.g. "dev@example.com, Ops <ops@example.com>"
+	addresses, err := mail.ParseAddressList(params["to"].(string))
+	if err != nil {
+		return errors.Wrapf(err, "invalid recipients %s", params["to"].(string))
+	}
+	var to []string
+	for _, a := range addresses {
+		to = append(to, a.Address)
+	}
+
+	ej.logger.Infof("sending email to %s through %s", strings.Join(to, ","), addr)
+	err = email.SendMultipart(addr,
+		cfgMgr.Get(sysCtx, common.EmailIdentity).GetString(),
+		cfgMgr.Get(sysCtx, common.EmailUsername).GetString(),
+		cfgMgr.Get(sysCtx, common.EmailPassword).GetString(),
+		emailTimeout,
+		cfgMgr.Get(sysCtx, common.EmailSSL).GetBool(),
+		cfgMgr.Get(sysCtx, common.EmailInsecure).GetBool(),
+		cfgMgr.Get(sysCtx, common.EmailFrom).GetString(),
+		to,
+		params["subject"].(string),
+		params["text_body"].(string),
+		params["html_body"].(string))
+	if err != nil {
+		return errors.Wrapf(err, "failed to send email to %s", strings.Join(to, ","))
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package notification
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/config"
+	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/smtp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// emailJobContext overrides the system context to carry the config manager
+type emailJobContext struct {
+	*mockjobservice.MockJobContext
+	ctx context.Context
+}
+
+func (c *emailJobContext) SystemContext() context.Context {
+	return c.ctx
+}
+
+func TestEmailJobMaxFails(t *testing.T) {
+	defer os.Unsetenv(maxFails)
+	rep := &EmailJob{}
+	// test default max fails
+	assert.Equal(t, uint(10), rep.MaxFails())
+
+	// test user defined max fails
+	_ = os.Setenv(maxFails, "15")
+	assert.Equal(t, uint(15), rep.MaxFails())
+}
+
+func TestEmailJobShouldRetry(t *testing.T) {
+	rep := &EmailJob{}
+	assert.True(t, rep.ShouldRetry())
+}
+
+func TestEmailJobValidate(t *testing.T) {
+	rep := &EmailJob{}
+	assert.NotNil(t, rep.Validate(nil))
+
+	jp := job.Parameters{
+		"to":        "dev@example.com",
+		"subject":   "subject",
+		"text_body": "text",
+		"html_body": "<p>html</p>",
+	}
+	assert.Nil(t, rep.Validate(jp))
+
+	jp["to"] = []string{"dev@example.com"}
+	assert.NotNil(t, rep.Validate(jp))
+
+	delete(jp, "to")
+	assert.NotNil(t, rep.Validate(jp))
+}
+
+func TestEmailJobRun(t *testing.T) {
+	server, err := smtp.NewServer()
+	require.Nil(t, err)
+	defer server.Close()
+
+	host, port, _ := net.SplitHostPort(server.Addr())
+	p, _ := strconv.Atoi(port)
+	config.InitWithSettings(map[string]interface{}{
+		common.EmailHost: host,
+		common.EmailPort: p,
+		common.EmailFrom: "harbor@example.com",
+	})
+	sysCtx := config.NewContext(context.TODO(), config.GetCfgManager(context.TODO()))
+
+	mockCtx := &mockjobservice.MockJobContext{}
+	mockCtx.On("GetLogger").Return(&mockjobservice.MockJobLogger{})
+	ctx := &emailJobContext{MockJobContext: mockCtx, ctx: sysCtx}
+
+	params := map[string]interface{}{
+		"to":        "dev@example.com, QA <qa@example.com>",
+		"subject":   "subject",
+		"text_body": "text",
+		"html_body": "<p>html</p>",
+	}
+	rep := &EmailJob{}
+	assert.Nil(t, rep.Run(ctx, params))
+	messages := server.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, []string{"dev@example.com", "qa@example.com"}, messages[0].To)
+
+	// the failure is returned to retry the job
+	server.DataReplyCode = 451
+	assert.NotNil(t, rep.Run(ctx, params))
+}
@@ -0,0 +1,228 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purge
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/audit"
+)
+
+const (
+	// RetentionHour the retention hours of the audit logs
+	RetentionHour = "audit_retention_hour"
+	// IncludeOperations the comma separated operations of the audit logs to purge, all operations if empty
+	IncludeOperations = "include_operations"
+	// OperationRetentionHours the retention hours of the specific operations, which override the RetentionHour
+	OperationRetentionHours = "operation_retention_hours"
+	// DryRun only counts the audit logs to purge if it is true
+	DryRun = "dry_run"
+)
+
+// Job is the struct to purge the audit logs
+type Job struct {
+	retentionHour           int
+	includeOperations       []string
+	operationRetentionHours map[string]int
+	dryRun                  bool
+	auditMgr                audit.Manager
+	logger                  logger.Interface
+}
+
+// MaxFails implements the interface in job/Interface
+func (j *Job) MaxFails() uint {
+	return 1
+}
+
+// MaxCurrency is implementation of same method in Interface.
+func (j *Job) MaxCurrency() uint {
+	return 1
+}
+
+// ShouldRetry implements the interface in job/Interface
+func (j *Job) ShouldRetry() bool {
+	return false
+}
+
+// Validate implements the interface in job/Interface
+func (j *Job) Validate(params job.Parameters) error {
+	if params == nil {
+		return errors.New("missing the parameters of the purge job")
+	}
+	if _, err := retentionHour(params); err != nil {
+		return err
+	}
+	if ops, exist := params[IncludeOperations]; exist {
+		if _, ok := ops.(string); !ok {
+			return errors.Errorf("invalid %s: %v", IncludeOperations, ops)
+		}
+	}
+	if _, err := operationRetentionHours(params); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Run the purge job
+func (j *Job) Run(ctx job.Context, params job.Parameters) error {
+	if err := j.init(ctx, params); err != nil {
+		return err
+	}
+	opCmd, flag := ctx.OPCommand()
+	if flag && opCmd.IsStop() {
+		j.logger.Info("received the stop signal, quit purge job.")
+		return nil
+	}
+
+	var total int64
+	// the operations with their own retention hours are purged separately
+	var operations []string
+	for op := range j.operationRetentionHours {
+		operations = append(operations, op)
+	}
+	sort.Strings(operations)
+	for _, op := range operations {
+		hour := j.operationRetentionHours[op]
+		j.logger.Infof("start to purge the audit logs of the operation %s older than %d hours, dry run: %t", op, hour, j.dryRun)
+		count, err := j.auditMgr.Purge(ctx.SystemContext(), hour, []string{op}, nil, j.dryRun)
+		if err != nil {
+			j.logger.Errorf("failed to purge the audit logs of the operation %s: %v", op, err)
+			return err
+		}
+		total += count
+	}
+
+	includeOperations := j.includeOperations
+	if len(operations) > 0 && len(includeOperations) > 0 {
+		includeOperations = nil
+		for _, op := range j.includeOperations {
+			if _, exist := j.operationRetentionHours[strings.ToLower(op)]; !exist {
+				includeOperations = append(includeOperations, op)
+			}
+		}
+	}
+	// all the included operations have their own retention hours
+	if len(j.includeOperations) == 0 || len(includeOperations) > 0 {
+		j.logger.Infof("start to purge the audit logs older than %d hours, operations: %v, excluded operations: %v, dry run: %t",
+			j.retentionHour, includeOperations, operations, j.dryRun)
+		count, err := j.auditMgr.Purge(ctx.SystemContext(), j.retentionHour, includeOperations, operations, j.dryRun)
+		if err != nil {
+			j.logger.Errorf("failed to purge the audit logs: %v", err)
+			return err
+		}
+		total += count
+	}
+	if j.dryRun {
+		j.logger.Infof("dry run: %d audit log(s) would be purged", total)
+		return nil
+	}
+	j.logger.Infof("%d audit log(s) purged", total)
+	return nil
+}
+
+func (j *Job) init(ctx job.Context, params job.Parameters) error {
+	j.logger = ctx.GetLogger()
+	// UT will use the mock manager
+	if os.Getenv("UTTEST") != "true" {
+		j.auditMgr = audit.Mgr
+	}
+	hour, err := retentionHour(params)
+	if err != nil {
+		return err
+	}
+	j.retentionHour = hour
+
+	j.includeOperations = nil
+	if ops, ok := params[IncludeOperations].(string); ok {
+		for _, op := range strings.Split(ops, ",") {
+			if op = strings.TrimSpace(op); len(op) > 0 {
+				j.includeOperations = append(j.includeOperations, op)
+			}
+		}
+	}
+
+	j.operationRetentionHours, err = operationRetentionHours(params)
+	if err != nil {
+		return err
+	}
+
+	j.dryRun = false
+	if dryRun, ok := params[DryRun].(bool); ok {
+		j.dryRun = dryRun
+	}
+	return nil
+}
+
+// retentionHour parses the retention hours from the parameters, the value is decoded as float64 from the JSON
+func retentionHour(params job.Parameters) (int, error) {
+	value, exist := params[RetentionHour]
+	if !exist {
+		return 0, errors.Errorf("missing %s", RetentionHour)
+	}
+	var hour int
+	switch v := value.(type) {
+	case float64:
+		hour = int(v)
+	case int:
+		hour = v
+	case int64:
+		hour = int(v)
+	default:
+		return 0, errors.Errorf("invalid %s: %v", RetentionHour, value)
+	}
+	if hour <= 0 {
+		return 0, errors.Errorf("%s must be greater than 0", RetentionHour)
+	}
+	return hour, nil
+}
+
+// operationRetentionHours parses the retention hours of the operations from the parameters,
+// the operations are lower cased as the ones of the audit logs are compared case-insensitively
+func operationRetentionHours(params job.Parameters) (map[string]int, error) {
+	value, exist := params[OperationRetentionHours]
+	if !exist || value == nil {
+		return nil, nil
+	}
+	hours := map[string]int{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for op, h := range v {
+			hour, err := retentionHour(job.Parameters{RetentionHour: h})
+			if err != nil {
+				return nil, errors.Errorf("invalid %s of the operation %s: %v", OperationRetentionHours, op, h)
+			}
+			hours[strings.ToLower(strings.TrimSpace(op))] = hour
+		}
+	case map[string]int:
+		for op, h := range v {
+			hour, err := retentionHour(job.Parameters{RetentionHour: h})
+			if err != nil {
+				return nil, errors.Errorf("invalid %s of the operation %s: %v", OperationRetentionHours, op, h)
+			}
+			hours[strings.ToLower(strings.TrimSpace(op))] = hour
+		}
+	default:
+		return nil, errors.Errorf("invalid %s: %v", OperationRetentionHours, value)
+	}
+	if _, exist := hours[""]; exist {
+		return nil, errors.Errorf("invalid %s: empty operation", OperationRetentionHours)
+	}
+	return hours, nil
+}
@@ -0,0 +1,129 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purge
+
+import (
+	"os"
+	"testing"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/audit"
+	"github.com/stretchr/testify/suite"
+)
+
+type purgeJobTestSuite struct {
+	suite.Suite
+	auditMgr *audit.Manager
+}
+
+func (p *purgeJobTestSuite) SetupSuite() {
+	os.Setenv("UTTEST", "true")
+}
+
+func (p *purgeJobTestSuite) SetupTest() {
+	p.auditMgr = &audit.Manager{}
+}
+
+func (p *purgeJobTestSuite) TestValidate() {
+	j := &Job{}
+	p.NotNil(j.Validate(nil))
+	p.NotNil(j.Validate(job.Parameters{}))
+	p.NotNil(j.Validate(job.Parameters{RetentionHour: "24"}))
+	p.NotNil(j.Validate(job.Parameters{RetentionHour: float64(0)}))
+	p.NotNil(j.Validate(job.Parameters{RetentionHour: float64(24), IncludeOperations: 1}))
+	p.Nil(j.Validate(job.Parameters{RetentionHour: float64(24), IncludeOperations: "create,delete"}))
+	p.NotNil(j.Validate(job.Parameters{RetentionHour: float64(24), OperationRetentionHours: "delete=720"}))
+	p.NotNil(j.Validate(job.Parameters{RetentionHour: float64(24), OperationRetentionHours: map[string]interface{}{"delete": float64(0)}}))
+	p.NotNil(j.Validate(job.Parameters{RetentionHour: float64(24), OperationRetentionHours: map[string]interface{}{" ": float64(24)}}))
+	p.Nil(j.Validate(job.Parameters{RetentionHour: float64(24), OperationRetentionHours: map[string]interface{}{"delete": float64(720)}}))
+}
+
+func (p *purgeJobTestSuite) TestRun() {
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.NilCommand, true)
+	p.auditMgr.On("Purge", mock.Anything, 24, []string{"create", "pull"}, []string(nil), false).Return(int64(10), nil)
+
+	j := &Job{auditMgr: p.auditMgr}
+	err := j.Run(ctx, job.Parameters{
+		RetentionHour:     float64(24),
+		IncludeOperations: "create, pull,",
+		DryRun:            false,
+	})
+	p.Nil(err)
+	p.auditMgr.AssertExpectations(p.T())
+}
+
+func (p *purgeJobTestSuite) TestRunDryRun() {
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.NilCommand, true)
+	p.auditMgr.On("Purge", mock.Anything, 48, []string(nil), []string(nil), true).Return(int64(3), nil)
+
+	j := &Job{auditMgr: p.auditMgr}
+	err := j.Run(ctx, job.Parameters{
+		RetentionHour: float64(48),
+		DryRun:        true,
+	})
+	p.Nil(err)
+	p.auditMgr.AssertExpectations(p.T())
+}
+
+func (p *purgeJobTestSuite) TestStop() {
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.StopCommand, true)
+
+	j := &Job{auditMgr: p.auditMgr}
+	p.Nil(j.Run(ctx, job.Parameters{RetentionHour: float64(24)}))
+	p.auditMgr.AssertNotCalled(p.T(), "Purge", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (p *purgeJobTestSuite) TestRunWithOperationRetentionHours() {
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.NilCommand, true)
+	// the deletes are kept longer than the others
+	p.auditMgr.On("Purge", mock.Anything, 720, []string{"delete"}, []string(nil), false).Return(int64(1), nil)
+	p.auditMgr.On("Purge", mock.Anything, 24, []string{"pull"}, []string{"delete"}, false).Return(int64(10), nil)
+
+	j := &Job{auditMgr: p.auditMgr}
+	err := j.Run(ctx, job.Parameters{
+		RetentionHour:           float64(24),
+		IncludeOperations:       "Delete,pull",
+		OperationRetentionHours: map[string]interface{}{"Delete": float64(720)},
+	})
+	p.Nil(err)
+	p.auditMgr.AssertExpectations(p.T())
+}
+
+func (p *purgeJobTestSuite) TestRunWithOnlyOperationRetentionHours() {
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.NilCommand, true)
+	p.auditMgr.On("Purge", mock.Anything, 720, []string{"delete"}, []string(nil), true).Return(int64(1), nil)
+
+	j := &Job{auditMgr: p.auditMgr}
+	err := j.Run(ctx, job.Parameters{
+		RetentionHour:           float64(24),
+		IncludeOperations:       "delete",
+		OperationRetentionHours: map[string]interface{}{"delete": float64(720)},
+		DryRun:                  true,
+	})
+	p.Nil(err)
+	p.auditMgr.AssertExpectations(p.T())
+	p.auditMgr.AssertNumberOfCalls(p.T(), "Purge", 1)
+}
+
+func TestPurgeJob(t *testing.T) {
+	suite.Run(t, &purgeJobTestSuite{})
+}
@@ -0,0 +1,98 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/controller/replication/transfer"
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/lib/errors"
+	libredis "github.com/goharbor/harbor/src/lib/redis"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/gomodule/redigo/redis"
+)
+
+// newLimiter creates the limiter which limits the aggregate rate of all the tasks of the policy
+// and the aggregate rate of all the tasks whose destination is the registry, the limiters are
+// shared by the jobservice workers through Redis
+func newLimiter(logger logger.Interface, policyID int64, speed int32, schedule model.BandwidthSchedule, dst *model.Registry) transfer.Limiter {
+	limitPolicy := speed > 0 || len(schedule) > 0
+	limitRegistry := dst != nil && dst.ID > 0 && (dst.Speed > 0 || len(dst.BandwidthSchedule) > 0)
+	if !limitPolicy && !limitRegistry {
+		return nil
+	}
+
+	pool, err := redisPool()
+	if err != nil {
+		logger.Warningf("failed to get the redis pool, the bandwidth is limited for the current task only: %v", err)
+	}
+
+	var policyLimiter, registryLimiter transfer.Limiter
+	if limitPolicy {
+		logger.Infof("limit the bandwidth of the policy at %d kb/s, the bandwidth schedule: %s", speed, formatSchedule(schedule))
+		if policyID > 0 {
+			policyLimiter = transfer.NewSharedLimiter(pool, limiterKey("policy", policyID), speed, schedule)
+		} else {
+			// the jobs submitted by the previous versions have no policy ID
+			policyLimiter = transfer.NewLimiter(speed, schedule)
+		}
+	}
+	if limitRegistry {
+		logger.Infof("limit the bandwidth of the destination registry at %d kb/s, the bandwidth schedule: %s",
+			dst.Speed, formatSchedule(dst.BandwidthSchedule))
+		registryLimiter = transfer.NewSharedLimiter(pool, limiterKey("registry", dst.ID), dst.Speed, dst.BandwidthSchedule)
+	}
+	return transfer.NewMultiLimiter(policyLimiter, registryLimiter)
+}
+
+func redisPool() (*redis.Pool, error) {
+	cfg := config.DefaultConfig
+	if cfg == nil || cfg.PoolConfig == nil || cfg.PoolConfig.RedisPoolCfg == nil ||
+		len(cfg.PoolConfig.RedisPoolCfg.RedisURL) == 0 {
+		return nil, errors.New("the redis of jobservice isn't configured")
+	}
+	return libredis.GetRedisPool("ReplicationBandwidth", cfg.PoolConfig.RedisPoolCfg.RedisURL, &libredis.PoolParam{
+		PoolMaxIdle:           10,
+		PoolIdleTimeout:       time.Minute,
+		DialConnectionTimeout: time.Second,
+		DialReadTimeout:       time.Second,
+		DialWriteTimeout:      time.Second,
+	})
+}
+
+func limiterKey(kind string, id int64) string {
+	var namespace string
+	if cfg := config.DefaultConfig; cfg != nil && cfg.PoolConfig != nil && cfg.PoolConfig.RedisPoolCfg != nil {
+		namespace = cfg.PoolConfig.RedisPoolCfg.Namespace
+	}
+	return fmt.Sprintf("%s:replication:bandwidth:%s:%d", namespace, kind, id)
+}
+
+func formatSchedule(schedule model.BandwidthSchedule) string {
+	if len(schedule) == 0 {
+		return "none"
+	}
+	var s string
+	for i, window := range schedule {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("%s-%s %d kb/s", window.Start, window.End, window.Speed)
+	}
+	return s
+}
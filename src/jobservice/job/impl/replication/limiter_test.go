@@ -0,0 +1,55 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLimiter(t *testing.T) {
+	logger := log.DefaultLogger()
+
+	// no limit
+	assert.Nil(t, newLimiter(logger, 1, 0, nil, nil))
+	assert.Nil(t, newLimiter(logger, 1, 0, nil, &model.Registry{ID: 1}))
+
+	// limit the policy
+	assert.NotNil(t, newLimiter(logger, 1, 1024, nil, nil))
+	assert.NotNil(t, newLimiter(logger, 0, 0, model.BandwidthSchedule{
+		{Start: "09:00", End: "18:00", Speed: 1024},
+	}, nil))
+
+	// limit the registry
+	assert.NotNil(t, newLimiter(logger, 1, 0, nil, &model.Registry{ID: 1, Speed: 1024}))
+}
+
+func TestLimiterKey(t *testing.T) {
+	cfg := config.DefaultConfig
+	defer func() { config.DefaultConfig = cfg }()
+
+	config.DefaultConfig = &config.Configuration{
+		PoolConfig: &config.PoolConfig{
+			RedisPoolCfg: &config.RedisPoolConfig{
+				Namespace: "{harbor_job_service_namespace}",
+			},
+		},
+	}
+	assert.Equal(t, "{harbor_job_service_namespace}:replication:bandwidth:policy:1", limiterKey("policy", 1))
+}
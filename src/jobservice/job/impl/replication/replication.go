@@ -75,13 +75,44 @@ func (r *Replication) Run(ctx job.Context, params job.Parameters) error {
 		}
 		return cmd == job.StopCommand
 	}
-	trans, err := factory(ctx.GetLogger(), stopFunc)
+	trans, err := factory(ctx.GetLogger(), stopFunc, newSessionStore(ctx))
 	if err != nil {
 		logger.Errorf("failed to create transfer: %v", err)
 		return err
 	}
 
-	return trans.Transfer(src, dst, speed)
+	policyID, schedule, err := parseBandwidthParams(params)
+	if err != nil {
+		logger.Errorf("failed to parse parameters: %v", err)
+		return err
+	}
+
+	return trans.Transfer(src, dst, newLimiter(logger, policyID, speed, schedule, dst.Registry))
+}
+
+// parse the parameters of the bandwidth shared by the tasks of the policy,
+// they are absent in the jobs submitted by the previous versions
+func parseBandwidthParams(params map[string]interface{}) (int64, model.BandwidthSchedule, error) {
+	var policyID int64
+	if value, exist := params["policy_id"]; exist {
+		switch id := value.(type) {
+		case int64:
+			policyID = id
+		case int:
+			policyID = int64(id)
+		case float64:
+			policyID = int64(id)
+		default:
+			return 0, nil, fmt.Errorf("the value of policy_id isn't integer (%T)", value)
+		}
+	}
+	var schedule model.BandwidthSchedule
+	if _, exist := params["bandwidth_schedule"]; exist {
+		if err := parseParam(params, "bandwidth_schedule", &schedule); err != nil {
+			return 0, nil, err
+		}
+	}
+	return policyID, schedule, nil
 }
 
 func parseParams(params map[string]interface{}) (*model.Resource, *model.Resource, int32, error) {
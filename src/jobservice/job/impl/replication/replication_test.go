@@ -60,6 +60,30 @@ func TestParseParams(t *testing.T) {
 	assert.Equal(t, int32(0), speed)
 }
 
+func TestParseBandwidthParams(t *testing.T) {
+	// the jobs submitted by the previous versions
+	policyID, schedule, err := parseBandwidthParams(map[string]interface{}{})
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), policyID)
+	assert.Nil(t, schedule)
+
+	// invalid policy ID
+	_, _, err = parseBandwidthParams(map[string]interface{}{
+		"policy_id": "1",
+	})
+	assert.NotNil(t, err)
+
+	// pass
+	policyID, schedule, err = parseBandwidthParams(map[string]interface{}{
+		"policy_id":          float64(1),
+		"bandwidth_schedule": `[{"start":"09:00","end":"18:00","speed":1024}]`,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), policyID)
+	require.Len(t, schedule, 1)
+	assert.Equal(t, int32(1024), schedule[0].Speed)
+}
+
 func TestMaxFails(t *testing.T) {
 	rep := &Replication{}
 	assert.Equal(t, uint(3), rep.MaxFails())
@@ -77,13 +101,13 @@ func TestValidate(t *testing.T) {
 
 var transferred = false
 
-var fakedTransferFactory = func(transfer.Logger, transfer.StopFunc) (transfer.Transfer, error) {
+var fakedTransferFactory = func(transfer.Logger, transfer.StopFunc, transfer.SessionStore) (transfer.Transfer, error) {
 	return &fakedTransfer{}, nil
 }
 
 type fakedTransfer struct{}
 
-func (f *fakedTransfer) Transfer(src *model.Resource, dst *model.Resource, speed int32) error {
+func (f *fakedTransfer) Transfer(src *model.Resource, dst *model.Resource, limiter transfer.Limiter) error {
 	transferred = true
 	return nil
 }
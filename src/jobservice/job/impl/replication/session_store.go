@@ -0,0 +1,109 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/goharbor/harbor/src/controller/replication/transfer"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+// the key of the task extra attribute that the blob upload sessions are persisted in
+const blobUploadSessionsKey = "blob_upload_sessions"
+
+// sessionStore persists the blob upload sessions in the extra attributes of the
+// replication task that the job belongs to, so that the retried job can resume the uploads
+type sessionStore struct {
+	ctx     context.Context
+	jobID   string
+	taskMgr task.Manager
+}
+
+func newSessionStore(ctx job.Context) transfer.SessionStore {
+	tracker := ctx.Tracker()
+	if tracker == nil || tracker.Job() == nil {
+		return nil
+	}
+	return &sessionStore{
+		ctx:     ctx.SystemContext(),
+		jobID:   tracker.Job().Info.JobID,
+		taskMgr: task.Mgr,
+	}
+}
+
+func (s *sessionStore) Get(digest string) (*transfer.BlobUploadSession, error) {
+	_, sessions, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return sessions[digest], nil
+}
+
+func (s *sessionStore) Save(session *transfer.BlobUploadSession) error {
+	t, sessions, err := s.load()
+	if err != nil {
+		return err
+	}
+	sessions[session.Digest] = session
+	return s.save(t, sessions)
+}
+
+func (s *sessionStore) Delete(digest string) error {
+	t, sessions, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, exist := sessions[digest]; !exist {
+		return nil
+	}
+	delete(sessions, digest)
+	return s.save(t, sessions)
+}
+
+func (s *sessionStore) load() (*task.Task, map[string]*transfer.BlobUploadSession, error) {
+	tasks, err := s.taskMgr.List(s.ctx, q.New(q.KeyWords{"JobID": s.jobID}))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, nil, errors.NotFoundError(nil).WithMessage("task with job ID %s not found", s.jobID)
+	}
+	t := tasks[0]
+
+	sessions := map[string]*transfer.BlobUploadSession{}
+	if value, exist := t.ExtraAttrs[blobUploadSessionsKey]; exist {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err = json.Unmarshal(data, &sessions); err != nil {
+			return nil, nil, err
+		}
+	}
+	return t, sessions, nil
+}
+
+func (s *sessionStore) save(t *task.Task, sessions map[string]*transfer.BlobUploadSession) error {
+	if t.ExtraAttrs == nil {
+		t.ExtraAttrs = map[string]interface{}{}
+	}
+	t.ExtraAttrs[blobUploadSessionsKey] = sessions
+	return s.taskMgr.UpdateExtraAttrs(s.ctx, t.ID, t.ExtraAttrs)
+}
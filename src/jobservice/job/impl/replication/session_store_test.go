@@ -0,0 +1,106 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goharbor/harbor/src/controller/replication/transfer"
+	"github.com/goharbor/harbor/src/pkg/task"
+	tasktesting "github.com/goharbor/harbor/src/testing/pkg/task"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type sessionStoreTestSuite struct {
+	suite.Suite
+	taskMgr *tasktesting.Manager
+	store   *sessionStore
+}
+
+func (s *sessionStoreTestSuite) SetupTest() {
+	s.taskMgr = &tasktesting.Manager{}
+	s.store = &sessionStore{
+		ctx:     context.TODO(),
+		jobID:   "job-id",
+		taskMgr: s.taskMgr,
+	}
+}
+
+func (s *sessionStoreTestSuite) TestGet() {
+	s.taskMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Task{
+		{
+			ID: 1,
+			ExtraAttrs: map[string]interface{}{
+				blobUploadSessionsKey: map[string]interface{}{
+					"digest": map[string]interface{}{
+						"digest":   "digest",
+						"location": "location",
+						"offset":   10,
+					},
+				},
+			},
+		},
+	}, nil)
+
+	session, err := s.store.Get("digest")
+	s.Require().Nil(err)
+	s.Require().NotNil(session)
+	s.Equal("location", session.Location)
+	s.Equal(int64(10), session.Offset)
+
+	session, err = s.store.Get("not-exist")
+	s.Require().Nil(err)
+	s.Nil(session)
+}
+
+func (s *sessionStoreTestSuite) TestGetTaskNotFound() {
+	s.taskMgr.On("List", mock.Anything, mock.Anything).Return(nil, nil)
+
+	_, err := s.store.Get("digest")
+	s.NotNil(err)
+}
+
+func (s *sessionStoreTestSuite) TestSaveAndDelete() {
+	t := &task.Task{
+		ID: 1,
+		ExtraAttrs: map[string]interface{}{
+			"resource_type": "image",
+		},
+	}
+	s.taskMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Task{t}, nil)
+	s.taskMgr.On("UpdateExtraAttrs", mock.Anything, int64(1), mock.Anything).Return(nil)
+
+	err := s.store.Save(&transfer.BlobUploadSession{
+		Digest:   "digest",
+		Location: "location",
+		Offset:   10,
+	})
+	s.Require().Nil(err)
+	s.Equal("image", t.ExtraAttrs["resource_type"])
+	sessions := t.ExtraAttrs[blobUploadSessionsKey].(map[string]*transfer.BlobUploadSession)
+	s.Equal(int64(10), sessions["digest"].Offset)
+
+	err = s.store.Delete("digest")
+	s.Require().Nil(err)
+	sessions = t.ExtraAttrs[blobUploadSessionsKey].(map[string]*transfer.BlobUploadSession)
+	s.Empty(sessions)
+	s.taskMgr.AssertNumberOfCalls(s.T(), "UpdateExtraAttrs", 2)
+}
+
+func TestSessionStoreTestSuite(t *testing.T) {
+	suite.Run(t, &sessionStoreTestSuite{})
+}
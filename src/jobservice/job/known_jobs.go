@@ -30,8 +30,12 @@ const (
 	WebhookJob = "WEBHOOK"
 	// SlackJob : the name of the slack job in job service
 	SlackJob = "SLACK"
+	// EmailJob : the name of the email job in job service
+	EmailJob = "EMAIL"
 	// Retention : the name of the retention job
 	Retention = "RETENTION"
 	// P2PPreheat : the name of the P2P preheat job
 	P2PPreheat = "P2P_PREHEAT"
+	// PurgeAudit : the name of the purge audit log job
+	PurgeAudit = "PURGE_AUDIT_LOG"
 )
@@ -156,6 +156,8 @@ var (
 		{Name: common.RobotTokenDuration, Scope: UserScope, Group: BasicGroup, EnvKey: "ROBOT_TOKEN_DURATION", DefaultValue: "30", ItemType: &IntType{}, Editable: true, Description: `The robot account token duration in days`},
 		{Name: common.RobotNamePrefix, Scope: UserScope, Group: BasicGroup, EnvKey: "ROBOT_NAME_PREFIX", DefaultValue: "robot$", ItemType: &StringType{}, Editable: true, Description: `The rebot account name prefix`},
 		{Name: common.NotificationEnable, Scope: UserScope, Group: BasicGroup, EnvKey: "NOTIFICATION_ENABLE", DefaultValue: "true", ItemType: &BoolType{}, Editable: true, Description: `Enable notification`},
+		{Name: common.NotificationEmailLanguage, Scope: UserScope, Group: BasicGroup, EnvKey: "NOTIFICATION_EMAIL_LANGUAGE", DefaultValue: "en-us", ItemType: &StringType{}, Editable: true, Description: `The language of the email notification, en-us or ko-kr`},
+		{Name: common.NotificationEmailTemplates, Scope: UserScope, Group: BasicGroup, EnvKey: "NOTIFICATION_EMAIL_TEMPLATES", DefaultValue: "{}", ItemType: &StringToStringMapType{}, Editable: true, Description: `The customized Go templates of the email notification, the key is in format "<language>.<subject|text|html>"`},
 
 		{Name: common.MetricEnable, Scope: SystemScope, Group: BasicGroup, EnvKey: "METRIC_ENABLE", DefaultValue: "false", ItemType: &BoolType{}, Editable: true},
 		{Name: common.MetricPort, Scope: SystemScope, Group: BasicGroup, EnvKey: "METRIC_PORT", DefaultValue: "9090", ItemType: &PortType{}, Editable: true},
@@ -164,6 +166,15 @@ var (
 		{Name: common.QuotaPerProjectEnable, Scope: UserScope, Group: QuotaGroup, EnvKey: "QUOTA_PER_PROJECT_ENABLE", DefaultValue: "true", ItemType: &BoolType{}, Editable: true, Description: `Enable quota per project`},
 		{Name: common.StoragePerProject, Scope: UserScope, Group: QuotaGroup, EnvKey: "STORAGE_PER_PROJECT", DefaultValue: "-1", ItemType: &QuotaType{}, Editable: true, Description: `The storage quota per project`},
 
+		{Name: common.ProjectRequestTTL, Scope: UserScope, Group: BasicGroup, EnvKey: "PROJECT_REQUEST_TTL", DefaultValue: "0", ItemType: &IntType{}, Editable: true, Description: `The hours a pending project request lives before it's expired, 0 means never expire`},
+		{Name: common.ProjectRequestApprovalRules, Scope: UserScope, Group: BasicGroup, EnvKey: "PROJECT_REQUEST_APPROVAL_RULES", DefaultValue: "[]", ItemType: &ProjectRequestApprovalRulesType{}, Editable: true, Description: `The rules to approve the project requests automatically`},
+
+		{Name: common.AuditLogForwardEndpoint, Scope: UserScope, Group: BasicGroup, EnvKey: "AUDIT_LOG_FORWARD_ENDPOINT", DefaultValue: "", ItemType: &StringType{}, Editable: true, Description: `The endpoint to forward the audit logs to, "udp://", "tcp://" or "tls://" for the syslog server, "http://" or "https://" for the HTTP endpoint`},
+		{Name: common.AuditLogForwardInsecure, Scope: UserScope, Group: BasicGroup, EnvKey: "AUDIT_LOG_FORWARD_INSECURE", DefaultValue: "false", ItemType: &BoolType{}, Editable: true, Description: `Skip the certificate verification when forwarding the audit logs over TLS`},
+		{Name: common.SkipAuditLogDatabase, Scope: UserScope, Group: BasicGroup, EnvKey: "SKIP_AUDIT_LOG_DATABASE", DefaultValue: "false", ItemType: &BoolType{}, Editable: true, Description: `Skip persisting the audit logs into database, only works when the audit logs are forwarded`},
+		{Name: common.AuditLogForwardBufferDir, Scope: SystemScope, Group: BasicGroup, EnvKey: "AUDIT_LOG_FORWARD_BUFFER_DIR", DefaultValue: "/tmp/audit_log_buffer", ItemType: &StringType{}, Editable: false, Description: `The directory to buffer the audit logs waiting to be forwarded`},
+		{Name: common.AuditLogForwardBufferSize, Scope: SystemScope, Group: BasicGroup, EnvKey: "AUDIT_LOG_FORWARD_BUFFER_SIZE", DefaultValue: "104857600", ItemType: &Int64Type{}, Editable: false, Description: `The max size in bytes of the buffered audit logs, the oldest ones are dropped when it's exceeded`},
+
 		{Name: common.TraceEnabled, Scope: SystemScope, Group: BasicGroup, EnvKey: "TRACE_ENABLED", DefaultValue: "false", ItemType: &BoolType{}, Editable: false, Description: `Enable trace`},
 		{Name: common.TraceServiceName, Scope: SystemScope, Group: BasicGroup, EnvKey: "TRACE_SERVICE_NAME", DefaultValue: "", ItemType: &StringType{}, Editable: false, Description: `The service name of the trace`},
 		{Name: common.TraceNamespace, Scope: SystemScope, Group: BasicGroup, EnvKey: "TRACE_NAMESPACE", DefaultValue: "", ItemType: &StringType{}, Editable: false, Description: `The namespace of the trace`},
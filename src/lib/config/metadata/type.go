@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/lib/config/models"
 )
 
 // Type - Use this interface to define and encapsulate the behavior of validation and transformation
@@ -200,6 +201,48 @@ func (t *MapType) get(str string) (interface{}, error) {
 	return result, err
 }
 
+// ListType ...
+type ListType struct {
+}
+
+func (t *ListType) validate(str string) error {
+	result := []interface{}{}
+	err := json.Unmarshal([]byte(str), &result)
+	return err
+}
+
+func (t *ListType) get(str string) (interface{}, error) {
+	result := []interface{}{}
+	err := json.Unmarshal([]byte(str), &result)
+	return result, err
+}
+
+// ProjectRequestApprovalRulesType ...
+type ProjectRequestApprovalRulesType struct {
+	ListType
+}
+
+func (t *ProjectRequestApprovalRulesType) validate(str string) error {
+	var rules []*models.ProjectRequestApprovalRule
+	if err := json.Unmarshal([]byte(str), &rules); err != nil {
+		return err
+	}
+	names := map[string]struct{}{}
+	for _, rule := range rules {
+		if rule == nil {
+			return fmt.Errorf("invalid %s, the rule can not be null", common.ProjectRequestApprovalRules)
+		}
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("invalid %s, %v", common.ProjectRequestApprovalRules, err)
+		}
+		if _, exist := names[rule.Name]; exist {
+			return fmt.Errorf("invalid %s, duplicate rule %s", common.ProjectRequestApprovalRules, rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+	}
+	return nil
+}
+
 // StringToStringMapType ...
 type StringToStringMapType struct {
 }
@@ -98,6 +98,40 @@ func TestMapType_get(t *testing.T) {
 	assert.Equal(t, map[string]interface{}{"sample": "abc", "another": "welcome"}, result)
 }
 
+func TestListType_validate(t *testing.T) {
+	test := &ListType{}
+	assert.Nil(t, test.validate(`[{"name":"dev"}, {"name":"qa"}]`))
+	assert.Nil(t, test.validate(`[]`))
+	assert.NotNil(t, test.validate(`{"name":"dev"}`))
+}
+
+func TestListType_get(t *testing.T) {
+	test := &ListType{}
+	result, _ := test.get(`[{"name":"dev"}]`)
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "dev"}}, result)
+}
+
+func TestProjectRequestApprovalRulesType_validate(t *testing.T) {
+	test := &ProjectRequestApprovalRulesType{}
+	assert.Nil(t, test.validate(`[]`))
+	assert.Nil(t, test.validate(`[{"name":"dev","groups":["dev"]},{"name":"team","project_name_pattern":"team-**","max_storage_quota":1024}]`))
+	assert.NotNil(t, test.validate(`{"name":"dev"}`))
+	assert.NotNil(t, test.validate(`[null]`))
+	// no restriction
+	assert.NotNil(t, test.validate(`[{"name":"dev"}]`))
+	// no name
+	assert.NotNil(t, test.validate(`[{"groups":["dev"]}]`))
+	// duplicate names
+	assert.NotNil(t, test.validate(`[{"name":"dev","groups":["dev"]},{"name":"dev","groups":["qa"]}]`))
+	// malformed pattern
+	assert.NotNil(t, test.validate(`[{"name":"team","project_name_pattern":"team-["}]`))
+	// negative limits
+	assert.NotNil(t, test.validate(`[{"name":"dev","groups":["dev"],"max_storage_quota":-1}]`))
+	assert.NotNil(t, test.validate(`[{"name":"dev","groups":["dev"],"max_projects_per_user":-1}]`))
+	// invalid field type
+	assert.NotNil(t, test.validate(`[{"name":"dev","groups":"dev"}]`))
+}
+
 func TestStringToStringMapType_validate(t *testing.T) {
 	test := &StringToStringMapType{}
 	assert.Nil(t, test.validate(`{"sample":"abc", "another":"welcome"}`))
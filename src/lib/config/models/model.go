@@ -15,6 +15,10 @@
 package models
 
 import (
+	"fmt"
+	"path"
+	"strings"
+
 	"github.com/astaxie/beego/orm"
 )
 
@@ -62,6 +66,49 @@ type QuotaSetting struct {
 	StoragePerProject int64 `json:"storage_per_project"`
 }
 
+// ProjectRequestApprovalRule is the rule to approve the project request automatically,
+// the empty or zero fields don't restrict the request
+type ProjectRequestApprovalRule struct {
+	Name string `json:"name"`
+	// the names of the usergroups, the requester should be member of one of them
+	Groups []string `json:"groups,omitempty"`
+	// the glob pattern which the name of the requested project should match
+	ProjectNamePattern string `json:"project_name_pattern,omitempty"`
+	// the max storage quota in bytes, the unlimited quota(-1) doesn't match the positive value
+	MaxStorageQuota int64 `json:"max_storage_quota,omitempty"`
+	// the max count of the projects owned by the requester, the requested one is excluded
+	MaxProjectsPerUser int64 `json:"max_projects_per_user,omitempty"`
+}
+
+// Validate checks the rule, the rule without any restriction is rejected as it approves all the requests
+func (r *ProjectRequestApprovalRule) Validate() error {
+	if len(strings.TrimSpace(r.Name)) == 0 {
+		return fmt.Errorf("the name of the auto-approval rule is required")
+	}
+	for _, g := range r.Groups {
+		if len(strings.TrimSpace(g)) == 0 {
+			return fmt.Errorf("empty group in the auto-approval rule %s", r.Name)
+		}
+	}
+	if r.ProjectNamePattern != "" {
+		// the "**" of the doublestar pattern is equivalent to "*" as the project name contains no "/",
+		// and path.Match reports the malformed pattern regardless of the name matched
+		if _, err := path.Match(strings.ReplaceAll(r.ProjectNamePattern, "**", "*"), ""); err != nil {
+			return fmt.Errorf("invalid project name pattern %s of the auto-approval rule %s: %v", r.ProjectNamePattern, r.Name, err)
+		}
+	}
+	if r.MaxStorageQuota < 0 {
+		return fmt.Errorf("the max storage quota of the auto-approval rule %s can not be negative", r.Name)
+	}
+	if r.MaxProjectsPerUser < 0 {
+		return fmt.Errorf("the max projects per user of the auto-approval rule %s can not be negative", r.Name)
+	}
+	if len(r.Groups) == 0 && r.ProjectNamePattern == "" && r.MaxStorageQuota == 0 && r.MaxProjectsPerUser == 0 {
+		return fmt.Errorf("the auto-approval rule %s has no restriction", r.Name)
+	}
+	return nil
+}
+
 func init() {
 	orm.RegisterModel(new(ConfigEntry))
 }
@@ -141,6 +141,16 @@ func GetGCTimeWindow() int64 {
 	return common.DefaultGCTimeWindowHours
 }
 
+// AuditLogForwardBufferDir returns the directory to buffer the audit logs waiting to be forwarded
+func AuditLogForwardBufferDir() string {
+	return defaultMgr().Get(backgroundCtx, common.AuditLogForwardBufferDir).GetString()
+}
+
+// AuditLogForwardBufferSize returns the max size in bytes of the buffered audit logs
+func AuditLogForwardBufferSize() int64 {
+	return defaultMgr().Get(backgroundCtx, common.AuditLogForwardBufferSize).GetInt64()
+}
+
 // WithNotary returns a bool value to indicate if Harbor's deployed with Notary
 func WithNotary() bool {
 	return defaultMgr().Get(backgroundCtx, common.WithNotary).GetBool()
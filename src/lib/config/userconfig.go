@@ -16,6 +16,7 @@ package config
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/models"
 	cfgModels "github.com/goharbor/harbor/src/lib/config/models"
@@ -196,11 +197,39 @@ func NotificationEnable(ctx context.Context) bool {
 	return defaultMgr().Get(ctx, common.NotificationEnable).GetBool()
 }
 
+// NotificationEmailLanguage returns the language of the email notification
+func NotificationEmailLanguage(ctx context.Context) string {
+	return defaultMgr().Get(ctx, common.NotificationEmailLanguage).GetString()
+}
+
+// NotificationEmailTemplates returns the customized templates of the email notification
+func NotificationEmailTemplates(ctx context.Context) map[string]string {
+	return defaultMgr().Get(ctx, common.NotificationEmailTemplates).GetStringToStringMap()
+}
+
 // QuotaPerProjectEnable returns a bool to indicates if quota per project enabled in harbor
 func QuotaPerProjectEnable(ctx context.Context) bool {
 	return defaultMgr().Get(ctx, common.QuotaPerProjectEnable).GetBool()
 }
 
+// ProjectRequestTTL returns the hours a pending project request lives before it's expired, 0 means never expire
+func ProjectRequestTTL(ctx context.Context) int {
+	return defaultMgr().Get(ctx, common.ProjectRequestTTL).GetInt()
+}
+
+// ProjectRequestApprovalRules returns the rules to approve the project requests automatically
+func ProjectRequestApprovalRules(ctx context.Context) ([]*cfgModels.ProjectRequestApprovalRule, error) {
+	var rules []*cfgModels.ProjectRequestApprovalRule
+	value := defaultMgr().Get(ctx, common.ProjectRequestApprovalRules).GetString()
+	if len(value) == 0 {
+		return rules, nil
+	}
+	if err := json.Unmarshal([]byte(value), &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
 // QuotaSetting returns the setting of quota.
 func QuotaSetting(ctx context.Context) (*cfgModels.QuotaSetting, error) {
 	if err := defaultMgr().Load(ctx); err != nil {
@@ -226,3 +255,18 @@ func SplitAndTrim(s, sep string) []string {
 	}
 	return res
 }
+
+// AuditLogForwardEndpoint returns the endpoint to which the audit logs are forwarded, empty means not forwarded
+func AuditLogForwardEndpoint(ctx context.Context) string {
+	return defaultMgr().Get(ctx, common.AuditLogForwardEndpoint).GetString()
+}
+
+// AuditLogForwardInsecure returns whether to skip the certificate verification when forwarding the audit logs
+func AuditLogForwardInsecure(ctx context.Context) bool {
+	return defaultMgr().Get(ctx, common.AuditLogForwardInsecure).GetBool()
+}
+
+// SkipAuditLogDatabase returns whether to skip persisting the audit logs into database
+func SkipAuditLogDatabase(ctx context.Context) bool {
+	return defaultMgr().Get(ctx, common.SkipAuditLogDatabase).GetBool()
+}
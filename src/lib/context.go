@@ -25,6 +25,7 @@ const (
 	contextKeyAPIVersion   contextKey = "apiVersion"
 	contextKeyArtifactInfo contextKey = "artifactInfo"
 	contextKeyAuthMode     contextKey = "authMode"
+	contextKeyAuthMethod   contextKey = "authMethod"
 	contextKeyCarrySession contextKey = "carrySession"
 )
 
@@ -98,6 +99,21 @@ func GetAuthMode(ctx context.Context) string {
 	return mode
 }
 
+// WithAuthMethod returns a context with the method by which the request is authenticated set
+func WithAuthMethod(ctx context.Context, method string) context.Context {
+	return setToContext(ctx, contextKeyAuthMethod, method)
+}
+
+// GetAuthMethod gets the method by which the request is authenticated from the context
+func GetAuthMethod(ctx context.Context) string {
+	method := ""
+	value := getFromContext(ctx, contextKeyAuthMethod)
+	if value != nil {
+		method, _ = value.(string)
+	}
+	return method
+}
+
 // WithCarrySession returns a context with "carry session" set that indicates whether the request carries session or not
 func WithCarrySession(ctx context.Context, carrySession bool) context.Context {
 	return setToContext(ctx, contextKeyCarrySession, carrySession)
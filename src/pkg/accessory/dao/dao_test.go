@@ -0,0 +1,135 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"testing"
+
+	beegoorm "github.com/astaxie/beego/orm"
+	common_dao "github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	artdao "github.com/goharbor/harbor/src/pkg/artifact/dao"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+)
+
+type daoTestSuite struct {
+	suite.Suite
+	dao          DAO
+	artDAO       artdao.DAO
+	ctx          context.Context
+	subjectArtID int64
+	accArtID     int64
+	id           int64
+}
+
+func (d *daoTestSuite) SetupSuite() {
+	common_dao.PrepareTestForPostgresSQL()
+	d.dao = New()
+	d.artDAO = artdao.New()
+	d.ctx = orm.NewContext(nil, beegoorm.NewOrm())
+}
+
+func (d *daoTestSuite) SetupTest() {
+	id, err := d.artDAO.Create(d.ctx, &artdao.Artifact{
+		Type:              "IMAGE",
+		MediaType:         v1.MediaTypeImageConfig,
+		ManifestMediaType: v1.MediaTypeImageManifest,
+		ProjectID:         1,
+		RepositoryID:      1,
+		Digest:            "sha256:subject",
+	})
+	d.Require().Nil(err)
+	d.subjectArtID = id
+
+	id, err = d.artDAO.Create(d.ctx, &artdao.Artifact{
+		Type:              "IMAGE",
+		MediaType:         v1.MediaTypeImageConfig,
+		ManifestMediaType: v1.MediaTypeImageManifest,
+		ProjectID:         1,
+		RepositoryID:      1,
+		Digest:            "sha256:signature",
+	})
+	d.Require().Nil(err)
+	d.accArtID = id
+
+	id, err = d.dao.Create(d.ctx, &model.Accessory{
+		ArtifactID:        d.accArtID,
+		SubjectArtifactID: d.subjectArtID,
+		Type:              model.TypeCosignSignature,
+		Size:              1024,
+		Digest:            "sha256:signature",
+	})
+	d.Require().Nil(err)
+	d.id = id
+}
+
+func (d *daoTestSuite) TearDownTest() {
+	d.Require().Nil(d.artDAO.Delete(d.ctx, d.accArtID))
+	d.Require().Nil(d.artDAO.Delete(d.ctx, d.subjectArtID))
+}
+
+func (d *daoTestSuite) TestCount() {
+	total, err := d.dao.Count(d.ctx, q.New(q.KeyWords{"SubjectArtifactID": d.subjectArtID}))
+	d.Require().Nil(err)
+	d.Equal(int64(1), total)
+}
+
+func (d *daoTestSuite) TestList() {
+	accs, err := d.dao.List(d.ctx, q.New(q.KeyWords{"SubjectArtifactID": d.subjectArtID}))
+	d.Require().Nil(err)
+	d.Require().Len(accs, 1)
+	d.Equal(d.accArtID, accs[0].ArtifactID)
+	d.Equal(model.TypeCosignSignature, accs[0].Type)
+}
+
+func (d *daoTestSuite) TestCreate() {
+	// conflict
+	_, err := d.dao.Create(d.ctx, &model.Accessory{
+		ArtifactID:        d.accArtID,
+		SubjectArtifactID: d.subjectArtID,
+		Type:              model.TypeCosignSignature,
+	})
+	d.True(errors.IsConflictErr(err))
+
+	// the subject artifact doesn't exist
+	_, err = d.dao.Create(d.ctx, &model.Accessory{
+		ArtifactID:        d.accArtID,
+		SubjectArtifactID: 10000,
+		Type:              model.TypeCosignSignature,
+	})
+	d.True(errors.IsErr(err, errors.ViolateForeignKeyConstraintCode))
+}
+
+func (d *daoTestSuite) TestDelete() {
+	err := d.dao.Delete(d.ctx, 10000)
+	d.True(errors.IsErr(err, errors.NotFoundCode))
+
+	d.Require().Nil(d.dao.Delete(d.ctx, d.id))
+}
+
+func (d *daoTestSuite) TestDeleteAccessories() {
+	n, err := d.dao.DeleteAccessories(d.ctx, q.New(q.KeyWords{"ArtifactID": d.accArtID}))
+	d.Require().Nil(err)
+	d.Equal(int64(1), n)
+}
+
+func TestDaoTestSuite(t *testing.T) {
+	suite.Run(t, &daoTestSuite{})
+}
@@ -0,0 +1,72 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessory
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accessory/dao"
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+)
+
+// Mgr is a global instance of accessory manager
+var Mgr = NewManager()
+
+// Manager manages the accessories attached to the artifacts
+type Manager interface {
+	// Count returns the total count of accessories according to the query
+	Count(ctx context.Context, query *q.Query) (total int64, err error)
+	// List accessories according to the query
+	List(ctx context.Context, query *q.Query) (accessories []*model.Accessory, err error)
+	// Create the accessory
+	Create(ctx context.Context, accessory *model.Accessory) (id int64, err error)
+	// Delete the accessory specified by ID
+	Delete(ctx context.Context, id int64) (err error)
+	// DeleteAccessories deletes the accessories according to the query
+	DeleteAccessories(ctx context.Context, query *q.Query) (err error)
+}
+
+// NewManager returns an instance of the default manager
+func NewManager() Manager {
+	return &manager{
+		dao: dao.New(),
+	}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.Count(ctx, query)
+}
+
+func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.Accessory, error) {
+	return m.dao.List(ctx, query)
+}
+
+func (m *manager) Create(ctx context.Context, accessory *model.Accessory) (int64, error) {
+	return m.dao.Create(ctx, accessory)
+}
+
+func (m *manager) Delete(ctx context.Context, id int64) error {
+	return m.dao.Delete(ctx, id)
+}
+
+func (m *manager) DeleteAccessories(ctx context.Context, query *q.Query) error {
+	_, err := m.dao.DeleteAccessories(ctx, query)
+	return err
+}
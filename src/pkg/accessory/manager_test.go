@@ -0,0 +1,85 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/accessory/dao"
+	"github.com/stretchr/testify/suite"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	mgr *manager
+	dao *dao.DAO
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &dao.DAO{}
+	m.mgr = &manager{
+		dao: m.dao,
+	}
+}
+
+func (m *managerTestSuite) TestCreate() {
+	m.dao.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	id, err := m.mgr.Create(context.Background(), &model.Accessory{ArtifactID: 2, SubjectArtifactID: 1})
+	m.Nil(err)
+	m.Equal(int64(1), id)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestCount() {
+	m.dao.On("Count", mock.Anything, mock.Anything).Return(int64(1), nil)
+	n, err := m.mgr.Count(context.Background(), nil)
+	m.Nil(err)
+	m.Equal(int64(1), n)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestList() {
+	m.dao.On("List", mock.Anything, mock.Anything).Return([]*model.Accessory{
+		{
+			ID:   1,
+			Type: model.TypeCosignSignature,
+		},
+	}, nil)
+	accs, err := m.mgr.List(context.Background(), q.New(q.KeyWords{"SubjectArtifactID": 1}))
+	m.Require().Nil(err)
+	m.Require().Len(accs, 1)
+	m.Equal(model.TypeCosignSignature, accs[0].Type)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestDelete() {
+	m.dao.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	m.Nil(m.mgr.Delete(context.Background(), 1))
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestDeleteAccessories() {
+	m.dao.On("DeleteAccessories", mock.Anything, mock.Anything).Return(int64(2), nil)
+	m.Nil(m.mgr.DeleteAccessories(context.Background(), q.New(q.KeyWords{"SubjectArtifactID": 1})))
+	m.dao.AssertExpectations(m.T())
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
@@ -0,0 +1,76 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+)
+
+func init() {
+	orm.RegisterModel(&Accessory{})
+}
+
+const (
+	// TypeCosignSignature is the type of the cosign signature
+	TypeCosignSignature = "signature.cosign"
+	// TypeCosignAttestation is the type of the cosign attestation
+	TypeCosignAttestation = "attestation.cosign"
+	// TypeCosignSBOM is the type of the SBOM attached by cosign
+	TypeCosignSBOM = "sbom.cosign"
+	// TypeSubject is the type of the artifact which declares the subject in its manifest(OCI 1.1 referrer)
+	TypeSubject = "subject.accessory"
+)
+
+var (
+	// cosign stores the accessories with the tag "sha256-<hex digest of subject>.<suffix>"
+	cosignTagRe = regexp.MustCompile(`^(sha256)-([a-f0-9]{64})\.(sig|att|sbom)$`)
+
+	cosignSuffixTypes = map[string]string{
+		"sig":  TypeCosignSignature,
+		"att":  TypeCosignAttestation,
+		"sbom": TypeCosignSBOM,
+	}
+)
+
+// Accessory records the artifact which is attached to the subject artifact,
+// e.g. the cosign signature, attestation and SBOM
+type Accessory struct {
+	ID                int64     `orm:"pk;auto;column(id)" json:"id"`
+	ArtifactID        int64     `orm:"column(artifact_id)" json:"artifact_id"`
+	SubjectArtifactID int64     `orm:"column(subject_artifact_id)" json:"subject_artifact_id"`
+	Type              string    `orm:"column(type)" json:"type"`
+	Size              int64     `orm:"column(size)" json:"size"`
+	Digest            string    `orm:"column(digest)" json:"digest"`
+	CreationTime      time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+}
+
+// TableName for accessory
+func (a *Accessory) TableName() string {
+	return "artifact_accessory"
+}
+
+// ParseCosignTag parses the tag following the cosign naming convention, returns the digest of
+// the subject artifact and the accessory type. The "ok" is false if the tag isn't a cosign tag
+func ParseCosignTag(tag string) (subjectDigest string, typ string, ok bool) {
+	matches := cosignTagRe.FindStringSubmatch(tag)
+	if len(matches) != 4 {
+		return "", "", false
+	}
+	return fmt.Sprintf("%s:%s", matches[1], matches[2]), cosignSuffixTypes[matches[3]], true
+}
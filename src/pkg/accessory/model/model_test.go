@@ -0,0 +1,44 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCosignTag(t *testing.T) {
+	hex := "2e2b0ff3d4d0d0bbd1e9dbcd5d3bd7e2e0b0b43a8e0d8f0c8a0e4e2c2d8b9d2a"
+	cases := []struct {
+		tag    string
+		digest string
+		typ    string
+		ok     bool
+	}{
+		{tag: "sha256-" + hex + ".sig", digest: "sha256:" + hex, typ: TypeCosignSignature, ok: true},
+		{tag: "sha256-" + hex + ".att", digest: "sha256:" + hex, typ: TypeCosignAttestation, ok: true},
+		{tag: "sha256-" + hex + ".sbom", digest: "sha256:" + hex, typ: TypeCosignSBOM, ok: true},
+		{tag: "sha256-" + hex + ".txt"},
+		{tag: "sha256-" + hex[:10] + ".sig"},
+		{tag: "latest"},
+	}
+	for _, c := range cases {
+		digest, typ, ok := ParseCosignTag(c.tag)
+		assert.Equal(t, c.ok, ok, c.tag)
+		assert.Equal(t, c.digest, digest, c.tag)
+		assert.Equal(t, c.typ, typ, c.tag)
+	}
+}
@@ -16,6 +16,8 @@ package models
 
 import (
 	"time"
+
+	"github.com/goharbor/harbor/src/pkg/reg/util"
 )
 
 // CVEAllowlist defines the data model for a CVE allowlist
@@ -32,6 +34,35 @@ type CVEAllowlist struct {
 // CVEAllowlistItem defines one item in the CVE allowlist
 type CVEAllowlistItem struct {
 	CVEID string `json:"cve_id"`
+	// ExpiresAt is the expiration time of the item in unix timestamp, the item never expires when it's nil
+	ExpiresAt *int64 `json:"expires_at,omitempty"`
+	// Justification explains why the CVE is allowed
+	Justification string `json:"justification,omitempty"`
+	// Author is the user who added the item
+	Author string `json:"author,omitempty"`
+	// Repository limits the item to the repositories matched by it, the item applies to all the repositories when it's empty.
+	// It's the full name of the repository or a doublestar pattern, e.g. "library/photon" or "library/**"
+	Repository string `json:"repository,omitempty"`
+}
+
+// IsExpired returns whether the item is expired
+func (it *CVEAllowlistItem) IsExpired() bool {
+	if it.ExpiresAt == nil {
+		return false
+	}
+	return time.Now().Unix() >= *it.ExpiresAt
+}
+
+// Applies returns whether the item applies to the specified repository
+func (it *CVEAllowlistItem) Applies(repository string) bool {
+	if len(it.Repository) == 0 {
+		return true
+	}
+	matched, err := util.Match(it.Repository, repository)
+	if err != nil {
+		return false
+	}
+	return matched
 }
 
 // TableName ...
@@ -48,6 +79,22 @@ func (c *CVEAllowlist) CVESet() CVESet {
 	return r
 }
 
+// CVESetFor returns the set of CVE id of the items which are not expired and apply to the repository,
+// the set is empty when the whole allowlist is expired
+func (c *CVEAllowlist) CVESetFor(repository string) CVESet {
+	r := CVESet{}
+	if c.IsExpired() {
+		return r
+	}
+	for _, it := range c.Items {
+		if it.IsExpired() || !it.Applies(repository) {
+			continue
+		}
+		r[it.CVEID] = struct{}{}
+	}
+	return r
+}
+
 // IsExpired returns whether the allowlist is expired
 func (c *CVEAllowlist) IsExpired() bool {
 	if c.ExpiresAt == nil {
@@ -70,3 +70,25 @@ func TestCVEAllowlist_All(t *testing.T) {
 		assert.Equal(t, c.cveset, c.input.CVESet())
 	}
 }
+
+func TestCVEAllowlist_CVESetFor(t *testing.T) {
+	future := int64(4411494000)
+	past := time.Now().Unix() - 60
+	l := CVEAllowlist{
+		ProjectID: 1,
+		Items: []CVEAllowlistItem{
+			{CVEID: "CVE-2021-44228"},
+			{CVEID: "CVE-2021-45046", Repository: "library/photon"},
+			{CVEID: "CVE-2022-22965", Repository: "library/**", ExpiresAt: &future},
+			{CVEID: "CVE-2020-1472", ExpiresAt: &past},
+		},
+	}
+
+	assert.Equal(t, CVESet{"CVE-2021-44228": {}, "CVE-2021-45046": {}, "CVE-2022-22965": {}}, l.CVESetFor("library/photon"))
+	assert.Equal(t, CVESet{"CVE-2021-44228": {}, "CVE-2022-22965": {}}, l.CVESetFor("library/nginx"))
+	assert.Equal(t, CVESet{"CVE-2021-44228": {}}, l.CVESetFor("other/photon"))
+
+	// the whole allowlist is expired
+	l.ExpiresAt = &past
+	assert.Equal(t, CVESet{}, l.CVESetFor("library/photon"))
+}
@@ -17,6 +17,8 @@ package allowlist
 import (
 	"fmt"
 
+	"github.com/bmatcuk/doublestar"
+
 	models2 "github.com/goharbor/harbor/src/pkg/allowlist/models"
 )
 
@@ -43,7 +45,8 @@ func IsInvalidErr(err error) bool {
 
 const cveIDPattern = `^CVE-\d{4}-\d+$`
 
-// Validate help validates the CVE allowlist, to ensure the CVE ID is valid and there's no duplication
+// Validate help validates the CVE allowlist, to ensure the CVE ID is valid, the repository pattern is valid
+// and there's no duplication, the same CVE ID can be in the allowlist more than once with different repositories
 func Validate(wl models2.CVEAllowlist) error {
 	m := map[string]struct{}{}
 	//	re := regexp.MustCompile(cveIDPattern)
@@ -52,10 +55,20 @@ func Validate(wl models2.CVEAllowlist) error {
 		//		if !re.MatchString(it.CVEID) {
 		//			return &invalidErr{fmt.Sprintf("invalid CVE ID: %s", it.CVEID)}
 		//		}
-		if _, ok := m[it.CVEID]; ok {
+		if len(it.Repository) > 0 {
+			// match the pattern with itself to check the syntax of the whole pattern
+			if _, err := doublestar.Match(it.Repository, it.Repository); err != nil {
+				return &invalidErr{fmt.Sprintf("invalid repository pattern for %s: %s", it.CVEID, it.Repository)}
+			}
+		}
+		key := it.CVEID + "@" + it.Repository
+		if _, ok := m[key]; ok {
+			if len(it.Repository) > 0 {
+				return &invalidErr{fmt.Sprintf("duplicate CVE ID in allowlist: %s for repository %s", it.CVEID, it.Repository)}
+			}
 			return &invalidErr{fmt.Sprintf("duplicate CVE ID in allowlist: %s", it.CVEID)}
 		}
-		m[it.CVEID] = struct{}{}
+		m[key] = struct{}{}
 	}
 	return nil
 }
@@ -92,6 +92,33 @@ func TestValidate(t *testing.T) {
 			},
 			noError: false,
 		},
+		{
+			l: models2.CVEAllowlist{
+				Items: []models2.CVEAllowlistItem{
+					{CVEID: "CVE-2014-456132"},
+					{CVEID: "CVE-2014-456132", Repository: "library/photon"},
+					{CVEID: "CVE-2014-456132", Repository: "library/**"},
+				},
+			},
+			noError: true,
+		},
+		{
+			l: models2.CVEAllowlist{
+				Items: []models2.CVEAllowlistItem{
+					{CVEID: "CVE-2014-456132", Repository: "library/photon"},
+					{CVEID: "CVE-2014-456132", Repository: "library/photon"},
+				},
+			},
+			noError: false,
+		},
+		{
+			l: models2.CVEAllowlist{
+				Items: []models2.CVEAllowlistItem{
+					{CVEID: "CVE-2014-456132", Repository: "library/{photon"},
+				},
+			},
+			noError: false,
+		},
 	}
 	for n, c := range cases {
 		t.Logf("Executing TestValidate case: %d\n", n)
@@ -30,7 +30,7 @@ type DAO interface {
 	// Count returns the total count of artifacts according to the query
 	Count(ctx context.Context, query *q.Query) (total int64, err error)
 	// List artifacts according to the query. The artifacts that referenced by others and
-	// without tags are not returned
+	// without tags are not returned, neither are the accessories
 	List(ctx context.Context, query *q.Query) (artifacts []*Artifact, err error)
 	// Get the artifact specified by ID
 	Get(ctx context.Context, id int64) (*Artifact, error)
@@ -54,12 +54,12 @@ type DAO interface {
 
 const (
 	// the QuerySetter of beego doesn't support "EXISTS" directly, use qs.FilterRaw("id", "=id AND xxx") to workaround the limitation
-	// base filter: both tagged and untagged artifacts
+	// base filter: both tagged and untagged artifacts, the accessories are excluded
 	both = `=id AND (
 		EXISTS (SELECT 1 FROM tag WHERE tag.artifact_id = T0.id)
 		OR 
 		NOT EXISTS (SELECT 1 FROM artifact_reference ref WHERE ref.child_id = T0.id)
-	)`
+	) AND NOT EXISTS (SELECT 1 FROM artifact_accessory acc WHERE acc.artifact_id = T0.id)`
 	// tag filter: only untagged artifacts
 	// the "untagged" filter is based on "base" filter, so we consider the tag only
 	untagged = `=id AND NOT EXISTS(
@@ -274,7 +274,7 @@ func querySetter(ctx context.Context, query *q.Query) (beegoorm.QuerySeter, erro
 // handle q=base=*
 // when "q=base=*" is specified in the query, the base collection is the all artifacts of database,
 // otherwise the base collection is only the tagged artifacts and untagged artifacts that aren't
-// referenced by others
+// referenced by others, and the accessories(cosign signatures, etc.) are excluded
 func setBaseQuery(qs beegoorm.QuerySeter, query *q.Query) (beegoorm.QuerySeter, error) {
 	if query == nil || len(query.Keywords) == 0 {
 		qs = qs.FilterRaw("id", both)
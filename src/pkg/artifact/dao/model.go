@@ -32,6 +32,8 @@ type Artifact struct {
 	Type              string    `orm:"column(type)"`                // image or chart
 	MediaType         string    `orm:"column(media_type)"`          // the media type of artifact
 	ManifestMediaType string    `orm:"column(manifest_media_type)"` // the media type of manifest/index
+	ArtifactType      string    `orm:"column(artifact_type)"`       // the OCI artifact type of manifest/index
+	SubjectDigest     string    `orm:"column(subject_digest)"`      // the digest of the subject declared in manifest/index
 	ProjectID         int64     `orm:"column(project_id)"`          // needed for quota
 	RepositoryID      int64     `orm:"column(repository_id)"`
 	RepositoryName    string    `orm:"column(repository_name)"`
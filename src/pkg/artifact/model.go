@@ -33,6 +33,8 @@ type Artifact struct {
 	Type              string                 `json:"type"`                // image, chart, etc
 	MediaType         string                 `json:"media_type"`          // the media type of artifact. Mostly, it's the value of `manifest.config.mediatype`
 	ManifestMediaType string                 `json:"manifest_media_type"` // the media type of manifest/index
+	ArtifactType      string                 `json:"artifact_type"`       // the OCI artifact type of manifest/index
+	SubjectDigest     string                 `json:"-"`                   // the digest of the subject declared in manifest/index
 	ProjectID         int64                  `json:"project_id"`
 	RepositoryID      int64                  `json:"repository_id"`
 	RepositoryName    string                 `json:"repository_name"`
@@ -44,6 +46,7 @@ type Artifact struct {
 	ExtraAttrs        map[string]interface{} `json:"extra_attrs"` // only contains the simple attributes specific for the different artifact type, most of them should come from the config layer
 	Annotations       map[string]string      `json:"annotations"`
 	References        []*Reference           `json:"references"` // child artifacts referenced by the parent artifact if the artifact is an index
+	Subject           *v1.Descriptor         `json:"-"`          // the subject declared in the manifest/index, only populated when abstracting
 }
 
 func (a *Artifact) String() string {
@@ -62,6 +65,8 @@ func (a *Artifact) From(art *dao.Artifact) {
 	a.Type = art.Type
 	a.MediaType = art.MediaType
 	a.ManifestMediaType = art.ManifestMediaType
+	a.ArtifactType = art.ArtifactType
+	a.SubjectDigest = art.SubjectDigest
 	a.ProjectID = art.ProjectID
 	a.RepositoryID = art.RepositoryID
 	a.RepositoryName = art.RepositoryName
@@ -91,6 +96,8 @@ func (a *Artifact) To() *dao.Artifact {
 		Type:              a.Type,
 		MediaType:         a.MediaType,
 		ManifestMediaType: a.ManifestMediaType,
+		ArtifactType:      a.ArtifactType,
+		SubjectDigest:     a.SubjectDigest,
 		ProjectID:         a.ProjectID,
 		RepositoryID:      a.RepositoryID,
 		RepositoryName:    a.RepositoryName,
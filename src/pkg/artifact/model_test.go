@@ -36,6 +36,8 @@ func (m *modelTestSuite) TestArtifactFrom() {
 		Type:              "IMAGE",
 		MediaType:         "application/vnd.oci.image.config.v1+json",
 		ManifestMediaType: "application/vnd.oci.image.manifest.v1+json",
+		ArtifactType:      "application/vnd.example.sbom",
+		SubjectDigest:     "sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f",
 		ProjectID:         1,
 		RepositoryID:      1,
 		Digest:            "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180",
@@ -51,6 +53,8 @@ func (m *modelTestSuite) TestArtifactFrom() {
 	assert.Equal(t, dbArt.Type, art.Type)
 	assert.Equal(t, dbArt.MediaType, art.MediaType)
 	assert.Equal(t, dbArt.ManifestMediaType, art.ManifestMediaType)
+	assert.Equal(t, dbArt.ArtifactType, art.ArtifactType)
+	assert.Equal(t, dbArt.SubjectDigest, art.SubjectDigest)
 	assert.Equal(t, dbArt.ProjectID, art.ProjectID)
 	assert.Equal(t, dbArt.RepositoryID, art.RepositoryID)
 	assert.Equal(t, dbArt.Digest, art.Digest)
@@ -70,6 +74,8 @@ func (m *modelTestSuite) TestArtifactTo() {
 		RepositoryID:      1,
 		MediaType:         "application/vnd.oci.image.config.v1+json",
 		ManifestMediaType: "application/vnd.oci.image.manifest.v1+json",
+		ArtifactType:      "application/vnd.example.sbom",
+		SubjectDigest:     "sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f",
 		Digest:            "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180",
 		Size:              1024,
 		PushTime:          time.Now(),
@@ -86,6 +92,8 @@ func (m *modelTestSuite) TestArtifactTo() {
 	assert.Equal(t, art.Type, dbArt.Type)
 	assert.Equal(t, art.MediaType, dbArt.MediaType)
 	assert.Equal(t, art.ManifestMediaType, dbArt.ManifestMediaType)
+	assert.Equal(t, art.ArtifactType, dbArt.ArtifactType)
+	assert.Equal(t, art.SubjectDigest, dbArt.SubjectDigest)
 	assert.Equal(t, art.ProjectID, dbArt.ProjectID)
 	assert.Equal(t, art.RepositoryID, dbArt.RepositoryID)
 	assert.Equal(t, art.Digest, dbArt.Digest)
@@ -16,6 +16,9 @@ package dao
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
@@ -34,6 +37,10 @@ type DAO interface {
 	Get(ctx context.Context, id int64) (access *model.AuditLog, err error)
 	// Delete the audit log specified by ID
 	Delete(ctx context.Context, id int64) (err error)
+	// Purge the audit logs older than the retention hours, only the audit logs of the included operations
+	// are purged if any is specified, the ones of the excluded operations are kept,
+	// returns the count of the purged audit logs, nothing is deleted in dry run mode
+	Purge(ctx context.Context, retentionHour int, includeOperations, excludeOperations []string, dryRun bool) (total int64, err error)
 }
 
 // New returns an instance of the default DAO
@@ -118,3 +125,42 @@ func (d *dao) Delete(ctx context.Context, id int64) error {
 	}
 	return nil
 }
+
+// Purge ...
+func (d *dao) Purge(ctx context.Context, retentionHour int, includeOperations, excludeOperations []string, dryRun bool) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where := "op_time < NOW() - ? * interval '1 hour'"
+	params := []interface{}{retentionHour}
+	if len(includeOperations) > 0 {
+		var ops []string
+		for _, op := range includeOperations {
+			ops = append(ops, "?")
+			params = append(params, strings.ToLower(op))
+		}
+		where = fmt.Sprintf("%s AND lower(operation) IN (%s)", where, strings.Join(ops, ","))
+	}
+	if len(excludeOperations) > 0 {
+		var ops []string
+		for _, op := range excludeOperations {
+			ops = append(ops, "?")
+			params = append(params, strings.ToLower(op))
+		}
+		where = fmt.Sprintf("%s AND lower(operation) NOT IN (%s)", where, strings.Join(ops, ","))
+	}
+
+	if dryRun {
+		var total int64
+		if err := ormer.Raw(fmt.Sprintf("SELECT count(1) FROM audit_log WHERE %s", where), params...).QueryRow(&total); err != nil {
+			return 0, err
+		}
+		return total, nil
+	}
+	result, err := ormer.Raw(fmt.Sprintf("DELETE FROM audit_log WHERE %s", where), params...).Exec()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/goharbor/harbor/src/pkg/audit/model"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 type daoTestSuite struct {
@@ -157,6 +158,46 @@ func (d *daoTestSuite) TestDelete() {
 	d.Equal(errors.NotFoundCode, e.Code)
 }
 
+func (d *daoTestSuite) TestPurge() {
+	id1, err := d.dao.Create(d.ctx, &model.AuditLog{
+		Operation:    "pull",
+		ResourceType: "artifact",
+		Resource:     "library/purge-audit",
+		Username:     "admin",
+		OpTime:       time.Now().Add(-48 * time.Hour),
+	})
+	d.Require().Nil(err)
+	id2, err := d.dao.Create(d.ctx, &model.AuditLog{
+		Operation:    "create",
+		ResourceType: "artifact",
+		Resource:     "library/purge-audit",
+		Username:     "admin",
+		OpTime:       time.Now().Add(-48 * time.Hour),
+	})
+	d.Require().Nil(err)
+	defer d.dao.Delete(d.ctx, id2)
+
+	// dry run deletes nothing
+	total, err := d.dao.Purge(d.ctx, 24, []string{"Pull"}, nil, true)
+	d.Require().Nil(err)
+	d.Equal(int64(1), total)
+	_, err = d.dao.Get(d.ctx, id1)
+	d.Require().Nil(err)
+
+	// the excluded operations are kept
+	total, err = d.dao.Purge(d.ctx, 24, []string{"pull", "create"}, []string{"Create"}, true)
+	d.Require().Nil(err)
+	d.Equal(int64(1), total)
+
+	total, err = d.dao.Purge(d.ctx, 24, []string{"pull"}, nil, false)
+	d.Require().Nil(err)
+	d.Equal(int64(1), total)
+	_, err = d.dao.Get(d.ctx, id1)
+	d.True(errors.IsErr(err, errors.NotFoundCode))
+	_, err = d.dao.Get(d.ctx, id2)
+	d.Require().Nil(err)
+}
+
 func TestDaoTestSuite(t *testing.T) {
 	suite.Run(t, &daoTestSuite{})
 }
@@ -0,0 +1,128 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/audit/dao"
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+)
+
+const (
+	// ExportFormatJSONL exports the audit logs as JSON Lines, one JSON object per line
+	ExportFormatJSONL = "jsonl"
+	// ExportFormatCSV exports the audit logs as CSV with a header line
+	ExportFormatCSV = "csv"
+
+	// the audit logs are read from database page by page to avoid loading all of them into memory
+	exportPageSize = 1000
+)
+
+var csvHeader = []string{"id", "op_time", "username", "operation", "resource_type", "resource", "project_id",
+	"success", "auth_method", "client_ip", "user_agent", "request_id", "reason"}
+
+// auditLogWriter writes the audit logs in the specific format
+type auditLogWriter interface {
+	Write(log *model.AuditLog) error
+	Flush() error
+}
+
+func export(ctx context.Context, d dao.DAO, query *q.Query, format string, w io.Writer) error {
+	var writer auditLogWriter
+	switch format {
+	case ExportFormatJSONL:
+		writer = &jsonlWriter{encoder: json.NewEncoder(w)}
+	case ExportFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return err
+		}
+		writer = &csvWriter{writer: cw}
+	default:
+		return errors.BadRequestError(nil).WithMessage("unsupported export format: %s", format)
+	}
+
+	query = q.MustClone(query)
+	query.PageSize = exportPageSize
+	for query.PageNumber = 1; ; query.PageNumber++ {
+		logs, err := d.List(ctx, query)
+		if err != nil {
+			return err
+		}
+		for _, log := range logs {
+			if err := writer.Write(log); err != nil {
+				return err
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		// send out the exported audit logs as soon as possible when streaming them in the HTTP response
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		if len(logs) < exportPageSize {
+			return nil
+		}
+	}
+}
+
+type jsonlWriter struct {
+	encoder *json.Encoder
+}
+
+func (j *jsonlWriter) Write(log *model.AuditLog) error {
+	return j.encoder.Encode(log)
+}
+
+func (j *jsonlWriter) Flush() error {
+	return nil
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func (c *csvWriter) Write(log *model.AuditLog) error {
+	return c.writer.Write([]string{
+		strconv.FormatInt(log.ID, 10),
+		log.OpTime.UTC().Format(time.RFC3339),
+		log.Username,
+		log.Operation,
+		log.ResourceType,
+		log.Resource,
+		strconv.FormatInt(log.ProjectID, 10),
+		strconv.FormatBool(log.Success),
+		log.AuthMethod,
+		log.ClientIP,
+		log.UserAgent,
+		log.RequestID,
+		log.Reason,
+	})
+}
+
+func (c *csvWriter) Flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
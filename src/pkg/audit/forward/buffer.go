@@ -0,0 +1,166 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+const bufferFileSuffix = ".json"
+
+// bufferRecord is the index entry of the buffered record
+type bufferRecord struct {
+	name string
+	size int64
+}
+
+// buffer is a bounded FIFO queue on disk, every record is stored as a single file whose name is
+// ordered by the time when it's pushed, the oldest records are dropped when the size exceeds the limit.
+// The records are indexed in memory in the pushing order, the directory is only listed when loading the buffer
+type buffer struct {
+	dir     string
+	maxSize int64
+	size    int64
+	seq     int64
+	records []*bufferRecord
+	lock    sync.Mutex
+}
+
+func newBuffer(dir string, maxSize int64) (*buffer, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	b := &buffer{
+		dir:     dir,
+		maxSize: maxSize,
+	}
+	// the records buffered before the restarting are kept
+	files, err := b.files()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		b.records = append(b.records, &bufferRecord{name: f.Name(), size: f.Size()})
+		b.size += f.Size()
+	}
+	return b, nil
+}
+
+// push appends the record to the end of the queue, the record larger than the size limit is rejected
+func (b *buffer) push(data []byte) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.maxSize > 0 && int64(len(data)) > b.maxSize {
+		return fmt.Errorf("the size of the audit log %d exceeds the size limit %d of the buffer", len(data), b.maxSize)
+	}
+
+	b.seq++
+	name := fmt.Sprintf("%020d-%010d%s", time.Now().UnixNano(), b.seq, bufferFileSuffix)
+	// write into a temporary file first to avoid reading the partial record
+	tmp := filepath.Join(b.dir, "."+name)
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(b.dir, name)); err != nil {
+		return err
+	}
+	b.records = append(b.records, &bufferRecord{name: name, size: int64(len(data))})
+	b.size += int64(len(data))
+
+	for b.maxSize > 0 && b.size > b.maxSize && len(b.records) > 0 {
+		record := b.records[0]
+		if err := os.Remove(filepath.Join(b.dir, record.name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		b.records = b.records[1:]
+		b.size -= record.size
+		log.Warningf("the audit log buffer exceeds the size limit %d, the record %s is dropped", b.maxSize, record.name)
+	}
+	return nil
+}
+
+// peek returns the record at the head of the queue, the name is empty if the queue is empty
+func (b *buffer) peek() (string, []byte, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for len(b.records) > 0 {
+		record := b.records[0]
+		data, err := ioutil.ReadFile(filepath.Join(b.dir, record.name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				// removed from the disk by others
+				b.records = b.records[1:]
+				b.size -= record.size
+				continue
+			}
+			return "", nil, err
+		}
+		return record.name, data, nil
+	}
+	return "", nil, nil
+}
+
+// remove the record from the queue
+func (b *buffer) remove(name string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	// the record removed is the head of the queue in most cases
+	for i, record := range b.records {
+		if record.name != name {
+			continue
+		}
+		if err := os.Remove(filepath.Join(b.dir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if i == 0 {
+			b.records = b.records[1:]
+		} else {
+			b.records = append(b.records[:i], b.records[i+1:]...)
+		}
+		b.size -= record.size
+		return nil
+	}
+	// dropped already as the size exceeded the limit
+	return nil
+}
+
+// files returns the buffered records ordered from the oldest to the newest
+func (b *buffer) files() ([]os.FileInfo, error) {
+	infos, err := ioutil.ReadDir(b.dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []os.FileInfo
+	for _, info := range infos {
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") || !strings.HasSuffix(info.Name(), bufferFileSuffix) {
+			continue
+		}
+		files = append(files, info)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name() < files[j].Name() })
+	return files, nil
+}
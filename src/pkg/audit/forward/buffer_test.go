@@ -0,0 +1,120 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type bufferTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (b *bufferTestSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "audit-buffer")
+	b.Require().Nil(err)
+	b.dir = dir
+}
+
+func (b *bufferTestSuite) TearDownTest() {
+	os.RemoveAll(b.dir)
+}
+
+func (b *bufferTestSuite) TestPushPeekRemove() {
+	buf, err := newBuffer(b.dir, 0)
+	b.Require().Nil(err)
+
+	name, _, err := buf.peek()
+	b.Require().Nil(err)
+	b.Empty(name)
+
+	b.Require().Nil(buf.push([]byte("1")))
+	b.Require().Nil(buf.push([]byte("22")))
+	b.Equal(int64(3), buf.size)
+
+	name, data, err := buf.peek()
+	b.Require().Nil(err)
+	b.Equal("1", string(data))
+	b.Require().Nil(buf.remove(name))
+	b.Equal(int64(2), buf.size)
+
+	// the buffered records are kept after restarting
+	buf, err = newBuffer(b.dir, 0)
+	b.Require().Nil(err)
+	b.Equal(int64(2), buf.size)
+	name, data, err = buf.peek()
+	b.Require().Nil(err)
+	b.Equal("22", string(data))
+	b.Require().Nil(buf.remove(name))
+	// removing the dropped record is ignored
+	b.Require().Nil(buf.remove(name))
+}
+
+func (b *bufferTestSuite) TestDropOldest() {
+	buf, err := newBuffer(b.dir, 5)
+	b.Require().Nil(err)
+
+	b.Require().Nil(buf.push([]byte("111")))
+	b.Require().Nil(buf.push([]byte("222")))
+	b.Equal(int64(3), buf.size)
+
+	_, data, err := buf.peek()
+	b.Require().Nil(err)
+	b.Equal("222", string(data))
+
+	// the record larger than the limit is rejected and the buffered ones are kept
+	b.NotNil(buf.push([]byte("333333")))
+	b.Equal(int64(3), buf.size)
+	_, data, err = buf.peek()
+	b.Require().Nil(err)
+	b.Equal("222", string(data))
+}
+
+func (b *bufferTestSuite) TestIndex() {
+	buf, err := newBuffer(b.dir, 0)
+	b.Require().Nil(err)
+
+	b.Require().Nil(buf.push([]byte("1")))
+	b.Require().Nil(buf.push([]byte("22")))
+	b.Require().Nil(buf.push([]byte("333")))
+	b.Len(buf.records, 3)
+
+	// remove the record in the middle of the queue
+	b.Require().Nil(buf.remove(buf.records[1].name))
+	b.Equal(int64(4), buf.size)
+
+	// the record removed from the disk by others is skipped
+	b.Require().Nil(os.Remove(filepath.Join(b.dir, buf.records[0].name)))
+	name, data, err := buf.peek()
+	b.Require().Nil(err)
+	b.Equal("333", string(data))
+	b.Equal(int64(3), buf.size)
+	b.Require().Nil(buf.remove(name))
+
+	name, _, err = buf.peek()
+	b.Require().Nil(err)
+	b.Empty(name)
+	b.Equal(int64(0), buf.size)
+}
+
+func TestBufferTestSuite(t *testing.T) {
+	suite.Run(t, &bufferTestSuite{})
+}
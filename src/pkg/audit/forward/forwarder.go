@@ -0,0 +1,182 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+)
+
+const (
+	minRetryInterval = time.Second
+	maxRetryInterval = time.Minute
+	// check the buffer periodically in case the notification is missed
+	checkInterval = time.Minute
+)
+
+var (
+	// Fwd is the global audit log forwarder instance
+	Fwd = New()
+
+	errForwardingDisabled = errors.New("the audit log forwarding is disabled")
+)
+
+// Forwarder forwards the audit logs to the external log system
+type Forwarder interface {
+	// Forward puts the audit log into the buffer and it will be delivered asynchronously
+	Forward(ctx context.Context, log *model.AuditLog) error
+}
+
+// New returns a default implementation of Forwarder which buffers the audit logs on disk
+// and delivers them to the endpoint configured in the system configurations
+func New() Forwarder {
+	return &forwarder{
+		newSink:  NewSink,
+		settings: settings,
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// settings returns the endpoint and whether to skip the certificate verification
+func settings() (string, bool) {
+	ctx := orm.Context()
+	return config.AuditLogForwardEndpoint(ctx), config.AuditLogForwardInsecure(ctx)
+}
+
+type forwarder struct {
+	once     sync.Once
+	initErr  error
+	buffer   *buffer
+	notify   chan struct{}
+	newSink  func(endpoint string, insecure bool) (Sink, error)
+	settings func() (string, bool)
+	// the sink is only accessed by the delivering goroutine
+	sink     Sink
+	endpoint string
+	insecure bool
+}
+
+func (f *forwarder) Forward(ctx context.Context, l *model.AuditLog) error {
+	f.once.Do(func() {
+		f.buffer, f.initErr = newBuffer(config.AuditLogForwardBufferDir(), config.AuditLogForwardBufferSize())
+		if f.initErr == nil {
+			go f.deliver()
+		}
+	})
+	if f.initErr != nil {
+		return f.initErr
+	}
+
+	data, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	if err = f.buffer.push(data); err != nil {
+		return err
+	}
+	select {
+	case f.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// deliver sends the buffered audit logs one by one in order, the failed one is retried with backoff
+func (f *forwarder) deliver() {
+	interval := minRetryInterval
+	backoff := func() {
+		time.Sleep(interval)
+		if interval *= 2; interval > maxRetryInterval {
+			interval = maxRetryInterval
+		}
+	}
+	for {
+		name, data, err := f.buffer.peek()
+		if err != nil {
+			log.Errorf("failed to read the audit log buffer: %v", err)
+		}
+		if err != nil || len(name) == 0 {
+			f.wait(checkInterval)
+			continue
+		}
+
+		if err = f.send(data); err != nil {
+			if err == errForwardingDisabled {
+				f.wait(checkInterval)
+				continue
+			}
+			if isPermanent(err) {
+				// the rejected audit log can never be delivered, drop it to unblock the following ones
+				log.Errorf("the audit log is rejected and dropped: %v, audit log: %s", err, string(data))
+				if err = f.buffer.remove(name); err != nil {
+					log.Errorf("failed to remove the rejected audit log %s from buffer, will retry in %s: %v", name, interval, err)
+					backoff()
+				}
+				continue
+			}
+			log.Warningf("failed to forward the audit log, will retry in %s: %v", interval, err)
+			backoff()
+			continue
+		}
+		if err = f.buffer.remove(name); err != nil {
+			// the audit log may be sent again, back off to avoid flooding the endpoint
+			log.Errorf("failed to remove the forwarded audit log %s from buffer, will retry in %s: %v", name, interval, err)
+			backoff()
+			continue
+		}
+		interval = minRetryInterval
+	}
+}
+
+func (f *forwarder) wait(d time.Duration) {
+	select {
+	case <-f.notify:
+	case <-time.After(d):
+	}
+}
+
+func (f *forwarder) send(data []byte) error {
+	endpoint, insecure := f.settings()
+	if len(endpoint) == 0 {
+		// keep the buffered audit logs until the forwarding is enabled again
+		return errForwardingDisabled
+	}
+	if f.sink == nil || endpoint != f.endpoint || insecure != f.insecure {
+		if f.sink != nil {
+			f.sink.Close()
+		}
+		sink, err := f.newSink(endpoint, insecure)
+		if err != nil {
+			return err
+		}
+		f.sink, f.endpoint, f.insecure = sink, endpoint, insecure
+	}
+
+	l := &model.AuditLog{}
+	if err := json.Unmarshal(data, l); err != nil {
+		// the corrupted record can never be delivered, drop it
+		log.Errorf("failed to unmarshal the buffered audit log, dropped: %v", err)
+		return nil
+	}
+	return f.sink.Send(l)
+}
@@ -0,0 +1,131 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeSink struct {
+	sync.Mutex
+	failures int
+	rejected map[int64]bool
+	logs     []*model.AuditLog
+}
+
+func (f *fakeSink) Send(log *model.AuditLog) error {
+	f.Lock()
+	defer f.Unlock()
+	if f.rejected[log.ID] {
+		return &permanentError{errors.New("bad request")}
+	}
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("connection refused")
+	}
+	f.logs = append(f.logs, log)
+	return nil
+}
+
+func (f *fakeSink) Close() error {
+	return nil
+}
+
+func (f *fakeSink) received() []*model.AuditLog {
+	f.Lock()
+	defer f.Unlock()
+	return f.logs
+}
+
+type forwarderTestSuite struct {
+	suite.Suite
+	dir       string
+	sink      *fakeSink
+	forwarder *forwarder
+}
+
+func (f *forwarderTestSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "audit-forward")
+	f.Require().Nil(err)
+	f.dir = dir
+	buf, err := newBuffer(dir, 0)
+	f.Require().Nil(err)
+
+	f.sink = &fakeSink{}
+	f.forwarder = &forwarder{
+		buffer: buf,
+		notify: make(chan struct{}, 1),
+		newSink: func(endpoint string, insecure bool) (Sink, error) {
+			return f.sink, nil
+		},
+		settings: func() (string, bool) {
+			return "udp://127.0.0.1:514", false
+		},
+	}
+	// skip the initialization from the system configurations
+	f.forwarder.once.Do(func() {})
+}
+
+func (f *forwarderTestSuite) TearDownTest() {
+	os.RemoveAll(f.dir)
+}
+
+func (f *forwarderTestSuite) TestForward() {
+	// the first delivery fails and is retried
+	f.sink.failures = 1
+	go f.forwarder.deliver()
+
+	f.Require().Nil(f.forwarder.Forward(nil, &model.AuditLog{ID: 1, Resource: "library"}))
+	f.Require().Nil(f.forwarder.Forward(nil, &model.AuditLog{ID: 2, Resource: "library/hello-world"}))
+
+	f.Eventually(func() bool { return len(f.sink.received()) == 2 }, 10*time.Second, 100*time.Millisecond)
+	logs := f.sink.received()
+	f.Equal(int64(1), logs[0].ID)
+	f.Equal(int64(2), logs[1].ID)
+
+	f.Eventually(func() bool {
+		name, _, err := f.forwarder.buffer.peek()
+		return err == nil && len(name) == 0
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func (f *forwarderTestSuite) TestForwardRejected() {
+	// the rejected audit log is dropped instead of blocking the following ones
+	f.sink.rejected = map[int64]bool{1: true}
+	go f.forwarder.deliver()
+
+	f.Require().Nil(f.forwarder.Forward(nil, &model.AuditLog{ID: 1, Resource: "library"}))
+	f.Require().Nil(f.forwarder.Forward(nil, &model.AuditLog{ID: 2, Resource: "library/hello-world"}))
+
+	f.Eventually(func() bool { return len(f.sink.received()) == 1 }, 10*time.Second, 100*time.Millisecond)
+	f.Equal(int64(2), f.sink.received()[0].ID)
+
+	f.Eventually(func() bool {
+		name, _, err := f.forwarder.buffer.peek()
+		return err == nil && len(name) == 0
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestForwarderTestSuite(t *testing.T) {
+	suite.Run(t, &forwarderTestSuite{})
+}
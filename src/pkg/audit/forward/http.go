@@ -0,0 +1,88 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+)
+
+// httpSink posts the audit logs in JSON to the HTTP endpoint
+type httpSink struct {
+	endpoint string
+	client   *http.Client
+}
+
+func newHTTPSink(endpoint string, insecure bool) *httpSink {
+	return &httpSink{
+		endpoint: endpoint,
+		client: &http.Client{
+			Transport: commonhttp.GetHTTPTransport(commonhttp.WithInsecure(insecure)),
+			Timeout:   30 * time.Second,
+		},
+	}
+}
+
+func (h *httpSink) Send(log *model.AuditLog) error {
+	data, err := json.Marshal(log)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, h.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		err = fmt.Errorf("failed to post the audit log to %s, status code: %d, body: %s", h.endpoint, resp.StatusCode, string(body))
+		if rejected(resp.StatusCode) {
+			return &permanentError{err}
+		}
+		return err
+	}
+	return nil
+}
+
+// rejected checks whether the status code means the audit log itself is rejected by the endpoint. The other
+// client errors, e.g. 401, 404 and 429, are caused by the endpoint or the configuration and are retried
+func rejected(code int) bool {
+	switch code {
+	case http.StatusBadRequest,
+		http.StatusRequestEntityTooLarge,
+		http.StatusUnsupportedMediaType,
+		http.StatusUnprocessableEntity:
+		return true
+	default:
+		return false
+	}
+}
+
+func (h *httpSink) Close() error {
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"net/url"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+)
+
+const (
+	schemeUDP   = "udp"
+	schemeTCP   = "tcp"
+	schemeTLS   = "tls"
+	schemeHTTP  = "http"
+	schemeHTTPS = "https"
+)
+
+// Sink delivers the audit logs to the external log system
+type Sink interface {
+	// Send the audit log to the external log system
+	Send(log *model.AuditLog) error
+	// Close releases the resources held by the sink
+	Close() error
+}
+
+// permanentError is the error of the audit log rejected by the external log system, retrying doesn't help
+type permanentError struct {
+	error
+}
+
+// isPermanent checks whether the error is permanent, the audit log of which should be dropped
+func isPermanent(err error) bool {
+	_, ok := err.(*permanentError)
+	return ok
+}
+
+// NewSink creates the sink according to the scheme of the endpoint:
+// "udp", "tcp" and "tls" for the RFC5424 syslog server, "http" and "https" for the HTTP endpoint accepting JSON
+func NewSink(endpoint string, insecure bool) (Sink, error) {
+	u, err := parseEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case schemeHTTP, schemeHTTPS:
+		return newHTTPSink(endpoint, insecure), nil
+	default:
+		return newSyslogSink(u.Scheme, u.Host, insecure), nil
+	}
+}
+
+// ValidateEndpoint checks whether the endpoint is supported
+func ValidateEndpoint(endpoint string) error {
+	_, err := parseEndpoint(endpoint)
+	return err
+}
+
+func parseEndpoint(endpoint string) (*url.URL, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.BadRequestError(err).WithMessage("invalid audit log forward endpoint: %s", endpoint)
+	}
+	switch u.Scheme {
+	case schemeUDP, schemeTCP, schemeTLS:
+		if len(u.Port()) == 0 {
+			return nil, errors.BadRequestError(nil).WithMessage("the port of the syslog server is required: %s", endpoint)
+		}
+	case schemeHTTP, schemeHTTPS:
+		if len(u.Host) == 0 {
+			return nil, errors.BadRequestError(nil).WithMessage("the host of the HTTP endpoint is required: %s", endpoint)
+		}
+	default:
+		return nil, errors.BadRequestError(nil).WithMessage("unsupported scheme of the audit log forward endpoint: %s", endpoint)
+	}
+	return u, nil
+}
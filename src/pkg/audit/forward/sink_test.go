@@ -0,0 +1,156 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type sinkTestSuite struct {
+	suite.Suite
+	log *model.AuditLog
+}
+
+func (s *sinkTestSuite) SetupSuite() {
+	s.log = &model.AuditLog{
+		ID:           1,
+		Operation:    "delete",
+		ResourceType: "repository",
+		Resource:     "library/hello-world",
+		Username:     "admin",
+		Success:      true,
+		OpTime:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (s *sinkTestSuite) TestValidateEndpoint() {
+	s.Nil(ValidateEndpoint("udp://127.0.0.1:514"))
+	s.Nil(ValidateEndpoint("tls://syslog.example.com:6514"))
+	s.Nil(ValidateEndpoint("https://siem.example.com/audit"))
+	s.NotNil(ValidateEndpoint("tcp://127.0.0.1"))
+	s.NotNil(ValidateEndpoint("ftp://127.0.0.1:21"))
+	s.NotNil(ValidateEndpoint("https:///audit"))
+}
+
+func (s *sinkTestSuite) TestSyslogTCP() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().Nil(err)
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		length, err := reader.ReadString(' ')
+		if err != nil {
+			return
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(length))
+		msg := make([]byte, n)
+		if _, err = reader.Read(msg); err != nil {
+			return
+		}
+		received <- string(msg)
+	}()
+
+	sink, err := NewSink("tcp://"+listener.Addr().String(), false)
+	s.Require().Nil(err)
+	defer sink.Close()
+	s.Require().Nil(sink.Send(s.log))
+
+	select {
+	case msg := <-received:
+		s.True(strings.HasPrefix(msg, "<110>1 2021-01-01T00:00:00.000000Z "))
+		s.Contains(msg, " core ")
+		s.Contains(msg, ` audit - {"id":1,`)
+	case <-time.After(5 * time.Second):
+		s.Fail("the syslog message isn't received")
+	}
+}
+
+func (s *sinkTestSuite) TestSyslogUDP() {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	s.Require().Nil(err)
+	defer conn.Close()
+
+	sink, err := NewSink("udp://"+conn.LocalAddr().String(), false)
+	s.Require().Nil(err)
+	defer sink.Close()
+	s.Require().Nil(sink.Send(s.log))
+
+	buf := make([]byte, 4096)
+	s.Require().Nil(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	s.Require().Nil(err)
+	s.True(strings.HasPrefix(string(buf[:n]), "<110>1 "))
+}
+
+func (s *sinkTestSuite) TestHTTP() {
+	var received *model.AuditLog
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/audit" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("reject") == "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = &model.AuditLog{}
+		if err := json.NewDecoder(r.Body).Decode(received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	sink, err := NewSink(server.URL+"/audit", false)
+	s.Require().Nil(err)
+	s.Require().Nil(sink.Send(s.log))
+	s.Require().NotNil(received)
+	s.Equal("library/hello-world", received.Resource)
+
+	// the endpoint not found is retried
+	sink, err = NewSink(server.URL+"/not-found", false)
+	s.Require().Nil(err)
+	err = sink.Send(s.log)
+	s.Require().NotNil(err)
+	s.False(isPermanent(err))
+
+	// the rejected audit log isn't retried
+	sink, err = NewSink(server.URL+"/audit?reject=true", false)
+	s.Require().Nil(err)
+	err = sink.Send(s.log)
+	s.Require().NotNil(err)
+	s.True(isPermanent(err))
+}
+
+func TestSinkTestSuite(t *testing.T) {
+	suite.Run(t, &sinkTestSuite{})
+}
@@ -0,0 +1,120 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+)
+
+const (
+	// facility "log audit" (13) and severity "informational" (6)
+	syslogPriority = 13*8 + 6
+	syslogAppName  = "core"
+	syslogMsgID    = "audit"
+	syslogTimeout  = 10 * time.Second
+)
+
+// syslogSink sends the audit logs to the syslog server in RFC5424 format,
+// the messages are framed by octet counting (RFC6587) over TCP and TLS
+type syslogSink struct {
+	network  string
+	addr     string
+	insecure bool
+	hostname string
+	conn     net.Conn
+}
+
+func newSyslogSink(network, addr string, insecure bool) *syslogSink {
+	hostname, err := os.Hostname()
+	if err != nil || len(hostname) == 0 {
+		hostname = "-"
+	}
+	return &syslogSink{
+		network:  network,
+		addr:     addr,
+		insecure: insecure,
+		hostname: hostname,
+	}
+}
+
+func (s *syslogSink) Send(log *model.AuditLog) error {
+	msg, err := s.format(log)
+	if err != nil {
+		return err
+	}
+	if s.network != schemeUDP {
+		msg = append([]byte(fmt.Sprintf("%d ", len(msg))), msg...)
+	}
+	if s.conn == nil {
+		if err = s.connect(); err != nil {
+			return err
+		}
+	}
+	if err = s.conn.SetWriteDeadline(time.Now().Add(syslogTimeout)); err != nil {
+		return err
+	}
+	if _, err = s.conn.Write(msg); err != nil {
+		// reconnect when sending the next message
+		s.Close()
+		return err
+	}
+	return nil
+}
+
+func (s *syslogSink) connect() error {
+	dialer := &net.Dialer{Timeout: syslogTimeout}
+	var (
+		conn net.Conn
+		err  error
+	)
+	if s.network == schemeTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", s.addr, &tls.Config{InsecureSkipVerify: s.insecure})
+	} else {
+		conn, err = dialer.Dial(s.network, s.addr)
+	}
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	return nil
+}
+
+// format the audit log as RFC5424 message: "<PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID SD MSG",
+// the MSG is the audit log in JSON
+func (s *syslogSink) format(log *model.AuditLog) ([]byte, error) {
+	data, err := json.Marshal(log)
+	if err != nil {
+		return nil, err
+	}
+	header := fmt.Sprintf("<%d>1 %s %s %s %d %s - ", syslogPriority,
+		log.OpTime.UTC().Format("2006-01-02T15:04:05.000000Z07:00"), s.hostname, syslogAppName, os.Getpid(), syslogMsgID)
+	return append([]byte(header), data...), nil
+}
+
+func (s *syslogSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
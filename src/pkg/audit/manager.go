@@ -16,6 +16,8 @@ package audit
 
 import (
 	"context"
+	"io"
+
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/audit/dao"
 	"github.com/goharbor/harbor/src/pkg/audit/model"
@@ -36,6 +38,13 @@ type Manager interface {
 	Create(ctx context.Context, audit *model.AuditLog) (id int64, err error)
 	// Delete the audit log specified by ID
 	Delete(ctx context.Context, id int64) (err error)
+	// Export writes the audit logs according to the query into the writer in the specified format,
+	// the supported formats are "jsonl" and "csv"
+	Export(ctx context.Context, query *q.Query, format string, w io.Writer) (err error)
+	// Purge the audit logs older than the retention hours, only the audit logs of the included operations
+	// are purged if any is specified, the ones of the excluded operations are kept,
+	// returns the count of the purged audit logs, nothing is deleted in dry run mode
+	Purge(ctx context.Context, retentionHour int, includeOperations, excludeOperations []string, dryRun bool) (total int64, err error)
 }
 
 // New returns a default implementation of Manager
@@ -73,3 +82,13 @@ func (m *manager) Create(ctx context.Context, audit *model.AuditLog) (int64, err
 func (m *manager) Delete(ctx context.Context, id int64) error {
 	return m.dao.Delete(ctx, id)
 }
+
+// Export ...
+func (m *manager) Export(ctx context.Context, query *q.Query, format string, w io.Writer) error {
+	return export(ctx, m.dao, query, format, w)
+}
+
+// Purge ...
+func (m *manager) Purge(ctx context.Context, retentionHour int, includeOperations, excludeOperations []string, dryRun bool) (int64, error) {
+	return m.dao.Purge(ctx, retentionHour, includeOperations, excludeOperations, dryRun)
+}
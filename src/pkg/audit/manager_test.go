@@ -15,7 +15,13 @@
 package audit
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/audit/model"
 	"github.com/stretchr/testify/mock"
@@ -47,6 +53,10 @@ func (f *fakeDao) Delete(ctx context.Context, id int64) error {
 	args := f.Called()
 	return args.Error(0)
 }
+func (f *fakeDao) Purge(ctx context.Context, retentionHour int, includeOperations, excludeOperations []string, dryRun bool) (int64, error) {
+	args := f.Called(retentionHour, includeOperations, excludeOperations, dryRun)
+	return int64(args.Int(0)), args.Error(1)
+}
 
 type managerTestSuite struct {
 	suite.Suite
@@ -114,6 +124,53 @@ func (m *managerTestSuite) TestDelete() {
 	m.dao.AssertExpectations(m.T())
 }
 
+func (m *managerTestSuite) TestPurge() {
+	m.dao.On("Purge", 24, []string{"pull"}, []string{"delete"}, true).Return(2, nil)
+	total, err := m.mgr.Purge(nil, 24, []string{"pull"}, []string{"delete"}, true)
+	m.Require().Nil(err)
+	m.dao.AssertExpectations(m.T())
+	m.Equal(int64(2), total)
+}
+
+func (m *managerTestSuite) TestExport() {
+	audit := &model.AuditLog{
+		ID:           1,
+		ProjectID:    1,
+		Operation:    "delete",
+		Resource:     "library/hello-world",
+		ResourceType: "repository",
+		Username:     "admin",
+		ClientIP:     "10.0.0.1",
+		UserAgent:    "curl/7.68.0",
+		RequestID:    "request-id",
+		AuthMethod:   "basic",
+		Success:      true,
+		OpTime:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	m.dao.On("List", mock.Anything).Return([]*model.AuditLog{audit}, nil)
+
+	buf := &bytes.Buffer{}
+	err := m.mgr.Export(nil, nil, ExportFormatJSONL, buf)
+	m.Require().Nil(err)
+	log := &model.AuditLog{}
+	m.Require().Nil(json.Unmarshal(buf.Bytes(), log))
+	m.Equal("10.0.0.1", log.ClientIP)
+	m.Equal("request-id", log.RequestID)
+	m.True(log.Success)
+
+	buf.Reset()
+	err = m.mgr.Export(nil, nil, ExportFormatCSV, buf)
+	m.Require().Nil(err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	m.Require().Len(lines, 2)
+	m.Equal("id,op_time,username,operation,resource_type,resource,project_id,success,auth_method,client_ip,user_agent,request_id,reason", lines[0])
+	m.Equal("1,2021-01-01T00:00:00Z,admin,delete,repository,library/hello-world,1,true,basic,10.0.0.1,curl/7.68.0,request-id,", lines[1])
+
+	err = m.mgr.Export(nil, nil, "xml", buf)
+	m.Require().NotNil(err)
+	m.True(errors.IsErr(err, errors.BadRequestCode))
+}
+
 func TestManager(t *testing.T) {
 	suite.Run(t, &managerTestSuite{})
 }
@@ -17,6 +17,12 @@ type AuditLog struct {
 	ResourceType string    `orm:"column(resource_type)"  json:"resource_type"`
 	Resource     string    `orm:"column(resource)" json:"resource"`
 	Username     string    `orm:"column(username)"  json:"username"`
+	Reason       string    `orm:"column(reason)" json:"reason"`
+	ClientIP     string    `orm:"column(client_ip)" json:"client_ip"`
+	UserAgent    string    `orm:"column(user_agent)" json:"user_agent"`
+	RequestID    string    `orm:"column(request_id)" json:"request_id"`
+	AuthMethod   string    `orm:"column(auth_method)" json:"auth_method"`
+	Success      bool      `orm:"column(success)" json:"success"`
 	OpTime       time.Time `orm:"column(op_time)" json:"op_time" sort:"default:desc"`
 }
 
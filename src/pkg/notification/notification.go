@@ -6,6 +6,7 @@ import (
 	"github.com/goharbor/harbor/src/controller/event"
 	notifier_model "github.com/goharbor/harbor/src/pkg/notifier/model"
 
+	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/notification/hook"
 	"github.com/goharbor/harbor/src/pkg/notification/job"
@@ -62,12 +63,15 @@ func initSupportedNotifyType() {
 		event.TopicScanningCompleted,
 		event.TopicReplication,
 		event.TopicTagRetention,
+		event.TopicCreateRequest,
+		event.TopicApproveRequest,
+		event.TopicRejectRequest,
 	}
 	for _, eventType := range eventTypes {
 		SupportedEventTypes[eventType] = struct{}{}
 	}
 
-	notifyTypes := []string{notifier_model.NotifyTypeHTTP, notifier_model.NotifyTypeSlack}
+	notifyTypes := []string{notifier_model.NotifyTypeHTTP, notifier_model.NotifyTypeSlack, notifier_model.NotifyTypeEmail}
 	for _, notifyType := range notifyTypes {
 		SupportedNotifyTypes[notifyType] = struct{}{}
 	}
@@ -79,6 +83,9 @@ type eventKey struct{}
 type EventCtx struct {
 	Events     *list.List
 	MustNotify bool
+	// the method by which the request is authenticated, it is populated by the security
+	// middleware after the event context created, so it's captured when adding the event
+	AuthMethod string
 }
 
 // NewEventCtx returns instance of EventCtx
@@ -111,6 +118,9 @@ func AddEvent(ctx context.Context, m n_event.Metadata, notify ...bool) {
 	if len(notify) != 0 {
 		e.MustNotify = notify[0]
 	}
+	if len(e.AuthMethod) == 0 {
+		e.AuthMethod = lib.GetAuthMethod(ctx)
+	}
 	e.Events.PushBack(m)
 	return
 }
@@ -0,0 +1,213 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/job/models"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+)
+
+const (
+	// EmailLanguageEnglish is the default language of the email notification
+	EmailLanguageEnglish = "en-us"
+	// EmailLanguageKorean ...
+	EmailLanguageKorean = "ko-kr"
+
+	emailSubject = "subject"
+	emailText    = "text"
+	emailHTML    = "html"
+)
+
+// the built-in templates of the email notification, the customized ones of admin take precedence
+var defaultEmailTemplates = map[string]map[string]string{
+	EmailLanguageEnglish: {
+		emailSubject: `[HyperRegistry] {{.Type}}{{with .EventData}}{{with .Request}} - {{.ProjectName}}{{end}}{{end}}`,
+		emailText: `HyperRegistry event {{.Type}}
+Occurred at: {{.OccurAt}}
+Operator: {{.Operator}}
+{{with .EventData}}{{with .Request}}
+Project: {{.ProjectName}}
+Requester: {{.Requester}}
+Storage quota: {{.StorageQuota}}
+{{if .Reason}}Reason: {{.Reason}}
+{{end}}{{end}}{{end}}
+Event data:
+{{.Details}}
+`,
+		emailHTML: `<html><body>
+<h3>HyperRegistry event {{.Type}}</h3>
+<p>Occurred at: {{.OccurAt}}<br/>Operator: {{.Operator}}</p>
+{{with .EventData}}{{with .Request}}<table>
+<tr><td>Project</td><td>{{.ProjectName}}</td></tr>
+<tr><td>Requester</td><td>{{.Requester}}</td></tr>
+<tr><td>Storage quota</td><td>{{.StorageQuota}}</td></tr>
+{{if .Reason}}<tr><td>Reason</td><td>{{.Reason}}</td></tr>{{end}}
+</table>{{end}}{{end}}
+<p>Event data:</p>
+<pre>{{.Details}}</pre>
+</body></html>`,
+	},
+	EmailLanguageKorean: {
+		emailSubject: `[HyperRegistry] {{.Type}}{{with .EventData}}{{with .Request}} - {{.ProjectName}}{{end}}{{end}}`,
+		emailText: `HyperRegistry 이벤트 {{.Type}}
+발생 시각: {{.OccurAt}}
+수행자: {{.Operator}}
+{{with .EventData}}{{with .Request}}
+프로젝트: {{.ProjectName}}
+요청자: {{.Requester}}
+스토리지 할당량: {{.StorageQuota}}
+{{if .Reason}}사유: {{.Reason}}
+{{end}}{{end}}{{end}}
+이벤트 데이터:
+{{.Details}}
+`,
+		emailHTML: `<html><body>
+<h3>HyperRegistry 이벤트 {{.Type}}</h3>
+<p>발생 시각: {{.OccurAt}}<br/>수행자: {{.Operator}}</p>
+{{with .EventData}}{{with .Request}}<table>
+<tr><td>프로젝트</td><td>{{.ProjectName}}</td></tr>
+<tr><td>요청자</td><td>{{.Requester}}</td></tr>
+<tr><td>스토리지 할당량</td><td>{{.StorageQuota}}</td></tr>
+{{if .Reason}}<tr><td>사유</td><td>{{.Reason}}</td></tr>{{end}}
+</table>{{end}}{{end}}
+<p>이벤트 데이터:</p>
+<pre>{{.Details}}</pre>
+</body></html>`,
+	},
+}
+
+// emailData is the data to render the email templates
+type emailData struct {
+	Type      string
+	OccurAt   string
+	Operator  string
+	EventData *model.EventData
+	// the event data in JSON
+	Details string
+}
+
+// EmailHandler renders the event to email and start the hook processing
+type EmailHandler struct {
+}
+
+// Name ...
+func (e *EmailHandler) Name() string {
+	return "Email"
+}
+
+// Handle handles event to email
+func (e *EmailHandler) Handle(ctx context.Context, value interface{}) error {
+	if value == nil {
+		return errors.New("EmailHandler cannot handle nil value")
+	}
+
+	event, ok := value.(*model.HookEvent)
+	if !ok || event == nil {
+		return errors.New("invalid notification email event")
+	}
+
+	return e.process(ctx, event)
+}
+
+// IsStateful ...
+func (e *EmailHandler) IsStateful() bool {
+	return false
+}
+
+func (e *EmailHandler) process(ctx context.Context, event *model.HookEvent) error {
+	j := &models.JobData{
+		Metadata: &models.JobMetadata{
+			JobKind: job.KindGeneric,
+		},
+	}
+	// Create an emailJob to send the email
+	j.Name = job.EmailJob
+
+	subject, text, html, err := RenderEmail(config.NotificationEmailLanguage(ctx), config.NotificationEmailTemplates(ctx), event.Payload)
+	if err != nil {
+		return fmt.Errorf("render email of %s event failed: %v", event.EventType, err)
+	}
+
+	// the address of the email target is the comma separated recipients
+	j.Parameters = map[string]interface{}{
+		"to":        event.Target.Address,
+		"subject":   subject,
+		"text_body": text,
+		"html_body": html,
+	}
+	return notification.HookManager.StartHook(ctx, event, j)
+}
+
+// RenderEmail renders the subject, plain text and HTML body of the email for the payload,
+// the customized templates is keyed by "<language>.<subject|text|html>"
+func RenderEmail(language string, customized map[string]string, payload *model.Payload) (string, string, string, error) {
+	language = strings.ToLower(language)
+	defaults, ok := defaultEmailTemplates[language]
+	if !ok {
+		language = EmailLanguageEnglish
+		defaults = defaultEmailTemplates[language]
+	}
+	tmpl := func(part string) string {
+		if t, ok := customized[language+"."+part]; ok && len(t) > 0 {
+			return t
+		}
+		return defaults[part]
+	}
+
+	details, err := json.MarshalIndent(payload.EventData, "", "  ")
+	if err != nil {
+		return "", "", "", fmt.Errorf("marshal from eventData %v failed: %v", payload.EventData, err)
+	}
+	data := &emailData{
+		Type:      payload.Type,
+		OccurAt:   time.Unix(payload.OccurAt, 0).Format(time.RFC3339),
+		Operator:  payload.Operator,
+		EventData: payload.EventData,
+		Details:   string(details),
+	}
+
+	subject, err := renderText(emailSubject, tmpl(emailSubject), data)
+	if err != nil {
+		return "", "", "", err
+	}
+	text, err := renderText(emailText, tmpl(emailText), data)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	ht, err := htmltemplate.New(emailHTML).Parse(tmpl(emailHTML))
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid %s template: %v", emailHTML, err)
+	}
+	var html bytes.Buffer
+	if err := ht.Execute(&html, data); err != nil {
+		return "", "", "", fmt.Errorf("failed to render %s template: %v", emailHTML, err)
+	}
+
+	// the subject should be in single line
+	subject = strings.Join(strings.Fields(subject), " ")
+	return subject, text, html.String(), nil
+}
+
+func renderText(name, tmpl string, data *emailData) (string, error) {
+	t, err := template.New(name).Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s template: %v", name, err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %v", name, err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,101 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/job/models"
+	evtModel "github.com/goharbor/harbor/src/controller/event/model"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/config"
+	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type capturedHookManager struct {
+	jobs []*models.JobData
+}
+
+func (c *capturedHookManager) StartHook(ctx context.Context, event *model.HookEvent, job *models.JobData) error {
+	c.jobs = append(c.jobs, job)
+	return nil
+}
+
+func requestPayload() *model.Payload {
+	return &model.Payload{
+		Type:     "REJECT_REQUEST",
+		OccurAt:  time.Now().Unix(),
+		Operator: "admin",
+		EventData: &model.EventData{
+			Request: &evtModel.ProjectRequest{
+				RequestID:    1,
+				ProjectName:  "library",
+				Requester:    "dev",
+				StorageQuota: 1024,
+				Reason:       "<reserved>",
+			},
+		},
+	}
+}
+
+func TestEmailHandler_Handle(t *testing.T) {
+	config.InitWithSettings(map[string]interface{}{
+		common.NotificationEmailLanguage: "ko-kr",
+	})
+	hookMgr := notification.HookManager
+	defer func() {
+		notification.HookManager = hookMgr
+	}()
+	captured := &capturedHookManager{}
+	notification.HookManager = captured
+
+	handler := &EmailHandler{}
+	assert.Error(t, handler.Handle(context.TODO(), nil))
+	assert.Error(t, handler.Handle(context.TODO(), &model.EventData{}))
+
+	err := handler.Handle(context.TODO(), &model.HookEvent{
+		PolicyID:  1,
+		EventType: "REJECT_REQUEST",
+		Target: &policy_model.EventTarget{
+			Type:    "email",
+			Address: "admin@example.com,ops@example.com",
+		},
+		Payload: requestPayload(),
+	})
+	require.NoError(t, err)
+	require.Len(t, captured.jobs, 1)
+	assert.Equal(t, job.EmailJob, captured.jobs[0].Name)
+	assert.Equal(t, "admin@example.com,ops@example.com", captured.jobs[0].Parameters["to"])
+	assert.Contains(t, captured.jobs[0].Parameters["text_body"], "요청자: dev")
+
+	assert.False(t, handler.IsStateful())
+}
+
+func TestRenderEmail(t *testing.T) {
+	// the default english templates
+	subject, text, html, err := RenderEmail("", nil, requestPayload())
+	require.NoError(t, err)
+	assert.Equal(t, "[HyperRegistry] REJECT_REQUEST - library", subject)
+	assert.Contains(t, text, "Requester: dev")
+	assert.Contains(t, text, "Reason: <reserved>")
+	assert.Contains(t, html, "&lt;reserved&gt;")
+
+	// the customized templates of the language take precedence
+	subject, text, _, err = RenderEmail(EmailLanguageKorean, map[string]string{
+		"ko-kr.subject": "프로젝트 {{.EventData.Request.ProjectName}} 요청",
+		"en-us.subject": "ignored",
+	}, requestPayload())
+	require.NoError(t, err)
+	assert.Equal(t, "프로젝트 library 요청", subject)
+	assert.Contains(t, text, "요청자: dev")
+
+	// invalid template
+	_, _, _, err = RenderEmail(EmailLanguageEnglish, map[string]string{"en-us.html": "{{.Type"}, requestPayload())
+	assert.Error(t, err)
+}
@@ -23,11 +23,12 @@ type Payload struct {
 
 // EventData of notification event payload
 type EventData struct {
-	Resources   []*Resource        `json:"resources,omitempty"`
-	Repository  *Repository        `json:"repository,omitempty"`
-	Replication *model.Replication `json:"replication,omitempty"`
-	Retention   *model.Retention   `json:"retention,omitempty"`
-	Custom      map[string]string  `json:"custom_attributes,omitempty"`
+	Resources   []*Resource           `json:"resources,omitempty"`
+	Repository  *Repository           `json:"repository,omitempty"`
+	Replication *model.Replication    `json:"replication,omitempty"`
+	Retention   *model.Retention      `json:"retention,omitempty"`
+	Request     *model.ProjectRequest `json:"project_request,omitempty"`
+	Custom      map[string]string     `json:"custom_attributes,omitempty"`
 }
 
 // Resource describe infos of resource triggered notification
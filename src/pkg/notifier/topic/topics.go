@@ -12,6 +12,7 @@ func init() {
 	handlersMap := map[string][]notifier.NotificationHandler{
 		model.WebhookTopic: {&notification.HTTPHandler{}},
 		model.SlackTopic:   {&notification.SlackHandler{}},
+		model.EmailTopic:   {&notification.EmailHandler{}},
 	}
 
 	for t, handlers := range handlersMap {
@@ -16,10 +16,12 @@ package models
 
 // keys of project metadata and severity values
 const (
-	ProMetaPublic               = "public"
-	ProMetaEnableContentTrust   = "enable_content_trust"
-	ProMetaPreventVul           = "prevent_vul" // prevent vulnerable images from being pulled
-	ProMetaSeverity             = "severity"
-	ProMetaAutoScan             = "auto_scan"
-	ProMetaReuseSysCVEAllowlist = "reuse_sys_cve_allowlist"
+	ProMetaPublic                   = "public"
+	ProMetaEnableContentTrust       = "enable_content_trust"
+	ProMetaPreventVul               = "prevent_vul" // prevent vulnerable images from being pulled
+	ProMetaSeverity                 = "severity"
+	ProMetaAutoScan                 = "auto_scan"
+	ProMetaReuseSysCVEAllowlist     = "reuse_sys_cve_allowlist"
+	ProMetaEnableCosignVerification = "enable_cosign_verification" // only allow pulling images signed by the cosign public keys
+	ProMetaCosignPublicKeys         = "cosign_public_keys"         // PEM encoded cosign public keys
 )
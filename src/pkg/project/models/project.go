@@ -103,6 +103,24 @@ func (p *Project) ContentTrustEnabled() bool {
 	return isTrue(enabled)
 }
 
+// CosignVerificationEnabled returns true when the pulled artifacts must be signed by the cosign public keys of project
+func (p *Project) CosignVerificationEnabled() bool {
+	enabled, exist := p.GetMetadata(ProMetaEnableCosignVerification)
+	if !exist {
+		return false
+	}
+	return isTrue(enabled)
+}
+
+// CosignPublicKeys returns the PEM encoded cosign public keys of project
+func (p *Project) CosignPublicKeys() string {
+	keys, exist := p.GetMetadata(ProMetaCosignPublicKeys)
+	if !exist {
+		return ""
+	}
+	return keys
+}
+
 // VulPrevented ...
 func (p *Project) VulPrevented() bool {
 	prevent, exist := p.GetMetadata(ProMetaPreventVul)
@@ -65,6 +65,14 @@ type ArtifactRegistry interface {
 	DeleteTag(repository, tag string) error
 }
 
+// ChunkedBlobRegistry defines the capabilities of pulling and pushing blobs by chunks, the adapters
+// implementing it should advertise the support by setting "SupportedCopyByChunk" in the registry info
+type ChunkedBlobRegistry interface {
+	PullBlobChunk(repository, digest string, blobSize, start, end int64) (size int64, blob io.ReadCloser, err error)
+	PushBlobChunk(repository, digest string, blobSize int64, chunk io.Reader, start, end int64, location string) (nextUploadLocation string, endRange int64, err error)
+	GetBlobUploadStatus(location string) (nextUploadLocation string, offset int64, err error)
+}
+
 // ChartRegistry defines the capabilities that a chart registry should have
 type ChartRegistry interface {
 	FetchCharts(filters []*model.Filter) ([]*model.Resource, error)
@@ -115,6 +115,8 @@ func (a *Adapter) Info() (*model.RegistryInfo, error) {
 			model.TriggerTypeScheduled,
 		},
 		SupportedRepositoryPathComponentType: model.RepositoryPathComponentTypeAtLeastTwo,
+		// the blobs are copied as a whole if the registry fails on the range or chunk requests
+		SupportedCopyByChunk: true,
 	}
 
 	enabled, err := a.Client.ChartRegistryEnabled()
@@ -16,6 +16,7 @@ package v2
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/controller/artifact"
@@ -65,6 +66,12 @@ func (c *client) listArtifacts(repo string) ([]*model.Artifact, error) {
 		}
 		for _, tag := range artifact.Tags {
 			art.Tags = append(art.Tags, tag.Name)
+			if !tag.PushTime.IsZero() {
+				if art.TagPushTimes == nil {
+					art.TagPushTimes = map[string]time.Time{}
+				}
+				art.TagPushTimes[tag.Name] = tag.PushTime
+			}
 		}
 		arts = append(arts, art)
 	}
@@ -105,6 +105,8 @@ func (a *Adapter) Info() (info *model.RegistryInfo, err error) {
 			model.TriggerTypeManual,
 			model.TriggerTypeScheduled,
 		},
+		// the blobs are copied as a whole if the registry fails on the range or chunk requests
+		SupportedCopyByChunk: true,
 	}, nil
 }
 
@@ -25,18 +25,21 @@ func init() {
 
 // Registry is the model for a registry, which wraps the endpoint URL and credential of a remote registry.
 type Registry struct {
-	ID             int64     `orm:"pk;auto;column(id)"`
-	URL            string    `orm:"column(url)"`
-	Name           string    `orm:"column(name)"`
-	CredentialType string    `orm:"column(credential_type);default(basic)"`
-	AccessKey      string    `orm:"column(access_key)"`
-	AccessSecret   string    `orm:"column(access_secret)"`
-	Type           string    `orm:"column(type)"`
-	Insecure       bool      `orm:"column(insecure)"`
-	Description    string    `orm:"column(description)"`
-	Status         string    `orm:"column(health)"`
-	CreationTime   time.Time `orm:"column(creation_time);auto_now_add"`
-	UpdateTime     time.Time `orm:"column(update_time);auto_now"`
+	ID             int64  `orm:"pk;auto;column(id)"`
+	URL            string `orm:"column(url)"`
+	Name           string `orm:"column(name)"`
+	CredentialType string `orm:"column(credential_type);default(basic)"`
+	AccessKey      string `orm:"column(access_key)"`
+	AccessSecret   string `orm:"column(access_secret)"`
+	Type           string `orm:"column(type)"`
+	Insecure       bool   `orm:"column(insecure)"`
+	Description    string `orm:"column(description)"`
+	Status         string `orm:"column(health)"`
+	Speed          int32  `orm:"column(speed_kb)"`
+	// the JSON string of the bandwidth schedule
+	BandwidthSchedule string    `orm:"column(bandwidth_schedule)"`
+	CreationTime      time.Time `orm:"column(creation_time);auto_now_add"`
+	UpdateTime        time.Time `orm:"column(update_time);auto_now"`
 }
 
 // TableName is required by by beego orm to map Registry to table registry
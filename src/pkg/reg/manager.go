@@ -16,6 +16,7 @@ package reg
 
 import (
 	"context"
+	"encoding/json"
 	commonthttp "github.com/goharbor/harbor/src/common/http"
 	"github.com/goharbor/harbor/src/lib/config"
 
@@ -227,10 +228,17 @@ func fromDaoModel(registry *dao.Registry) (*model.Registry, error) {
 		URL:          registry.URL,
 		Insecure:     registry.Insecure,
 		Status:       registry.Status,
+		Speed:        registry.Speed,
 		CreationTime: registry.CreationTime,
 		UpdateTime:   registry.UpdateTime,
 	}
 
+	if len(registry.BandwidthSchedule) > 0 {
+		if err := json.Unmarshal([]byte(registry.BandwidthSchedule), &r.BandwidthSchedule); err != nil {
+			return nil, err
+		}
+	}
+
 	if len(registry.AccessKey) != 0 {
 		credentialType := registry.CredentialType
 		if len(credentialType) == 0 {
@@ -261,10 +269,19 @@ func toDaoModel(registry *model.Registry) (*dao.Registry, error) {
 		Insecure:     registry.Insecure,
 		Description:  registry.Description,
 		Status:       registry.Status,
+		Speed:        registry.Speed,
 		CreationTime: registry.CreationTime,
 		UpdateTime:   registry.UpdateTime,
 	}
 
+	if len(registry.BandwidthSchedule) > 0 {
+		schedule, err := json.Marshal(registry.BandwidthSchedule)
+		if err != nil {
+			return nil, err
+		}
+		m.BandwidthSchedule = string(schedule)
+	}
+
 	if registry.Credential != nil && len(registry.Credential.AccessKey) != 0 {
 		credentialType := registry.Credential.Type
 		if len(credentialType) == 0 {
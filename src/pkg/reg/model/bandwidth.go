@@ -0,0 +1,106 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+const bandwidthTimeLayout = "15:04"
+
+// BandwidthWindow defines the speed limit during a time window of the day
+type BandwidthWindow struct {
+	// the start and end time of the window in UTC in the format "15:04", the
+	// window crosses the midnight if the end time is before the start time
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// the speed limit(kb/s) during the window, 0 means unlimited
+	Speed int32 `json:"speed"`
+}
+
+// Validate the window
+func (b *BandwidthWindow) Validate() error {
+	start, err := time.Parse(bandwidthTimeLayout, b.Start)
+	if err != nil {
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("invalid start time of the bandwidth window: %s", b.Start)
+	}
+	end, err := time.Parse(bandwidthTimeLayout, b.End)
+	if err != nil {
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("invalid end time of the bandwidth window: %s", b.End)
+	}
+	if start.Equal(end) {
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("the start and end time of the bandwidth window cannot be same")
+	}
+	if b.Speed < 0 {
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("the speed of the bandwidth window cannot be negative")
+	}
+	return nil
+}
+
+// contains returns whether the time of the day is in the window, the time is
+// converted to UTC before comparing as the window is defined in UTC
+func (b *BandwidthWindow) contains(t time.Time) bool {
+	t = t.UTC()
+	start, err := time.Parse(bandwidthTimeLayout, b.Start)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(bandwidthTimeLayout, b.End)
+	if err != nil {
+		return false
+	}
+	minutes := t.Hour()*60 + t.Minute()
+	s := start.Hour()*60 + start.Minute()
+	e := end.Hour()*60 + end.Minute()
+	if s < e {
+		return minutes >= s && minutes < e
+	}
+	// crosses the midnight
+	return minutes >= s || minutes < e
+}
+
+// BandwidthSchedule is a list of the bandwidth windows, the first window
+// matched takes effect
+type BandwidthSchedule []*BandwidthWindow
+
+// Validate the schedule
+func (b BandwidthSchedule) Validate() error {
+	for _, window := range b {
+		if window == nil {
+			return errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("empty bandwidth window")
+		}
+		if err := window.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SpeedAt returns the speed limit(kb/s) at the specified time, the "speed"
+// is returned if the time isn't in any window
+func (b BandwidthSchedule) SpeedAt(speed int32, t time.Time) int32 {
+	for _, window := range b {
+		if window != nil && window.contains(t) {
+			return window.Speed
+		}
+	}
+	return speed
+}
@@ -0,0 +1,53 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBandwidthScheduleValidate(t *testing.T) {
+	assert.Nil(t, BandwidthSchedule{}.Validate())
+	assert.Nil(t, BandwidthSchedule{{Start: "09:00", End: "18:00", Speed: 10240}}.Validate())
+	assert.NotNil(t, BandwidthSchedule{nil}.Validate())
+	assert.NotNil(t, BandwidthSchedule{{Start: "9", End: "18:00"}}.Validate())
+	assert.NotNil(t, BandwidthSchedule{{Start: "09:00", End: "24:00"}}.Validate())
+	assert.NotNil(t, BandwidthSchedule{{Start: "09:00", End: "09:00"}}.Validate())
+	assert.NotNil(t, BandwidthSchedule{{Start: "09:00", End: "18:00", Speed: -1}}.Validate())
+}
+
+func TestBandwidthScheduleSpeedAt(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2021, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+	schedule := BandwidthSchedule{
+		{Start: "09:00", End: "18:00", Speed: 10240},
+		{Start: "22:00", End: "06:00", Speed: 0},
+	}
+	assert.Equal(t, int32(10240), schedule.SpeedAt(1024, at(9, 0)))
+	assert.Equal(t, int32(10240), schedule.SpeedAt(1024, at(17, 59)))
+	assert.Equal(t, int32(1024), schedule.SpeedAt(1024, at(18, 0)))
+	assert.Equal(t, int32(0), schedule.SpeedAt(1024, at(23, 0)))
+	assert.Equal(t, int32(0), schedule.SpeedAt(1024, at(5, 59)))
+	assert.Equal(t, int32(1024), schedule.SpeedAt(1024, at(6, 0)))
+	assert.Equal(t, int32(1024), BandwidthSchedule(nil).SpeedAt(1024, at(12, 0)))
+	// the time in other time zones is converted to UTC
+	tokyo := time.FixedZone("UTC+9", 9*60*60)
+	assert.Equal(t, int32(10240), schedule.SpeedAt(1024, time.Date(2021, 1, 1, 18, 0, 0, 0, tokyo)))
+	assert.Equal(t, int32(1024), schedule.SpeedAt(1024, time.Date(2021, 1, 2, 3, 0, 0, 0, tokyo)))
+}
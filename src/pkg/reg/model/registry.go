@@ -100,8 +100,12 @@ type Registry struct {
 	Credential      *Credential `json:"credential"`
 	Insecure        bool        `json:"insecure"`
 	Status          string      `json:"status"`
-	CreationTime    time.Time   `json:"creation_time"`
-	UpdateTime      time.Time   `json:"update_time"`
+	// Speed is the speed limit(kb/s) shared by all the replication tasks whose destination is the registry
+	Speed int32 `json:"speed"`
+	// BandwidthSchedule overrides the Speed during the time windows of the day
+	BandwidthSchedule BandwidthSchedule `json:"bandwidth_schedule,omitempty"`
+	CreationTime      time.Time         `json:"creation_time"`
+	UpdateTime        time.Time         `json:"update_time"`
 }
 
 // FilterStyle ...
@@ -139,6 +143,7 @@ type RegistryInfo struct {
 	SupportedResourceFilters             []*FilterStyle `json:"supported_resource_filters"`
 	SupportedTriggers                    []string       `json:"supported_triggers"`
 	SupportedRepositoryPathComponentType string         `json:"supported_repository_path_component_type"` // how many path components are allowed in the repository name
+	SupportedCopyByChunk                 bool           `json:"supported_copy_by_chunk,omitempty"`        // whether the blobs can be pulled and pushed by chunks
 }
 
 // AdapterPattern provides base info and capability declarations of the registry
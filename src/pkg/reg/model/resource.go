@@ -14,6 +14,8 @@
 
 package model
 
+import "time"
+
 // the resource type
 const (
 	ResourceTypeArtifact = "artifact"
@@ -54,4 +56,6 @@ type Artifact struct {
 	Digest string   `json:"digest"`
 	Labels []string `json:"labels"`
 	Tags   []string `json:"tags"`
+	// the push time of the tags, only available for the registries which record it
+	TagPushTimes map[string]time.Time `json:"tag_push_times,omitempty"`
 }
@@ -0,0 +1,229 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+// const definition
+const (
+	RewriteRuleTypeRepository = "repository"
+	RewriteRuleTypeTag        = "tag"
+
+	// the replacement is expanded with the capture groups of the pattern, e.g. "$1" or "${name}"
+	RewriteRuleKindRegex = "regex"
+	// the replacement is rendered as Go template
+	RewriteRuleKindTemplate = "template"
+)
+
+// the functions available in the template, the string to be handled is the last
+// argument to support the pipeline, e.g. {{.Name | replace "_" "-"}}
+var rewriteTemplateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"replace": func(old, new, s string) string {
+		return strings.ReplaceAll(s, old, new)
+	},
+	"trimPrefix": func(prefix, s string) string {
+		return strings.TrimPrefix(s, prefix)
+	},
+	"trimSuffix": func(suffix, s string) string {
+		return strings.TrimSuffix(s, suffix)
+	},
+}
+
+// RewriteRule maps the name of the source repository or tag to the destination one. e.g.
+// the rule with pattern "library/(.*)" and replacement "mirror/dockerhub/$1" rewrites
+// the repository "library/hello-world" to "mirror/dockerhub/hello-world"
+type RewriteRule struct {
+	// "repository" or "tag"
+	Type string `json:"type"`
+	// "regex" or "template", default is "regex"
+	Kind string `json:"kind,omitempty"`
+	// the regular expression which must match the whole source name
+	Pattern     string `json:"pattern"`
+	Replacement string `json:"replacement"`
+}
+
+// the data used to render the template replacement
+type rewriteData struct {
+	// the source name to be rewritten
+	Name string
+	// the source repository, it's same with the "Name" for the repository rules
+	Repository string
+	// the capture groups of the pattern, the first one is the whole name
+	Groups []string
+}
+
+func (r *RewriteRule) kind() string {
+	if len(r.Kind) == 0 {
+		return RewriteRuleKindRegex
+	}
+	return r.Kind
+}
+
+func (r *RewriteRule) compile() (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + r.Pattern + ")$")
+}
+
+// literal returns whether the pattern matches only one name
+func (r *RewriteRule) literal() bool {
+	re, err := regexp.Compile(r.Pattern)
+	if err != nil {
+		return false
+	}
+	_, complete := re.LiteralPrefix()
+	return complete
+}
+
+// constant returns whether the replacement doesn't depend on the source name
+func (r *RewriteRule) constant() bool {
+	if r.kind() == RewriteRuleKindTemplate {
+		return !strings.Contains(r.Replacement, "{{")
+	}
+	return !strings.Contains(r.Replacement, "$")
+}
+
+// Validate the rule
+func (r *RewriteRule) Validate() error {
+	if r.Type != RewriteRuleTypeRepository && r.Type != RewriteRuleTypeTag {
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("invalid rewrite rule type: %s", r.Type)
+	}
+	if len(r.Pattern) == 0 || len(r.Replacement) == 0 {
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("the pattern and replacement of rewrite rule cannot be empty")
+	}
+	re, err := r.compile()
+	if err != nil {
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("invalid pattern of rewrite rule %s: %v", r.Pattern, err)
+	}
+	switch r.kind() {
+	case RewriteRuleKindRegex:
+	case RewriteRuleKindTemplate:
+		// render the template with empty values to make sure the groups it refers exist
+		if _, err := r.render(&rewriteData{
+			Groups: make([]string, re.NumSubexp()+1),
+		}); err != nil {
+			return err
+		}
+	default:
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("invalid rewrite rule kind: %s", r.Kind)
+	}
+	// the pattern matches more than one name but all of them are rewritten to the same one
+	if !r.literal() && r.constant() {
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("conflict rewrite rule: all the names matching the pattern %s are rewritten to the same %s", r.Pattern, r.Replacement)
+	}
+	return nil
+}
+
+// Rewrite the name if it matches the pattern, the "repository" is the source repository
+// which the name belongs to. Returns false if the name doesn't match the pattern
+func (r *RewriteRule) Rewrite(name, repository string) (string, bool, error) {
+	re, err := r.compile()
+	if err != nil {
+		return "", false, err
+	}
+	match := re.FindStringSubmatchIndex(name)
+	if match == nil {
+		return "", false, nil
+	}
+	if r.kind() == RewriteRuleKindRegex {
+		return string(re.ExpandString(nil, r.Replacement, name, match)), true, nil
+	}
+	result, err := r.render(&rewriteData{
+		Name:       name,
+		Repository: repository,
+		Groups:     re.FindStringSubmatch(name),
+	})
+	if err != nil {
+		return "", false, err
+	}
+	return result, true, nil
+}
+
+func (r *RewriteRule) render(data *rewriteData) (string, error) {
+	tmpl, err := template.New("rewrite").Funcs(rewriteTemplateFuncs).Parse(r.Replacement)
+	if err != nil {
+		return "", errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("invalid template of rewrite rule %s: %v", r.Replacement, err)
+	}
+	buf := &bytes.Buffer{}
+	if err = tmpl.Execute(buf, data); err != nil {
+		return "", errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("failed to render the template of rewrite rule %s: %v", r.Replacement, err)
+	}
+	return buf.String(), nil
+}
+
+// RewriteRules is a list of the rewrite rules, the first matched rule of the
+// specified type takes effect
+type RewriteRules []*RewriteRule
+
+// Validate the rules and detect the conflicts between them
+func (r RewriteRules) Validate() error {
+	for i, rule := range r {
+		if rule == nil {
+			return errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("empty rewrite rule")
+		}
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+		for _, prev := range r[:i] {
+			if prev == nil || prev.Type != rule.Type {
+				continue
+			}
+			// the rule never takes effect as the previous one matches the same names
+			if prev.Pattern == rule.Pattern {
+				return errors.New(nil).WithCode(errors.BadRequestCode).
+					WithMessage("conflict rewrite rules: the %s pattern %s is duplicated", rule.Type, rule.Pattern)
+			}
+			// the different names matching the two rules may be rewritten to the same one
+			if prev.kind() == rule.kind() && prev.Replacement == rule.Replacement {
+				return errors.New(nil).WithCode(errors.BadRequestCode).
+					WithMessage("conflict rewrite rules: the %s patterns %s and %s have the same replacement %s",
+						rule.Type, prev.Pattern, rule.Pattern, rule.Replacement)
+			}
+		}
+	}
+	return nil
+}
+
+// Rewrite the name by the first matched rule of the specified type, the "repository" is the
+// source repository which the name belongs to. Returns false if no rule matches the name
+func (r RewriteRules) Rewrite(ruleType, name, repository string) (string, bool, error) {
+	for _, rule := range r {
+		if rule == nil || rule.Type != ruleType {
+			continue
+		}
+		result, matched, err := rule.Rewrite(name, repository)
+		if err != nil {
+			return "", false, err
+		}
+		if matched {
+			return result, true, nil
+		}
+	}
+	return name, false, nil
+}
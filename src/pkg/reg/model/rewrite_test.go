@@ -0,0 +1,122 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteRuleValidate(t *testing.T) {
+	cases := []struct {
+		rule *RewriteRule
+		pass bool
+	}{
+		// invalid type
+		{&RewriteRule{Type: "invalid", Pattern: "library/(.*)", Replacement: "mirror/$1"}, false},
+		// empty replacement
+		{&RewriteRule{Type: RewriteRuleTypeRepository, Pattern: "library/(.*)"}, false},
+		// invalid pattern
+		{&RewriteRule{Type: RewriteRuleTypeRepository, Pattern: "library/(.*", Replacement: "mirror/$1"}, false},
+		// invalid kind
+		{&RewriteRule{Type: RewriteRuleTypeRepository, Kind: "invalid", Pattern: "library/(.*)", Replacement: "mirror/$1"}, false},
+		// invalid template
+		{&RewriteRule{Type: RewriteRuleTypeRepository, Kind: RewriteRuleKindTemplate, Pattern: "library/(.*)", Replacement: "mirror/{{.Name"}, false},
+		// the template refers the group that doesn't exist
+		{&RewriteRule{Type: RewriteRuleTypeRepository, Kind: RewriteRuleKindTemplate, Pattern: "library/(.*)", Replacement: "mirror/{{index .Groups 2}}"}, false},
+		// all the names are rewritten to the same one
+		{&RewriteRule{Type: RewriteRuleTypeRepository, Pattern: "library/(.*)", Replacement: "mirror/hello-world"}, false},
+		// pass
+		{&RewriteRule{Type: RewriteRuleTypeRepository, Pattern: "library/hello-world", Replacement: "mirror/hello-world"}, true},
+		{&RewriteRule{Type: RewriteRuleTypeRepository, Pattern: "library/(.*)", Replacement: "mirror/$1"}, true},
+		{&RewriteRule{Type: RewriteRuleTypeTag, Kind: RewriteRuleKindTemplate, Pattern: "(.*)", Replacement: "{{index .Groups 1}}-mirrored"}, true},
+	}
+	for _, c := range cases {
+		err := c.rule.Validate()
+		if c.pass {
+			assert.Nil(t, err, c.rule.Pattern)
+		} else {
+			assert.NotNil(t, err, c.rule.Pattern)
+		}
+	}
+}
+
+func TestRewriteRulesValidate(t *testing.T) {
+	// duplicated pattern
+	rules := RewriteRules{
+		{Type: RewriteRuleTypeRepository, Pattern: "library/(.*)", Replacement: "mirror/$1"},
+		{Type: RewriteRuleTypeRepository, Pattern: "library/(.*)", Replacement: "dockerhub/$1"},
+	}
+	assert.NotNil(t, rules.Validate())
+
+	// different patterns with the same replacement
+	rules = RewriteRules{
+		{Type: RewriteRuleTypeRepository, Pattern: "library/(.*)", Replacement: "mirror/$1"},
+		{Type: RewriteRuleTypeRepository, Pattern: "mirror/(.*)", Replacement: "mirror/$1"},
+	}
+	assert.NotNil(t, rules.Validate())
+
+	// the repository and tag rules don't conflict
+	rules = RewriteRules{
+		{Type: RewriteRuleTypeRepository, Pattern: "(.*)", Replacement: "$1"},
+		{Type: RewriteRuleTypeTag, Pattern: "(.*)", Replacement: "$1"},
+	}
+	assert.Nil(t, rules.Validate())
+}
+
+func TestRewriteRulesRewrite(t *testing.T) {
+	rules := RewriteRules{
+		{Type: RewriteRuleTypeRepository, Pattern: "library/(.*)", Replacement: "mirror/dockerhub/$1"},
+		{Type: RewriteRuleTypeRepository, Kind: RewriteRuleKindTemplate, Pattern: "(.*)/(.*)",
+			Replacement: `{{index .Groups 1 | upper}}/{{index .Groups 2 | replace "_" "-"}}`},
+		{Type: RewriteRuleTypeTag, Pattern: `v(\d+\.\d+)`, Replacement: "${1}-mirrored"},
+		{Type: RewriteRuleTypeTag, Kind: RewriteRuleKindTemplate, Pattern: "latest",
+			Replacement: `{{trimPrefix "library/" .Repository}}-latest`},
+	}
+	require.Nil(t, rules.Validate())
+
+	name, matched, err := rules.Rewrite(RewriteRuleTypeRepository, "library/hello-world", "library/hello-world")
+	require.Nil(t, err)
+	assert.True(t, matched)
+	assert.Equal(t, "mirror/dockerhub/hello-world", name)
+
+	name, matched, err = rules.Rewrite(RewriteRuleTypeRepository, "test/hello_world", "test/hello_world")
+	require.Nil(t, err)
+	assert.True(t, matched)
+	assert.Equal(t, "TEST/hello-world", name)
+
+	// not matched
+	name, matched, err = rules.Rewrite(RewriteRuleTypeRepository, "hello-world", "hello-world")
+	require.Nil(t, err)
+	assert.False(t, matched)
+	assert.Equal(t, "hello-world", name)
+
+	name, matched, err = rules.Rewrite(RewriteRuleTypeTag, "v1.0", "library/hello-world")
+	require.Nil(t, err)
+	assert.True(t, matched)
+	assert.Equal(t, "1.0-mirrored", name)
+
+	name, matched, err = rules.Rewrite(RewriteRuleTypeTag, "latest", "library/hello-world")
+	require.Nil(t, err)
+	assert.True(t, matched)
+	assert.Equal(t, "hello-world-latest", name)
+
+	// the pattern must match the whole name
+	_, matched, err = rules.Rewrite(RewriteRuleTypeTag, "v1.0.1", "library/hello-world")
+	require.Nil(t, err)
+	assert.False(t, matched)
+}
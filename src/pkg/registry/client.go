@@ -86,6 +86,16 @@ type Client interface {
 	PullBlob(repository, digest string) (size int64, blob io.ReadCloser, err error)
 	// PushBlob pushes the specified blob
 	PushBlob(repository, digest string, size int64, blob io.Reader) error
+	// PullBlobChunk pulls the specified range of the blob. The caller must close the returned "blob"
+	PullBlobChunk(repository, digest string, blobSize, start, end int64) (size int64, blob io.ReadCloser, err error)
+	// PushBlobChunk pushes the specified range of the blob to the upload session identified by the "location",
+	// a new upload session is initiated if the "location" is empty. The upload is completed when the last
+	// range of the blob is pushed. The location of the upload session for the next chunk and the end of the
+	// range that the registry has received are returned
+	PushBlobChunk(repository, digest string, blobSize int64, chunk io.Reader, start, end int64, location string) (nextUploadLocation string, endRange int64, err error)
+	// GetBlobUploadStatus gets the status of the upload session identified by the "location", the location
+	// of the upload session for the next chunk and the count of the bytes that the registry has received are returned
+	GetBlobUploadStatus(location string) (nextUploadLocation string, offset int64, err error)
 	// MountBlob mounts the blob from the source repository
 	MountBlob(srcRepository, digest, dstRepository string) (err error)
 	// DeleteBlob deletes the specified blob
@@ -380,6 +390,99 @@ func (c *client) PushBlob(repository, digest string, size int64, blob io.Reader)
 	return c.monolithicBlobUpload(location, digest, size, blob)
 }
 
+func (c *client) PullBlobChunk(repository, digest string, blobSize, start, end int64) (int64, io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, buildBlobURL(c.url, repository, digest), nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req.Header.Add(http.CanonicalHeaderKey("Accept-Encoding"), "identity")
+	req.Header.Add(http.CanonicalHeaderKey("Range"), fmt.Sprintf("bytes=%d-%d", start, end))
+	resp, err := c.do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	// the registry ignores the range header and returns the whole blob
+	if resp.StatusCode != http.StatusPartialContent && (start != 0 || end != blobSize-1) {
+		defer resp.Body.Close()
+		return 0, nil, errors.Errorf("the registry doesn't support pulling the range %d-%d of the blob %s", start, end, digest)
+	}
+
+	var size int64
+	n := resp.Header.Get(http.CanonicalHeaderKey("Content-Length"))
+	if len(n) > 0 {
+		size, err = strconv.ParseInt(n, 10, 64)
+		if err != nil {
+			defer resp.Body.Close()
+			return 0, nil, err
+		}
+	}
+
+	return size, resp.Body, nil
+}
+
+func (c *client) PushBlobChunk(repository, digest string, blobSize int64, chunk io.Reader, start, end int64, location string) (string, int64, error) {
+	var err error
+	if len(location) == 0 {
+		location, _, err = c.initiateBlobUpload(repository)
+		if err != nil {
+			return "", 0, err
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodPatch, buildBlobUploadURL(c.url, location), chunk)
+	if err != nil {
+		return "", 0, err
+	}
+	req.ContentLength = end - start + 1
+	req.Header.Set(http.CanonicalHeaderKey("Content-Type"), "application/octet-stream")
+	req.Header.Set(http.CanonicalHeaderKey("Content-Range"), fmt.Sprintf("%d-%d", start, end))
+	resp, err := c.do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+
+	endRange := end
+	if r := resp.Header.Get(http.CanonicalHeaderKey("Range")); len(r) > 0 {
+		if endRange, err = parseEndRange(r); err != nil {
+			return "", 0, err
+		}
+	}
+	location = resp.Header.Get(http.CanonicalHeaderKey("Location"))
+	// the whole blob is received, complete the upload
+	if endRange == blobSize-1 {
+		if err = c.monolithicBlobUpload(location, digest, 0, nil); err != nil {
+			return "", 0, err
+		}
+		return "", endRange, nil
+	}
+	return location, endRange, nil
+}
+
+func (c *client) GetBlobUploadStatus(location string) (string, int64, error) {
+	req, err := http.NewRequest(http.MethodGet, buildBlobUploadURL(c.url, location), nil)
+	if err != nil {
+		return "", 0, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+
+	endRange, err := parseEndRange(resp.Header.Get(http.CanonicalHeaderKey("Range")))
+	if err != nil {
+		return "", 0, err
+	}
+	// the registry returns "0-0" when nothing is received
+	var offset int64
+	if endRange > 0 {
+		offset = endRange + 1
+	}
+	return resp.Header.Get(http.CanonicalHeaderKey("Location")), offset, nil
+}
+
 func (c *client) initiateBlobUpload(repository string) (string, string, error) {
 	req, err := http.NewRequest(http.MethodPost, buildInitiateBlobUploadURL(c.url, repository), nil)
 	if err != nil {
@@ -582,6 +685,23 @@ func buildInitiateBlobUploadURL(endpoint, repository string) string {
 	return fmt.Sprintf("%s/v2/%s/blobs/uploads/", endpoint, repository)
 }
 
+func buildBlobUploadURL(endpoint, location string) string {
+	if strings.HasPrefix(location, "/") {
+		// the "relativeurls" is enabled in registry
+		return endpoint + location
+	}
+	return location
+}
+
+// parse the end of the range from the "Range" header whose format is "0-<end>"
+func parseEndRange(r string) (int64, error) {
+	strs := strings.SplitN(r, "-", 2)
+	if len(strs) != 2 {
+		return 0, errors.Errorf("invalid range: %s", r)
+	}
+	return strconv.ParseInt(strs[1], 10, 64)
+}
+
 func buildMonolithicBlobUploadURL(endpoint, location, digest string) (string, error) {
 	url, err := url.Parse(location)
 	if err != nil {
@@ -15,6 +15,7 @@
 package registry
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/goharbor/harbor/src/common/utils/test"
@@ -23,6 +24,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -347,6 +349,115 @@ func (c *clientTestSuite) TestPushBlob() {
 	c.Require().Nil(err)
 }
 
+func (c *clientTestSuite) TestPullBlobChunk() {
+	data := []byte{'a', 'b'}
+	server := test.NewServer(
+		&test.RequestHandlerMapping{
+			Method:  "GET",
+			Pattern: "/v2/library/hello-world/blobs/digest",
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				c.Equal("bytes=1-1", r.Header.Get("Range"))
+				w.Header().Set("Content-Length", "1")
+				w.WriteHeader(http.StatusPartialContent)
+				w.Write(data[1:])
+			},
+		},
+		&test.RequestHandlerMapping{
+			Method:  "GET",
+			Pattern: "/v2/library/hello-world/blobs/sha256",
+			Handler: test.Handler(&test.Response{
+				Headers: map[string]string{
+					"Content-Length": strconv.Itoa(len(data)),
+				},
+				Body: data,
+			}),
+		})
+	defer server.Close()
+
+	client := NewClient(server.URL, "", "", true)
+	size, blob, err := client.PullBlobChunk("library/hello-world", "digest", 2, 1, 1)
+	c.Require().Nil(err)
+	c.Equal(int64(1), size)
+	b, err := ioutil.ReadAll(blob)
+	c.Require().Nil(err)
+	c.EqualValues(data[1:], b)
+
+	// the registry doesn't support range requests
+	_, _, err = client.PullBlobChunk("library/hello-world", "sha256", 2, 1, 1)
+	c.NotNil(err)
+}
+
+func (c *clientTestSuite) TestPushBlobChunk() {
+	completed := false
+	server := test.NewServer(
+		&test.RequestHandlerMapping{
+			Method:  "POST",
+			Pattern: "/v2/library/hello-world/blobs/uploads/",
+			Handler: test.Handler(&test.Response{
+				StatusCode: http.StatusAccepted,
+				Headers: map[string]string{
+					"Location": "/v2/library/hello-world/blobs/uploads/uuid",
+				},
+			}),
+		},
+		&test.RequestHandlerMapping{
+			Method:  "PATCH",
+			Pattern: "/v2/library/hello-world/blobs/uploads/uuid",
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				end := strings.SplitN(r.Header.Get("Content-Range"), "-", 2)[1]
+				w.Header().Set("Location", "/v2/library/hello-world/blobs/uploads/uuid")
+				w.Header().Set("Range", "0-"+end)
+				w.WriteHeader(http.StatusAccepted)
+			},
+		},
+		&test.RequestHandlerMapping{
+			Method:  "PUT",
+			Pattern: "/v2/library/hello-world/blobs/uploads/uuid",
+			Handler: func(w http.ResponseWriter, r *http.Request) {
+				c.Equal("digest", r.URL.Query().Get("digest"))
+				completed = true
+				w.WriteHeader(http.StatusCreated)
+			},
+		})
+	defer server.Close()
+
+	client := NewClient(server.URL, "", "", true)
+	// the first chunk
+	location, end, err := client.PushBlobChunk("library/hello-world", "digest", 2, bytes.NewReader([]byte{'a'}), 0, 0, "")
+	c.Require().Nil(err)
+	c.Equal("/v2/library/hello-world/blobs/uploads/uuid", location)
+	c.Equal(int64(0), end)
+	c.False(completed)
+
+	// the last chunk
+	location, end, err = client.PushBlobChunk("library/hello-world", "digest", 2, bytes.NewReader([]byte{'b'}), 1, 1, location)
+	c.Require().Nil(err)
+	c.Empty(location)
+	c.Equal(int64(1), end)
+	c.True(completed)
+}
+
+func (c *clientTestSuite) TestGetBlobUploadStatus() {
+	server := test.NewServer(
+		&test.RequestHandlerMapping{
+			Method:  "GET",
+			Pattern: "/v2/library/hello-world/blobs/uploads/uuid",
+			Handler: test.Handler(&test.Response{
+				StatusCode: http.StatusNoContent,
+				Headers: map[string]string{
+					"Location": "/v2/library/hello-world/blobs/uploads/uuid2",
+					"Range":    "0-9",
+				},
+			}),
+		})
+	defer server.Close()
+
+	location, offset, err := NewClient(server.URL, "", "", true).GetBlobUploadStatus("/v2/library/hello-world/blobs/uploads/uuid")
+	c.Require().Nil(err)
+	c.Equal("/v2/library/hello-world/blobs/uploads/uuid2", location)
+	c.Equal(int64(10), offset)
+}
+
 func (c *clientTestSuite) TestDeleteBlob() {
 	server := test.NewServer(
 		&test.RequestHandlerMapping{
@@ -42,6 +42,10 @@ type Policy struct {
 	CreationTime              time.Time `orm:"column(creation_time);auto_now_add" sort:"default:desc"`
 	UpdateTime                time.Time `orm:"column(update_time);auto_now"`
 	Speed                     int32     `orm:"column(speed_kb)"`
+	BandwidthSchedule         string    `orm:"column(bandwidth_schedule)"`
+	RewriteRules              string    `orm:"column(rewrite_rules)"`
+	Sync                      bool      `orm:"column(sync)"`
+	ConflictResolution        string    `orm:"column(conflict_resolution)"`
 }
 
 // TableName set table name for ORM
@@ -36,12 +36,20 @@ type DAO interface {
 	Delete(ctx context.Context, id int64) error
 	// Get get request instance by id
 	Get(ctx context.Context, id int64) (*models.Request, error)
+	// GetForUpdate get request instance by id and locks it until the transaction ends
+	GetForUpdate(ctx context.Context, id int64) (*models.Request, error)
 	// GetByName get request instance by name
 	GetByName(ctx context.Context, name string) (*models.Request, error)
 	// List list requests
 	List(ctx context.Context, query *q.Query) ([]*models.Request, error)
 	// Update request
 	Update(ctx context.Context, request *models.Request, props ...string) error
+	// CreateHistory create a state transition record of the request
+	CreateHistory(ctx context.Context, history *models.History) (int64, error)
+	// CountHistories returns the total count of the state transition records according to the query
+	CountHistories(ctx context.Context, query *q.Query) (int64, error)
+	// ListHistories list the state transition records according to the query
+	ListHistories(ctx context.Context, query *q.Query) ([]*models.History, error)
 }
 
 // New returns an instance of the default DAO
@@ -125,6 +133,20 @@ func (d *dao) Get(ctx context.Context, id int64) (*models.Request, error) {
 	return request, nil
 }
 
+// GetForUpdate get request instance by id with "SELECT ... FOR UPDATE", it should be called in transaction
+func (d *dao) GetForUpdate(ctx context.Context, id int64) (*models.Request, error) {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &models.Request{RequestID: id, Deleted: false}
+	if err = o.ReadForUpdate(request, "request_id", "deleted"); err != nil {
+		return nil, orm.WrapNotFoundError(err, "request %d not found", id)
+	}
+	return request, nil
+}
+
 // GetByName get request instance by name
 func (d *dao) GetByName(ctx context.Context, name string) (*models.Request, error) {
 	o, err := orm.FromContext(ctx)
@@ -170,3 +192,46 @@ func (d *dao) Update(ctx context.Context, request *models.Request, props ...stri
 	}
 	return nil
 }
+
+// CreateHistory create a state transition record of the request
+func (d *dao) CreateHistory(ctx context.Context, history *models.History) (int64, error) {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	history.CreationTime = time.Now()
+	id, err := o.Insert(history)
+	if err != nil {
+		if e := orm.AsForeignKeyError(err, "the history tries to refer a non existing request %d", history.RequestID); e != nil {
+			err = e
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+// CountHistories returns the total count of the state transition records according to the query
+func (d *dao) CountHistories(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &models.History{}, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return qs.Count()
+}
+
+// ListHistories list the state transition records according to the query
+func (d *dao) ListHistories(ctx context.Context, query *q.Query) ([]*models.History, error) {
+	qs, err := orm.QuerySetter(ctx, &models.History{}, query)
+	if err != nil {
+		return nil, err
+	}
+
+	histories := []*models.History{}
+	if _, err := qs.All(&histories); err != nil {
+		return nil, err
+	}
+
+	return histories, nil
+}
@@ -127,6 +127,15 @@ func (suite *DaoTestSuite) TestGet() {
 	}
 }
 
+func (suite *DaoTestSuite) TestGetForUpdate() {
+	{
+		request, err := suite.dao.GetForUpdate(orm.Context(), 10000)
+		suite.Error(err)
+		suite.True(errors.IsNotFoundErr(err))
+		suite.Nil(request)
+	}
+}
+
 func (suite *DaoTestSuite) TestGetByName() {
 	{
 		// not found
@@ -209,6 +218,45 @@ func (suite *DaoTestSuite) TestListByOwner() {
 	}
 }
 
+func (suite *DaoTestSuite) TestHistory() {
+	request := &models.Request{
+		Name:    "foobar",
+		OwnerID: 1,
+	}
+
+	requestID, err := suite.dao.Create(orm.Context(), request)
+	suite.Nil(err)
+	defer suite.dao.Delete(orm.Context(), requestID)
+
+	request.IsApproved = models.Rejected
+	request.Comment = "the name is reserved"
+	suite.Nil(suite.dao.Update(orm.Context(), request, "IsApproved", "Comment"))
+
+	_, err = suite.dao.CreateHistory(orm.Context(), &models.History{
+		RequestID: requestID,
+		FromState: models.NotDetermined,
+		ToState:   models.Rejected,
+		Operator:  "admin",
+		Comment:   request.Comment,
+	})
+	suite.Nil(err)
+
+	count, err := suite.dao.CountHistories(orm.Context(), q.New(q.KeyWords{"request_id": requestID}))
+	suite.Nil(err)
+	suite.Equal(int64(1), count)
+
+	histories, err := suite.dao.ListHistories(orm.Context(), q.New(q.KeyWords{"request_id": requestID}))
+	suite.Nil(err)
+	suite.Require().Len(histories, 1)
+	suite.Equal(models.Rejected, histories[0].ToState)
+	suite.Equal("the name is reserved", histories[0].Comment)
+
+	r, err := suite.dao.Get(orm.Context(), requestID)
+	suite.Nil(err)
+	suite.Equal(models.Rejected, r.IsApproved)
+	suite.Equal("the name is reserved", r.Comment)
+}
+
 func TestDaoTestSuite(t *testing.T) {
 	suite.Run(t, &DaoTestSuite{})
 }
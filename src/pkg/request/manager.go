@@ -44,14 +44,33 @@ type Manager interface {
 	// Get the request specified by the ID or name
 	Get(ctx context.Context, idOrName interface{}) (*models.Request, error)
 
+	// GetForUpdate gets the request specified by the ID and locks it until the transaction ends,
+	// it should be called in transaction
+	GetForUpdate(ctx context.Context, id int64) (*models.Request, error)
+
 	// List requests according to the query
 	List(ctx context.Context, query *q.Query) ([]*models.Request, error)
 
-	//
+	// Approve marks the request as approved with the comment of the request
 	Approve(ctx context.Context, request *models.Request) error
 
-	//
+	// Reject marks the request as rejected with the comment of the request
 	Reject(ctx context.Context, request *models.Request) error
+
+	// Cancel marks the request as cancelled by the requester
+	Cancel(ctx context.Context, request *models.Request) error
+
+	// Expire marks the request as expired
+	Expire(ctx context.Context, request *models.Request) error
+
+	// CreateHistory create a state transition record of the request
+	CreateHistory(ctx context.Context, history *models.History) (int64, error)
+
+	// CountHistories returns the total count of the state transition records according to the query
+	CountHistories(ctx context.Context, query *q.Query) (int64, error)
+
+	// ListHistories list the state transition records according to the query
+	ListHistories(ctx context.Context, query *q.Query) ([]*models.History, error)
 }
 
 // New returns a default implementation of Manager
@@ -87,6 +106,10 @@ func (m *manager) Create(ctx context.Context, request *models.Request) (int64, e
 		return 0, errors.BadRequestError(nil).WithMessage("request name is not in lower case or contains illegal characters")
 	}
 
+	if err := request.ConvertToDBModel(); err != nil {
+		return 0, err
+	}
+
 	return m.dao.Create(ctx, request)
 }
 
@@ -102,30 +125,93 @@ func (m *manager) Delete(ctx context.Context, id int64) error {
 
 // Get the request specified by the ID
 func (m *manager) Get(ctx context.Context, idOrName interface{}) (*models.Request, error) {
-	id, ok := idOrName.(int64)
-	if ok {
-		return m.dao.Get(ctx, id)
+	var (
+		request *models.Request
+		err     error
+	)
+	switch v := idOrName.(type) {
+	case int64:
+		request, err = m.dao.Get(ctx, v)
+	case string:
+		request, err = m.dao.GetByName(ctx, v)
+	default:
+		return nil, errors.Errorf("invalid parameter: %v, should be ID(int64) or name(string)", idOrName)
 	}
-	name, ok := idOrName.(string)
-	if ok {
-		return m.dao.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errors.Errorf("invalid parameter: %v, should be ID(int64) or name(string)", idOrName)
+
+	if err := request.ConvertFromDBModel(); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// GetForUpdate ...
+func (m *manager) GetForUpdate(ctx context.Context, id int64) (*models.Request, error) {
+	request, err := m.dao.GetForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := request.ConvertFromDBModel(); err != nil {
+		return nil, err
+	}
+	return request, nil
 }
 
 // List requests according to the query
 func (m *manager) List(ctx context.Context, query *q.Query) ([]*models.Request, error) {
-	return m.dao.List(ctx, query)
+	requests, err := m.dao.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, request := range requests {
+		if err := request.ConvertFromDBModel(); err != nil {
+			return nil, err
+		}
+	}
+	return requests, nil
 }
 
+// Approve marks the request as approved with the comment of the request
 func (m *manager) Approve(ctx context.Context, request *models.Request) error {
-	cols := []string{"IsApproved"}
-	request.IsApproved = models.Approved
-	return m.dao.Update(ctx, request, cols...)
+	return m.updateState(ctx, request, models.Approved)
 }
 
+// Reject marks the request as rejected with the comment of the request
 func (m *manager) Reject(ctx context.Context, request *models.Request) error {
-	cols := []string{"IsApproved"}
-	request.IsApproved = models.Rejected
+	return m.updateState(ctx, request, models.Rejected)
+}
+
+// Cancel marks the request as cancelled by the requester
+func (m *manager) Cancel(ctx context.Context, request *models.Request) error {
+	return m.updateState(ctx, request, models.Cancelled)
+}
+
+// Expire marks the request as expired
+func (m *manager) Expire(ctx context.Context, request *models.Request) error {
+	return m.updateState(ctx, request, models.Expired)
+}
+
+// CreateHistory create a state transition record of the request
+func (m *manager) CreateHistory(ctx context.Context, history *models.History) (int64, error) {
+	return m.dao.CreateHistory(ctx, history)
+}
+
+// CountHistories returns the total count of the state transition records according to the query
+func (m *manager) CountHistories(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.CountHistories(ctx, query)
+}
+
+// ListHistories list the state transition records according to the query
+func (m *manager) ListHistories(ctx context.Context, query *q.Query) ([]*models.History, error) {
+	return m.dao.ListHistories(ctx, query)
+}
+
+func (m *manager) updateState(ctx context.Context, request *models.Request, state int) error {
+	cols := []string{"IsApproved", "Comment"}
+	request.IsApproved = state
 	return m.dao.Update(ctx, request, cols...)
 }
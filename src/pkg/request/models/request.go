@@ -16,6 +16,7 @@ package models
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/astaxie/beego/orm"
 	"github.com/lib/pq"
@@ -23,19 +24,40 @@ import (
 	"time"
 )
 
+// the states of the request, only the pending(NotDetermined) request can be transited to the others
 const (
 	NotDetermined = 0
 	Approved      = 1
 	Rejected      = 2
+	Cancelled     = 3
+	Expired       = 4
 )
 
 const (
 	// RequestTable is the table name for request
 	RequestTable = "request"
+	// RequestHistoryTable is the table name for the state transitions of request
+	RequestHistoryTable = "request_history"
 )
 
+var stateNames = map[int]string{
+	NotDetermined: "pending",
+	Approved:      "approved",
+	Rejected:      "rejected",
+	Cancelled:     "cancelled",
+	Expired:       "expired",
+}
+
+// StateName returns the readable name of the request state
+func StateName(state int) string {
+	if name, ok := stateNames[state]; ok {
+		return name
+	}
+	return "unknown"
+}
+
 func init() {
-	orm.RegisterModel(&Request{})
+	orm.RegisterModel(&Request{}, &History{})
 }
 
 // Request holds the details of a project.
@@ -49,6 +71,72 @@ type Request struct {
 	OwnerName    string    `orm:"column(owner_name)" json:"owner_name"`
 	IsApproved   int       `orm:"column(is_approved)" json:"is_approved"`
 	StorageQuota int64     `orm:"column(storage_quota)" json:"storage_quota"`
+	Comment      string    `orm:"column(comment)" json:"comment"`
+	RegistryID   int64     `orm:"column(registry_id)" json:"registry_id"`
+	MetadataDB   string    `orm:"column(metadata)" json:"-"`
+	MembersDB    string    `orm:"column(members)" json:"-"`
+
+	// the settings applied to the project when the request is approved
+	Metadata map[string]string `orm:"-" json:"metadata"`
+	Members  []*Member         `orm:"-" json:"members"`
+}
+
+// Member is the initial member of the requested project, the user or the group
+// is specified by either the ID or the name
+type Member struct {
+	Role        int    `json:"role_id"`
+	UserID      int    `json:"user_id,omitempty"`
+	Username    string `json:"username,omitempty"`
+	GroupID     int    `json:"group_id,omitempty"`
+	GroupName   string `json:"group_name,omitempty"`
+	GroupType   int    `json:"group_type,omitempty"`
+	LdapGroupDN string `json:"ldap_group_dn,omitempty"`
+}
+
+// ConvertToDBModel convert the settings of the request to DB model data
+func (p *Request) ConvertToDBModel() error {
+	if len(p.Metadata) != 0 {
+		metadata, err := json.Marshal(p.Metadata)
+		if err != nil {
+			return err
+		}
+		p.MetadataDB = string(metadata)
+	}
+	if len(p.Members) != 0 {
+		members, err := json.Marshal(p.Members)
+		if err != nil {
+			return err
+		}
+		p.MembersDB = string(members)
+	}
+
+	return nil
+}
+
+// ConvertFromDBModel convert from DB model data to the settings of the request
+func (p *Request) ConvertFromDBModel() error {
+	metadata := map[string]string{}
+	if len(p.MetadataDB) != 0 {
+		if err := json.Unmarshal([]byte(p.MetadataDB), &metadata); err != nil {
+			return err
+		}
+	}
+	p.Metadata = metadata
+
+	members := []*Member{}
+	if len(p.MembersDB) != 0 {
+		if err := json.Unmarshal([]byte(p.MembersDB), &members); err != nil {
+			return err
+		}
+	}
+	p.Members = members
+
+	return nil
+}
+
+// IsPending returns true when the request is waiting for the decision
+func (p *Request) IsPending() bool {
+	return p.IsApproved == NotDetermined
 }
 
 // NamesQuery ...
@@ -103,6 +191,23 @@ func (p *Request) TableName() string {
 	return RequestTable
 }
 
+// History holds a state transition of the request
+type History struct {
+	ID           int64     `orm:"pk;auto;column(id)" json:"id"`
+	RequestID    int64     `orm:"column(request_id)" json:"request_id"`
+	FromState    int       `orm:"column(from_state)" json:"from_state"`
+	ToState      int       `orm:"column(to_state)" json:"to_state"`
+	Operator     string    `orm:"column(operator)" json:"operator"`
+	Comment      string    `orm:"column(comment)" json:"comment"`
+	Rule         string    `orm:"column(rule)" json:"rule"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time" sort:"default"`
+}
+
+// TableName is required by beego orm to map History to table request_history
+func (h *History) TableName() string {
+	return RequestHistoryTable
+}
+
 // Requests the connection for Request
 type Requests []*Request
 
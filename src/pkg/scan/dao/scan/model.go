@@ -124,3 +124,20 @@ func (rvr *ReportVulnerabilityRecord) TableUnique() [][]string {
 func (rvr *ReportVulnerabilityRecord) GetID() int64 {
 	return rvr.ID
 }
+
+// VulnerableArtifact is the artifact containing the vulnerable package.
+// It's the result of searching the vulnerabilities across the artifacts.
+type VulnerableArtifact struct {
+	ArtifactID     int64    `orm:"column(artifact_id)"`
+	ProjectID      int64    `orm:"column(project_id)"`
+	RepositoryName string   `orm:"column(repository_name)"`
+	Digest         string   `orm:"column(digest)"`
+	VulnRecordID   int64    `orm:"column(vuln_record_id)"`
+	CVEID          string   `orm:"column(cve_id)"`
+	Package        string   `orm:"column(package)"`
+	PackageVersion string   `orm:"column(package_version)"`
+	PackageType    string   `orm:"column(package_type)"`
+	Severity       string   `orm:"column(severity)"`
+	Fix            string   `orm:"column(fixed_version)"`
+	CVE3Score      *float64 `orm:"column(cvss_score_v3)"`
+}
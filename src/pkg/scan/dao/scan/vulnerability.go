@@ -16,6 +16,8 @@ package scan
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/log"
@@ -52,6 +54,10 @@ type VulnerabilityRecordDao interface {
 	DeleteForDigests(ctx context.Context, digests ...string) (int64, error)
 	// GetRecordIdsForScanner gets record ids of vulnerability records for a scanner
 	GetRecordIdsForScanner(ctx context.Context, registrationUUID string) ([]int, error)
+	// CountVulnerableArtifacts counts the artifacts containing the vulnerabilities matching the query
+	CountVulnerableArtifacts(ctx context.Context, query *q.Query) (int64, error)
+	// ListVulnerableArtifacts lists the artifacts containing the vulnerabilities matching the query
+	ListVulnerableArtifacts(ctx context.Context, query *q.Query) ([]*VulnerableArtifact, error)
 }
 
 // NewVulnerabilityRecordDao returns a new dao to handle vulnerability data
@@ -244,3 +250,125 @@ func (v *vulnerabilityRecordDao) GetRecordIdsForScanner(ctx context.Context, reg
 	}
 	return vulnRecordIds, err
 }
+
+// the keywords supported when searching the vulnerable artifacts, mapping to the column of the raw SQL
+var vulnerableArtifactKeywords = map[string]string{
+	"cve_id":          "vr.cve_id",
+	"package":         "vr.package",
+	"package_version": "vr.package_version",
+	"severity":        "vr.severity",
+	"project_id":      "a.project_id",
+}
+
+// CountVulnerableArtifacts counts the artifacts containing the vulnerabilities matching the query
+func (v *vulnerabilityRecordDao) CountVulnerableArtifacts(ctx context.Context, query *q.Query) (int64, error) {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	where, params := buildVulnerableArtifactsFilter(query)
+	sql := fmt.Sprintf(`select count(1) from (select distinct a.id as artifact_id, vr.id as vuln_record_id from artifact a
+			  inner join scan_report s on a.digest = s.digest
+			  inner join report_vulnerability_record rvr on s.uuid = rvr.report_uuid
+			  inner join vulnerability_record vr on rvr.vuln_record_id = vr.id %s) as t`, where)
+
+	var count int64
+	if err := o.Raw(sql, params...).QueryRow(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ListVulnerableArtifacts lists the artifacts containing the vulnerabilities matching the query,
+// the results are sorted by the severity score of the vulnerabilities
+func (v *vulnerabilityRecordDao) ListVulnerableArtifacts(ctx context.Context, query *q.Query) ([]*VulnerableArtifact, error) {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	where, params := buildVulnerableArtifactsFilter(query)
+	sql := fmt.Sprintf(`select distinct a.id as artifact_id, a.project_id, a.repository_name, a.digest,
+			  vr.id as vuln_record_id, vr.cve_id, vr.package, vr.package_version, vr.package_type, vr.severity,
+			  coalesce(vr.fixed_version, '') as fixed_version, vr.cvss_score_v3
+			  from artifact a
+			  inner join scan_report s on a.digest = s.digest
+			  inner join report_vulnerability_record rvr on s.uuid = rvr.report_uuid
+			  inner join vulnerability_record vr on rvr.vuln_record_id = vr.id %s
+			  order by vr.cvss_score_v3 desc nulls last, a.id desc, vuln_record_id`, where)
+	sql, params = orm.PaginationOnRawSQL(query, sql, params)
+
+	artifacts := make([]*VulnerableArtifact, 0)
+	if _, err := o.Raw(sql, params...).QueryRows(&artifacts); err != nil {
+		return nil, err
+	}
+
+	return artifacts, nil
+}
+
+// normalizeSeverity converts the severity to the title case to match the stored ones case-insensitively
+func normalizeSeverity(v interface{}) interface{} {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	return strings.Title(strings.ToLower(s))
+}
+
+// buildVulnerableArtifactsFilter builds the where clause of the raw SQL according to the keywords of the query,
+// the value of the keyword can be a single value, a FuzzyMatchValue or an OrList
+func buildVulnerableArtifactsFilter(query *q.Query) (string, []interface{}) {
+	if query == nil || len(query.Keywords) == 0 {
+		return "", nil
+	}
+
+	var (
+		conditions []string
+		params     []interface{}
+	)
+	for key, value := range query.Keywords {
+		col, ok := vulnerableArtifactKeywords[key]
+		if !ok {
+			log.Warningf("unsupported keyword %s to search the vulnerable artifacts, ignore it", key)
+			continue
+		}
+
+		// the severities are stored in the title case, e.g. "Critical"
+		normalize := func(v interface{}) interface{} { return v }
+		if key == "severity" {
+			normalize = normalizeSeverity
+		}
+
+		switch val := value.(type) {
+		case *q.OrList:
+			if len(val.Values) == 0 {
+				// match nothing
+				conditions = append(conditions, "1 = 0")
+				continue
+			}
+			conditions = append(conditions, fmt.Sprintf("%s in (%s)", col, orm.ParamPlaceholderForIn(len(val.Values))))
+			for _, v := range val.Values {
+				params = append(params, normalize(v))
+			}
+		case *q.FuzzyMatchValue:
+			if key == "severity" {
+				conditions = append(conditions, fmt.Sprintf("lower(%s) like ?", col))
+				params = append(params, "%"+orm.Escape(strings.ToLower(val.Value))+"%")
+				continue
+			}
+			conditions = append(conditions, fmt.Sprintf("%s like ?", col))
+			params = append(params, "%"+orm.Escape(val.Value)+"%")
+		default:
+			conditions = append(conditions, fmt.Sprintf("%s = ?", col))
+			params = append(params, normalize(val))
+		}
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return "where " + strings.Join(conditions, " and "), params
+}
@@ -244,6 +244,49 @@ func (suite *VulnerabilityTestSuite) TestListVulnerabilityRecord() {
 	suite.True(len(vulns) > 0)
 }
 
+// TestListVulnerableArtifacts tests searching the artifacts by the vulnerabilities
+func (suite *VulnerabilityTestSuite) TestListVulnerableArtifacts() {
+	sql := `INSERT INTO artifact ("type", media_type, manifest_media_type, digest, project_id, repository_id, repository_name) VALUES ('image', 'media_type', 'manifest_media_type', ?, ?, ?, 'library/hello-world')`
+	suite.ExecSQL(sql, "digest1001", 1, 1)
+	defer suite.ExecSQL(`DELETE FROM artifact WHERE digest = ?`, "digest1001")
+
+	count, err := suite.vulnerabilityRecordDao.CountVulnerableArtifacts(suite.Context(), q.New(q.KeyWords{"cve_id": "CVE-ID2"}))
+	suite.NoError(err)
+	suite.Equal(int64(1), count)
+
+	artifacts, err := suite.vulnerabilityRecordDao.ListVulnerableArtifacts(suite.Context(), q.New(q.KeyWords{"package": "Package2", "package_version": "NotAvailable"}))
+	suite.NoError(err)
+	suite.Require().Len(artifacts, 1)
+	suite.Equal("digest1001", artifacts[0].Digest)
+	suite.Equal("library/hello-world", artifacts[0].RepositoryName)
+	suite.Equal("CVE-ID2", artifacts[0].CVEID)
+	suite.Equal("1.0.0", artifacts[0].Fix)
+
+	count, err = suite.vulnerabilityRecordDao.CountVulnerableArtifacts(suite.Context(), q.New(q.KeyWords{"package": &q.FuzzyMatchValue{Value: "Package1"}}))
+	suite.NoError(err)
+	suite.Equal(int64(2), count)
+
+	count, err = suite.vulnerabilityRecordDao.CountVulnerableArtifacts(suite.Context(), q.New(q.KeyWords{"severity": "High"}))
+	suite.NoError(err)
+	suite.Equal(int64(5), count)
+
+	// the severity is matched case-insensitively
+	count, err = suite.vulnerabilityRecordDao.CountVulnerableArtifacts(suite.Context(), q.New(q.KeyWords{"severity": &q.OrList{Values: []interface{}{"high", "CRITICAL"}}}))
+	suite.NoError(err)
+	suite.Equal(int64(5), count)
+	count, err = suite.vulnerabilityRecordDao.CountVulnerableArtifacts(suite.Context(), q.New(q.KeyWords{"severity": &q.FuzzyMatchValue{Value: "IG"}}))
+	suite.NoError(err)
+	suite.Equal(int64(5), count)
+
+	artifacts, err = suite.vulnerabilityRecordDao.ListVulnerableArtifacts(suite.Context(), &q.Query{Keywords: q.KeyWords{"severity": "High"}, PageNumber: 2, PageSize: 2})
+	suite.NoError(err)
+	suite.Len(artifacts, 2)
+
+	count, err = suite.vulnerabilityRecordDao.CountVulnerableArtifacts(suite.Context(), q.New(q.KeyWords{"severity": "High", "project_id": &q.OrList{Values: []interface{}{999}}}))
+	suite.NoError(err)
+	suite.Equal(int64(0), count)
+}
+
 func (suite *VulnerabilityTestSuite) createReport(r *Report) {
 	id, err := suite.dao.Create(suite.Context(), r)
 	suite.NoError(err)
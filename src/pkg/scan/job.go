@@ -310,6 +310,17 @@ func (j *Job) Run(ctx job.Context, params job.Parameters) error {
 
 		rp := reports[0]
 
+		// The SBOM report is stored as it is
+		if report.IsSBOMMimeType(mimeType) {
+			if err := report.Mgr.UpdateReportData(ctx.SystemContext(), rp.UUID, rawReports[i]); err != nil {
+				myLogger.Errorf("Failed to update SBOM report data for report %s, error %v", rp.UUID, err)
+
+				return err
+			}
+
+			continue
+		}
+
 		logger.Debugf("Converting report ID %s to the new V2 schema", rp.UUID)
 
 		// use a new ormer here to use the short db connection
@@ -0,0 +1,106 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+)
+
+// DAO is the data access object for re-scan policy
+type DAO interface {
+	// Create the re-scan policy
+	Create(ctx context.Context, policy *Policy) (id int64, err error)
+	// Update the re-scan policy, only the properties specified by "props" will be updated if it is set
+	Update(ctx context.Context, policy *Policy, props ...string) (err error)
+	// GetByProject gets the re-scan policy of the project
+	GetByProject(ctx context.Context, projectID int64) (policy *Policy, err error)
+	// DeleteByProject deletes the re-scan policy of the project
+	DeleteByProject(ctx context.Context, projectID int64) (err error)
+}
+
+// NewDAO returns an instance of the default DAO
+func NewDAO() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+// Create ...
+func (d *dao) Create(ctx context.Context, policy *Policy) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	id, err := ormer.Insert(policy)
+	if err != nil {
+		if e := orm.AsConflictError(err, "the re-scan policy of project %d already exists", policy.ProjectID); e != nil {
+			err = e
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+// Update ...
+func (d *dao) Update(ctx context.Context, policy *Policy, props ...string) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.Update(policy, props...)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("re-scan policy %d not found", policy.ID)
+	}
+	return nil
+}
+
+// GetByProject ...
+func (d *dao) GetByProject(ctx context.Context, projectID int64) (*Policy, error) {
+	qs, err := orm.QuerySetter(ctx, &Policy{}, q.New(q.KeyWords{"ProjectID": projectID}))
+	if err != nil {
+		return nil, err
+	}
+	policy := &Policy{}
+	if err := qs.One(policy); err != nil {
+		if e := orm.AsNotFoundError(err, "re-scan policy of project %d not found", projectID); e != nil {
+			err = e
+		}
+		return nil, err
+	}
+	return policy, nil
+}
+
+// DeleteByProject ...
+func (d *dao) DeleteByProject(ctx context.Context, projectID int64) error {
+	qs, err := orm.QuerySetter(ctx, &Policy{}, q.New(q.KeyWords{"ProjectID": projectID}))
+	if err != nil {
+		return err
+	}
+	n, err := qs.Delete()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("re-scan policy of project %d not found", projectID)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+)
+
+// Mgr is the global re-scan policy manager
+var Mgr = NewManager()
+
+// Manager manages the re-scan policies of the projects
+type Manager interface {
+	// Create the re-scan policy
+	Create(ctx context.Context, policy *Policy) (id int64, err error)
+	// Update the re-scan policy, only the properties specified by "props" will be updated if it is set
+	Update(ctx context.Context, policy *Policy, props ...string) (err error)
+	// GetByProject gets the re-scan policy of the project
+	GetByProject(ctx context.Context, projectID int64) (policy *Policy, err error)
+	// DeleteByProject deletes the re-scan policy of the project
+	DeleteByProject(ctx context.Context, projectID int64) (err error)
+}
+
+// NewManager returns an instance of the default manager
+func NewManager() Manager {
+	return &manager{
+		dao: NewDAO(),
+	}
+}
+
+type manager struct {
+	dao DAO
+}
+
+// Create ...
+func (m *manager) Create(ctx context.Context, policy *Policy) (int64, error) {
+	if err := policy.Validate(); err != nil {
+		return 0, err
+	}
+	return m.dao.Create(ctx, policy)
+}
+
+// Update ...
+func (m *manager) Update(ctx context.Context, policy *Policy, props ...string) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	return m.dao.Update(ctx, policy, props...)
+}
+
+// GetByProject ...
+func (m *manager) GetByProject(ctx context.Context, projectID int64) (*Policy, error) {
+	return m.dao.GetByProject(ctx, projectID)
+}
+
+// DeleteByProject ...
+func (m *manager) DeleteByProject(ctx context.Context, projectID int64) error {
+	return m.dao.DeleteByProject(ctx, projectID)
+}
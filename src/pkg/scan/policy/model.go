@@ -0,0 +1,73 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/robfig/cron"
+)
+
+func init() {
+	orm.RegisterModel(&Policy{})
+}
+
+// Policy is the re-scan policy of the project, the artifacts whose scan reports are older than
+// the stale days or generated by an older version of the scanner are re-scanned periodically
+type Policy struct {
+	ID        int64  `orm:"pk;auto;column(id)" json:"id"`
+	ProjectID int64  `orm:"column(project_id)" json:"project_id"`
+	Enabled   bool   `orm:"column(enabled)" json:"enabled"`
+	Cron      string `orm:"column(cron)" json:"cron"`
+	// re-scan the artifacts whose scan reports are older than the days, 0 means not checking the age of the reports
+	StaleDays int `orm:"column(stale_days)" json:"stale_days"`
+	// re-scan the artifacts whose scan reports are generated by an older version of the scanner
+	OutdatedScanner bool `orm:"column(outdated_scanner)" json:"outdated_scanner"`
+	// only re-scan the artifacts pulled in the days, 0 means all the artifacts
+	PulledWithinDays int `orm:"column(pulled_within_days)" json:"pulled_within_days"`
+	// the max count of the scan jobs running concurrently for the project
+	MaxConcurrentJobs int       `orm:"column(max_concurrent_jobs)" json:"max_concurrent_jobs"`
+	CreationTime      time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+	UpdateTime        time.Time `orm:"column(update_time);auto_now" json:"update_time"`
+}
+
+// TableName for re-scan policy
+func (p *Policy) TableName() string {
+	return "scan_policy"
+}
+
+// Validate the policy
+func (p *Policy) Validate() error {
+	if p.StaleDays < 0 || p.PulledWithinDays < 0 {
+		return errors.BadRequestError(nil).WithMessage("the stale days and the pulled within days cannot be negative")
+	}
+	if p.StaleDays == 0 && !p.OutdatedScanner {
+		return errors.BadRequestError(nil).WithMessage("either the stale days or the outdated scanner must be set")
+	}
+	if p.MaxConcurrentJobs <= 0 {
+		return errors.BadRequestError(nil).WithMessage("the max concurrent jobs must be greater than 0")
+	}
+	if p.Enabled && len(p.Cron) == 0 {
+		return errors.BadRequestError(nil).WithMessage("the cron must be set for the enabled policy")
+	}
+	if len(p.Cron) > 0 {
+		if _, err := cron.Parse(p.Cron); err != nil {
+			return errors.BadRequestError(err).WithMessage("invalid cron %s: %v", p.Cron, err)
+		}
+	}
+	return nil
+}
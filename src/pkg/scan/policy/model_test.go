@@ -0,0 +1,72 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+type modelTestSuite struct {
+	suite.Suite
+}
+
+func (m *modelTestSuite) TestValidate() {
+	policy := &Policy{
+		ProjectID:         1,
+		Enabled:           true,
+		Cron:              "0 0 2 * * *",
+		StaleDays:         7,
+		PulledWithinDays:  30,
+		MaxConcurrentJobs: 5,
+	}
+	m.Nil(policy.Validate())
+
+	// only the outdated scanner is checked
+	policy.StaleDays = 0
+	policy.OutdatedScanner = true
+	m.Nil(policy.Validate())
+
+	// nothing to check
+	policy.OutdatedScanner = false
+	m.True(errors.IsErr(policy.Validate(), errors.BadRequestCode))
+	policy.StaleDays = 7
+
+	// negative days
+	policy.PulledWithinDays = -1
+	m.True(errors.IsErr(policy.Validate(), errors.BadRequestCode))
+	policy.PulledWithinDays = 0
+
+	// no concurrent job
+	policy.MaxConcurrentJobs = 0
+	m.True(errors.IsErr(policy.Validate(), errors.BadRequestCode))
+	policy.MaxConcurrentJobs = 1
+
+	// no cron
+	policy.Cron = ""
+	m.True(errors.IsErr(policy.Validate(), errors.BadRequestCode))
+	policy.Enabled = false
+	m.Nil(policy.Validate())
+
+	// invalid cron
+	policy.Cron = "invalid cron"
+	m.True(errors.IsErr(policy.Validate(), errors.BadRequestCode))
+}
+
+func TestModelTestSuite(t *testing.T) {
+	suite.Run(t, &modelTestSuite{})
+}
@@ -101,6 +101,30 @@ type Manager interface {
 	//    []*scan.Report : report list
 	//    error        : non nil error if any errors occurred
 	List(ctx context.Context, query *q.Query) ([]*scan.Report, error)
+
+	// Count the artifacts containing the vulnerabilities matching the query
+	//
+	//  Arguments:
+	//    ctx context.Context : the context for this method
+	//    query *q.Query : the query of the vulnerabilities, "cve_id", "package", "package_version",
+	//                     "severity" and "project_id" are supported
+	//
+	//  Returns:
+	//    int64        : the count of the vulnerable artifacts
+	//    error        : non nil error if any errors occurred
+	CountVulnerableArtifacts(ctx context.Context, query *q.Query) (int64, error)
+
+	// List the artifacts containing the vulnerabilities matching the query
+	//
+	//  Arguments:
+	//    ctx context.Context : the context for this method
+	//    query *q.Query : the query of the vulnerabilities, "cve_id", "package", "package_version",
+	//                     "severity" and "project_id" are supported
+	//
+	//  Returns:
+	//    []*scan.VulnerableArtifact : the vulnerable artifact list
+	//    error        : non nil error if any errors occurred
+	ListVulnerableArtifacts(ctx context.Context, query *q.Query) ([]*scan.VulnerableArtifact, error)
 }
 
 const (
@@ -223,3 +247,11 @@ func (bm *basicManager) DeleteByDigests(ctx context.Context, digests ...string)
 func (bm *basicManager) List(ctx context.Context, query *q.Query) ([]*scan.Report, error) {
 	return bm.dao.List(ctx, query)
 }
+
+func (bm *basicManager) CountVulnerableArtifacts(ctx context.Context, query *q.Query) (int64, error) {
+	return bm.vulnDao.CountVulnerableArtifacts(ctx, query)
+}
+
+func (bm *basicManager) ListVulnerableArtifacts(ctx context.Context, query *q.Query) ([]*scan.VulnerableArtifact, error) {
+	return bm.vulnDao.ListVulnerableArtifacts(ctx, query)
+}
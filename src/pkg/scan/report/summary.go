@@ -28,6 +28,8 @@ import (
 type Options struct {
 	// If it is set, the returned report will contains artifact digest for the vulnerabilities
 	ArtifactDigest string
+	// If it is set, the vulnerabilities in it will be marked as suppressed and not counted in the summary
+	Suppressions vuln.Suppressions
 }
 
 // Option for getting the report w/ summary with func template way.
@@ -40,6 +42,13 @@ func WithArtifactDigest(artifactDigest string) Option {
 	}
 }
 
+// WithSuppressions is an option of setting the suppressed vulnerabilities
+func WithSuppressions(suppressions vuln.Suppressions) Option {
+	return func(options *Options) {
+		options.Suppressions = suppressions
+	}
+}
+
 // SummaryMerger is a helper function to merge summary together
 type SummaryMerger func(s1, s2 interface{}) (interface{}, error)
 
@@ -133,6 +142,12 @@ func GenerateNativeSummary(r *scan.Report, options ...Option) (interface{}, erro
 		return nil, errors.Errorf("type mismatch: expect *vuln.Report but got %s", reflect.TypeOf(raw).String())
 	}
 
+	ops := &Options{}
+	for _, op := range options {
+		op(ops)
+	}
+	rp.Suppress(ops.Suppressions)
+
 	sum.CompleteCount = 1
 	sum.CompletePercent = 100
 	sum.Severity = rp.Severity
@@ -102,6 +102,20 @@ func (suite *SummaryTestSuite) TestSummaryGenerateSummaryNoOptions() {
 	suite.Equal("0.1.0", nativeSummary.Scanner.Version)
 }
 
+// TestSummaryGenerateSummaryWithSuppressions ...
+func (suite *SummaryTestSuite) TestSummaryGenerateSummaryWithSuppressions() {
+	summaries, err := GenerateSummary(suite.r, WithSuppressions(vuln.Suppressions{"2019-0980-0909": {Justification: "component_not_present"}}))
+	require.NoError(suite.T(), err)
+
+	nativeSummary, ok := summaries.(*vuln.NativeReportSummary)
+	require.Equal(suite.T(), true, ok)
+
+	suite.Equal(vuln.Medium, nativeSummary.Severity)
+	suite.Equal(1, nativeSummary.Summary.Total)
+	suite.Equal(1, nativeSummary.Summary.Suppressed)
+	suite.Equal(0, nativeSummary.Summary.Summary[vuln.High])
+}
+
 // TestSummaryGenerateSummaryWrongMime ...
 func (suite *SummaryTestSuite) TestSummaryGenerateSummaryWrongMime() {
 	suite.r.MimeType = "wrong-mime"
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom"
 	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -90,3 +91,24 @@ func (suite *SupportedMimesSuite) TestResolveDataForGenericMimeType() {
 		return
 	})
 }
+
+// TestResolveDataForSBOMMimeType tests the ResolveData for the SBOM report.
+func (suite *SupportedMimesSuite) TestResolveDataForSBOMMimeType() {
+	obj, err := ResolveData(v1.MimeTypeSPDXReport, []byte(`{"spdxVersion": "SPDX-2.2", "name": "photon"}`))
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), obj)
+	require.Condition(suite.T(), func() (success bool) {
+		rp, ok := obj.(*sbom.Report)
+		success = ok && rp != nil && rp.Format() == sbom.FormatSPDX
+
+		return
+	})
+}
+
+// TestIsSBOMMimeType tests the IsSBOMMimeType.
+func (suite *SupportedMimesSuite) TestIsSBOMMimeType() {
+	suite.True(IsSBOMMimeType(v1.MimeTypeSPDXReport))
+	suite.True(IsSBOMMimeType(v1.MimeTypeCycloneDXReport))
+	suite.False(IsSBOMMimeType(v1.MimeTypeNativeReport))
+	suite.False(IsSBOMMimeType(v1.MimeTypeGenericVulnerabilityReport))
+}
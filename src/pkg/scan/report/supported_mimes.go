@@ -20,6 +20,7 @@ import (
 
 	"github.com/goharbor/harbor/src/lib/errors"
 	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	"github.com/goharbor/harbor/src/pkg/scan/sbom"
 	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 )
 
@@ -28,6 +29,27 @@ var SupportedMimes = map[string]interface{}{
 	// The native report type
 	v1.MimeTypeNativeReport:               (*vuln.Report)(nil),
 	v1.MimeTypeGenericVulnerabilityReport: (*vuln.Report)(nil),
+	// The SBOM report types
+	v1.MimeTypeSPDXReport:      (*sbom.Report)(nil),
+	v1.MimeTypeCycloneDXReport: (*sbom.Report)(nil),
+}
+
+// SBOMMimeTypes are the mime types of the SBOM reports.
+var SBOMMimeTypes = []string{
+	v1.MimeTypeSPDXReport,
+	v1.MimeTypeCycloneDXReport,
+}
+
+// IsSBOMMimeType returns true when the mime type is for the SBOM report.
+// The SBOM report is stored as it is and not converted to the vulnerability schema.
+func IsSBOMMimeType(mime string) bool {
+	for _, m := range SBOMMimeTypes {
+		if m == mime {
+			return true
+		}
+	}
+
+	return false
 }
 
 // ResolveData is a helper func to parse the JSON data with the given mime type.
@@ -41,6 +41,10 @@ const (
 	MimeTypeScanResponse = "application/vnd.scanner.adapter.scan.response+json; version=1.0"
 	// MimeTypeGenericVulnerabilityReport defines the MIME type for the generic report with enhanced information
 	MimeTypeGenericVulnerabilityReport = "application/vnd.security.vulnerability.report; version=1.1"
+	// MimeTypeSPDXReport defines the MIME type for the SBOM report in SPDX JSON format
+	MimeTypeSPDXReport = "application/spdx+json"
+	// MimeTypeCycloneDXReport defines the MIME type for the SBOM report in CycloneDX JSON format
+	MimeTypeCycloneDXReport = "application/vnd.cyclonedx+json"
 
 	apiPrefix = "/api/v1"
 )
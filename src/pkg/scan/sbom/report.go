@@ -0,0 +1,56 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+const (
+	// FormatSPDX is the SPDX document format
+	FormatSPDX = "spdx"
+	// FormatCycloneDX is the CycloneDX document format
+	FormatCycloneDX = "cyclonedx"
+)
+
+// Report is the software bill of materials of an artifact produced by the scanner.
+// The document is kept as it is, so both SPDX and CycloneDX JSON documents can be stored.
+type Report map[string]interface{}
+
+// Format returns the format of the SBOM document, empty string is returned when the format is unknown.
+func (r Report) Format() string {
+	if _, ok := r["spdxVersion"]; ok {
+		return FormatSPDX
+	}
+
+	if f, ok := r["bomFormat"].(string); ok && f == "CycloneDX" {
+		return FormatCycloneDX
+	}
+
+	return ""
+}
+
+// Name returns the name of the SBOM document if it's available.
+func (r Report) Name() string {
+	if name, ok := r["name"].(string); ok {
+		return name
+	}
+
+	if md, ok := r["metadata"].(map[string]interface{}); ok {
+		if comp, ok := md["component"].(map[string]interface{}); ok {
+			if name, ok := comp["name"].(string); ok {
+				return name
+			}
+		}
+	}
+
+	return ""
+}
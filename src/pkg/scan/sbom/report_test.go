@@ -0,0 +1,44 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportFormat(t *testing.T) {
+	cases := []struct {
+		name   string
+		data   string
+		format string
+		doc    string
+	}{
+		{"spdx", `{"spdxVersion": "SPDX-2.2", "name": "library/photon"}`, FormatSPDX, "library/photon"},
+		{"cyclonedx", `{"bomFormat": "CycloneDX", "metadata": {"component": {"name": "library/photon"}}}`, FormatCycloneDX, "library/photon"},
+		{"unknown", `{"foo": "bar"}`, "", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var rp Report
+			assert.NoError(t, json.Unmarshal([]byte(c.data), &rp))
+			assert.Equal(t, c.format, rp.Format())
+			assert.Equal(t, c.doc, rp.Name())
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vuln
+
+// SeverityChange is the vulnerability whose severity is changed between the two reports
+type SeverityChange struct {
+	*VulnerabilityItem
+	// The severity of the vulnerability in the base report
+	PreviousSeverity Severity `json:"previous_severity"`
+}
+
+// ReportDiff contains the vulnerabilities added, removed and changed in severity
+// in the target report compared with the base report
+type ReportDiff struct {
+	Added           []*VulnerabilityItem `json:"added"`
+	Removed         []*VulnerabilityItem `json:"removed"`
+	SeverityChanged []*SeverityChange    `json:"severity_changed"`
+}
+
+// Diff compares the vulnerabilities of the target report with the base report.
+// The vulnerabilities are matched by the ID and the package regardless of the package version,
+// so a vulnerability which still exists after upgrading the package is neither added nor removed.
+func Diff(base, target *Report) *ReportDiff {
+	diff := &ReportDiff{
+		Added:           []*VulnerabilityItem{},
+		Removed:         []*VulnerabilityItem{},
+		SeverityChanged: []*SeverityChange{},
+	}
+
+	baseItems, baseKeys := indexByPackage(base)
+	targetItems, targetKeys := indexByPackage(target)
+
+	for _, key := range targetKeys {
+		item := targetItems[key]
+		prev, ok := baseItems[key]
+		if !ok {
+			diff.Added = append(diff.Added, item)
+			continue
+		}
+		if prev.Severity != item.Severity {
+			diff.SeverityChanged = append(diff.SeverityChanged, &SeverityChange{
+				VulnerabilityItem: item,
+				PreviousSeverity:  prev.Severity,
+			})
+		}
+	}
+
+	for _, key := range baseKeys {
+		if _, ok := targetItems[key]; !ok {
+			diff.Removed = append(diff.Removed, baseItems[key])
+		}
+	}
+
+	return diff
+}
+
+// packageKey identifies the vulnerability of the package
+type packageKey struct {
+	ID      string
+	Package string
+}
+
+// indexByPackage indexes the vulnerabilities of the report by the ID and the package,
+// the one with the highest severity is kept when the package has several versions in the report.
+// The keys are returned in the order of the vulnerabilities in the report.
+func indexByPackage(r *Report) (map[packageKey]*VulnerabilityItem, []packageKey) {
+	items := map[packageKey]*VulnerabilityItem{}
+	var keys []packageKey
+	if r == nil {
+		return items, keys
+	}
+
+	for _, item := range r.GetVulnerabilityItemList().Items() {
+		key := packageKey{ID: item.ID, Package: item.Package}
+		existing, ok := items[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		if !ok || item.Severity.Code() > existing.Severity.Code() {
+			items[key] = item
+		}
+	}
+
+	return items, keys
+}
@@ -0,0 +1,114 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vuln
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DiffTestSuite struct {
+	suite.Suite
+}
+
+func (suite *DiffTestSuite) TestDiff() {
+	base := &Report{
+		Vulnerabilities: []*VulnerabilityItem{
+			{ID: "CVE-2021-44228", Package: "log4j-core", Version: "2.14.1", Severity: Critical},
+			{ID: "CVE-2021-45046", Package: "log4j-core", Version: "2.14.1", Severity: Medium},
+			{ID: "CVE-2021-3711", Package: "openssl", Version: "1.1.1k", Severity: High},
+		},
+	}
+	target := &Report{
+		Vulnerabilities: []*VulnerabilityItem{
+			// still exists after upgrading the package, and the severity is changed
+			{ID: "CVE-2021-45046", Package: "log4j-core", Version: "2.15.0", Severity: Critical},
+			// still exists after upgrading the package
+			{ID: "CVE-2021-3711", Package: "openssl", Version: "1.1.1l", Severity: High},
+			{ID: "CVE-2022-0778", Package: "openssl", Version: "1.1.1l", Severity: High},
+		},
+	}
+
+	diff := Diff(base, target)
+	suite.Require().Len(diff.Added, 1)
+	suite.Equal("CVE-2022-0778", diff.Added[0].ID)
+	suite.Require().Len(diff.Removed, 1)
+	suite.Equal("CVE-2021-44228", diff.Removed[0].ID)
+	suite.Require().Len(diff.SeverityChanged, 1)
+	suite.Equal("CVE-2021-45046", diff.SeverityChanged[0].ID)
+	suite.Equal(Critical, diff.SeverityChanged[0].Severity)
+	suite.Equal(Medium, diff.SeverityChanged[0].PreviousSeverity)
+}
+
+func (suite *DiffTestSuite) TestDiffMultipleVersions() {
+	base := &Report{
+		Vulnerabilities: []*VulnerabilityItem{
+			{ID: "CVE-2021-3711", Package: "openssl", Version: "1.1.1k", Severity: Low},
+			{ID: "CVE-2021-3711", Package: "openssl", Version: "1.1.1j", Severity: High},
+		},
+	}
+	target := &Report{
+		Vulnerabilities: []*VulnerabilityItem{
+			{ID: "CVE-2021-3711", Package: "openssl", Version: "1.1.1k", Severity: High},
+		},
+	}
+
+	diff := Diff(base, target)
+	suite.Empty(diff.Added)
+	suite.Empty(diff.Removed)
+	suite.Empty(diff.SeverityChanged)
+}
+
+func (suite *DiffTestSuite) TestDiffNilReport() {
+	target := &Report{
+		Vulnerabilities: []*VulnerabilityItem{
+			{ID: "CVE-2021-3711", Package: "openssl", Version: "1.1.1k", Severity: High},
+		},
+	}
+
+	diff := Diff(nil, target)
+	suite.Len(diff.Added, 1)
+	suite.Empty(diff.Removed)
+
+	diff = Diff(target, nil)
+	suite.Empty(diff.Added)
+	suite.Len(diff.Removed, 1)
+}
+
+func (suite *DiffTestSuite) TestDiffAmbiguousKey() {
+	// the ID and package pairs are different even though they're joined to the same string "CVE-1-a-b"
+	base := &Report{
+		Vulnerabilities: []*VulnerabilityItem{
+			{ID: "CVE-1", Package: "a-b", Version: "1.0", Severity: High},
+		},
+	}
+	target := &Report{
+		Vulnerabilities: []*VulnerabilityItem{
+			{ID: "CVE-1-a", Package: "b", Version: "1.0", Severity: High},
+		},
+	}
+
+	diff := Diff(base, target)
+	suite.Require().Len(diff.Added, 1)
+	suite.Equal("CVE-1-a", diff.Added[0].ID)
+	suite.Require().Len(diff.Removed, 1)
+	suite.Equal("CVE-1", diff.Removed[0].ID)
+	suite.Empty(diff.SeverityChanged)
+}
+
+func TestDiffTestSuite(t *testing.T) {
+	suite.Run(t, &DiffTestSuite{})
+}
@@ -162,12 +162,18 @@ func (l *VulnerabilityItemList) GetSeveritySummary() (Severity, *VulnerabilitySu
 	}
 
 	sum := &VulnerabilitySummary{
-		Total:   len(l.Items()),
 		Summary: make(SeveritySummary),
 	}
 
 	severity := None
 	for _, v := range l.Items() {
+		// the suppressed vulnerabilities are not counted
+		if v.Suppressed {
+			sum.Suppressed++
+			continue
+		}
+		sum.Total++
+
 		if num, ok := sum.Summary[v.Severity]; ok {
 			sum.Summary[v.Severity] = num + 1
 		} else {
@@ -223,6 +229,11 @@ type VulnerabilityItem struct {
 	// A collection of vendor specific attributes for the vulnerability item
 	// with each attribute represented as a key-value pair.
 	VendorAttributes map[string]interface{} `json:"vendor_attributes"`
+	// The vulnerability is suppressed by the VEX statement which declares the artifact is not affected by it
+	Suppressed bool `json:"suppressed,omitempty"`
+	// The justification of the suppression
+	// e.g: vulnerable_code_not_present
+	SuppressionJustification string `json:"suppression_justification,omitempty"`
 }
 
 // Key returns the uniq key for the item
@@ -78,9 +78,10 @@ func (sum *NativeReportSummary) Merge(another *NativeReportSummary) *NativeRepor
 // VulnerabilitySummary contains the total number of the found vulnerabilities number
 // and numbers of each severity level.
 type VulnerabilitySummary struct {
-	Total   int             `json:"total"`
-	Fixable int             `json:"fixable"`
-	Summary SeveritySummary `json:"summary"`
+	Total      int             `json:"total"`
+	Fixable    int             `json:"fixable"`
+	Suppressed int             `json:"suppressed,omitempty"`
+	Summary    SeveritySummary `json:"summary"`
 }
 
 // SeveritySummary ...
@@ -0,0 +1,94 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vuln
+
+import "strings"
+
+// Suppression is the suppression of a vulnerability declared by the VEX statements
+type Suppression struct {
+	// The justification of the suppression
+	Justification string
+	// The packages which the suppression is limited to, the package is either the name
+	// or the package URL of the subcomponent. Empty means all the packages
+	Packages []string
+}
+
+// Covers checks whether the suppression covers the vulnerability found in the specified package.
+func (s *Suppression) Covers(pkg string) bool {
+	if len(s.Packages) == 0 {
+		return true
+	}
+	for _, p := range s.Packages {
+		if p == pkg {
+			return true
+		}
+		// the scanners name the packages in different ways, e.g. "openssl" for "pkg:deb/debian/openssl"
+		// and "org.apache.logging.log4j:log4j-core" for "pkg:maven/org.apache.logging.log4j/log4j-core"
+		name := purlName(p)
+		if name == pkg || name[strings.LastIndex(name, "/")+1:] == pkg || strings.ReplaceAll(name, "/", ":") == pkg {
+			return true
+		}
+	}
+	return false
+}
+
+// purlName returns the name of the package URL including the namespace, e.g. "github.com/gin-gonic/gin"
+// for "pkg:golang/github.com/gin-gonic/gin@v1.7.0". The value is returned as is if it isn't a package URL
+func purlName(purl string) string {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return purl
+	}
+	name := strings.TrimPrefix(purl, "pkg:")
+	// strip the subpath, qualifiers and version
+	for _, sep := range []string{"#", "?", "@"} {
+		if i := strings.Index(name, sep); i >= 0 {
+			name = name[:i]
+		}
+	}
+	// strip the type
+	if i := strings.Index(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// Suppressions is the set of the suppressed vulnerabilities, the key is the ID of the vulnerability.
+type Suppressions map[string]*Suppression
+
+// Contains checks whether the specified vulnerability is suppressed.
+func (s Suppressions) Contains(id string) bool {
+	_, ok := s[id]
+
+	return ok
+}
+
+// Suppress marks the vulnerabilities in the list which are in the suppressions as suppressed.
+func (l *VulnerabilityItemList) Suppress(s Suppressions) {
+	if l == nil || len(s) == 0 {
+		return
+	}
+
+	for _, item := range l.Items() {
+		if suppression, ok := s[item.ID]; ok && suppression.Covers(item.Package) {
+			item.Suppressed = true
+			item.SuppressionJustification = suppression.Justification
+		}
+	}
+}
+
+// Suppress marks the vulnerabilities in the report which are in the suppressions as suppressed.
+func (report *Report) Suppress(s Suppressions) {
+	report.GetVulnerabilityItemList().Suppress(s)
+}
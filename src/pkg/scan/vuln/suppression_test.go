@@ -0,0 +1,93 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vuln
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SuppressionTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SuppressionTestSuite) TestSuppress() {
+	report := &Report{
+		Vulnerabilities: []*VulnerabilityItem{
+			{ID: "CVE-2021-44228", Package: "log4j-core", Version: "2.14.1", Severity: Critical},
+			{ID: "CVE-2021-45046", Package: "log4j-core", Version: "2.14.1", Severity: High, FixVersion: "2.16.0"},
+			{ID: "CVE-2021-3711", Package: "openssl", Version: "1.1.1k", Severity: Medium},
+		},
+	}
+
+	report.Suppress(Suppressions{"CVE-2021-44228": {Justification: "vulnerable_code_not_in_execute_path"}})
+
+	suite.True(report.Vulnerabilities[0].Suppressed)
+	suite.Equal("vulnerable_code_not_in_execute_path", report.Vulnerabilities[0].SuppressionJustification)
+	suite.False(report.Vulnerabilities[1].Suppressed)
+
+	severity, sum := report.GetVulnerabilityItemList().GetSeveritySummary()
+	suite.Equal(High, severity)
+	suite.Equal(2, sum.Total)
+	suite.Equal(1, sum.Fixable)
+	suite.Equal(1, sum.Suppressed)
+	suite.Equal(0, sum.Summary[Critical])
+}
+
+func (suite *SuppressionTestSuite) TestSuppressNothing() {
+	var l *VulnerabilityItemList
+	l.Suppress(Suppressions{"CVE-2021-44228": {}})
+
+	report := &Report{Vulnerabilities: []*VulnerabilityItem{{ID: "CVE-2021-44228", Severity: Critical}}}
+	report.Suppress(nil)
+	suite.False(report.Vulnerabilities[0].Suppressed)
+}
+
+func (suite *SuppressionTestSuite) TestSuppressPackages() {
+	report := &Report{
+		Vulnerabilities: []*VulnerabilityItem{
+			{ID: "CVE-2022-1304", Package: "e2fsprogs", Version: "1.46.2", Severity: High},
+			{ID: "CVE-2022-1304", Package: "libcom-err2", Version: "1.46.2", Severity: High},
+			{ID: "CVE-2022-1304", Package: "libss2", Version: "1.46.2", Severity: High},
+			{ID: "CVE-2021-44228", Package: "org.apache.logging.log4j:log4j-core", Version: "2.14.1", Severity: Critical},
+			{ID: "CVE-2021-44228", Package: "org.apache.logging.log4j:log4j-api", Version: "2.14.1", Severity: Critical},
+		},
+	}
+
+	report.Suppress(Suppressions{"CVE-2022-1304": {
+		Justification: "vulnerable_code_not_present",
+		Packages:      []string{"e2fsprogs", "pkg:deb/debian/libcom-err2@1.46.2-2?arch=amd64"},
+	}, "CVE-2021-44228": {
+		Packages: []string{"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"},
+	}})
+
+	suite.True(report.Vulnerabilities[0].Suppressed)
+	suite.True(report.Vulnerabilities[1].Suppressed)
+	suite.False(report.Vulnerabilities[2].Suppressed)
+	suite.True(report.Vulnerabilities[3].Suppressed)
+	suite.False(report.Vulnerabilities[4].Suppressed)
+}
+
+func (suite *SuppressionTestSuite) TestPurlName() {
+	suite.Equal("github.com/gin-gonic/gin", purlName("pkg:golang/github.com/gin-gonic/gin@v1.7.0"))
+	suite.Equal("debian/openssl", purlName("pkg:deb/debian/openssl@1.1.1k?arch=amd64"))
+	suite.Equal("lodash", purlName("pkg:npm/lodash@4.17.20#dist"))
+	suite.Equal("openssl", purlName("openssl"))
+}
+
+func TestSuppressionTestSuite(t *testing.T) {
+	suite.Run(t, &SuppressionTestSuite{})
+}
@@ -0,0 +1,79 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+const pemTypePublicKey = "PUBLIC KEY"
+
+// ParsePublicKeys parses the PEM encoded public keys, multiple keys can be concatenated.
+// Only the ECDSA, RSA and ED25519 keys which are supported by cosign are accepted
+func ParsePublicKeys(data string) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	rest := []byte(strings.TrimSpace(data))
+	for len(rest) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("invalid PEM encoded public key")
+		}
+		if block.Type != pemTypePublicKey {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("unsupported PEM block type: %s", block.Type)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.New(err).WithCode(errors.BadRequestCode).WithMessage("failed to parse the public key: %v", err)
+		}
+		switch key.(type) {
+		case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		default:
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("unsupported public key type: %T", key)
+		}
+		keys = append(keys, key)
+		rest = []byte(strings.TrimSpace(string(rest)))
+	}
+	if len(keys) == 0 {
+		return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("no public key provided")
+	}
+	return keys, nil
+}
+
+// verify the signature of the payload with the public key in the same way as cosign:
+// ECDSA and RSA keys sign the SHA256 digest of the payload, ED25519 keys sign the payload directly
+func verify(key crypto.PublicKey, payload, signature []byte) bool {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		hash := sha256.Sum256(payload)
+		return ecdsa.VerifyASN1(k, hash[:], signature)
+	case *rsa.PublicKey:
+		hash := sha256.Sum256(payload)
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, payload, signature)
+	default:
+		return false
+	}
+}
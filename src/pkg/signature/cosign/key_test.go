@@ -0,0 +1,88 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func encodePublicKey(key interface{}) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		panic(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: pemTypePublicKey, Bytes: der}))
+}
+
+type keyTestSuite struct {
+	suite.Suite
+}
+
+func (k *keyTestSuite) TestParsePublicKeys() {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	k.Require().Nil(err)
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	k.Require().Nil(err)
+
+	// empty
+	_, err = ParsePublicKeys("")
+	k.NotNil(err)
+
+	// invalid PEM
+	_, err = ParsePublicKeys("invalid")
+	k.NotNil(err)
+
+	// unsupported PEM block type
+	_, err = ParsePublicKeys(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")})))
+	k.NotNil(err)
+
+	// one key
+	keys, err := ParsePublicKeys(encodePublicKey(&ecKey.PublicKey))
+	k.Require().Nil(err)
+	k.Len(keys, 1)
+
+	// multiple keys
+	keys, err = ParsePublicKeys(encodePublicKey(&ecKey.PublicKey) + "\n" + encodePublicKey(edKey))
+	k.Require().Nil(err)
+	k.Len(keys, 2)
+}
+
+func (k *keyTestSuite) TestVerify() {
+	payload := []byte("payload")
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	k.Require().Nil(err)
+	signature, err := signECDSA(ecKey, payload)
+	k.Require().Nil(err)
+	k.True(verify(&ecKey.PublicKey, payload, signature))
+	k.False(verify(&ecKey.PublicKey, []byte("another payload"), signature))
+
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	k.Require().Nil(err)
+	k.True(verify(edPub, payload, ed25519.Sign(edKey, payload)))
+	k.False(verify(edPub, payload, signature))
+}
+
+func TestKeyTestSuite(t *testing.T) {
+	suite.Run(t, &keyTestSuite{})
+}
@@ -0,0 +1,267 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/goharbor/harbor/src/lib/cache"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/pkg/registry"
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// SignatureAnnotation is the annotation of the layer in cosign signature manifest which contains
+	// the base64 encoded signature of the layer content
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// the max size of the simple signing payload, the payload is a small json document
+	maxPayloadSize = 1 << 20
+	// the prefix of the cache key of verification result
+	cacheKeyPrefix = "cosign:verification"
+	// the expiration of the cached verification result
+	cacheExpiration = 24 * time.Hour
+)
+
+var (
+	// the media types of the layers that cosign produces for each type of accessories
+	accessoryLayerMediaTypes = map[string]map[string]struct{}{
+		accessorymodel.TypeCosignSignature: {
+			"application/vnd.dev.cosign.simplesigning.v1+json": {},
+		},
+		accessorymodel.TypeCosignAttestation: {
+			"application/vnd.dsse.envelope.v1+json": {},
+		},
+		accessorymodel.TypeCosignSBOM: {
+			"text/spdx":                      {},
+			"text/spdx+json":                 {},
+			"application/vnd.cyclonedx":      {},
+			"application/vnd.cyclonedx+json": {},
+			"application/vnd.cyclonedx+xml":  {},
+			"application/vnd.syft+json":      {},
+		},
+	}
+)
+
+// simpleSigning is the payload signed by cosign, only the fields used in the verification are defined
+type simpleSigning struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// Verifier verifies the cosign signatures of artifacts
+type Verifier interface {
+	// Verify checks whether the artifact has a valid cosign signature signed by one of the PEM encoded public keys.
+	// The signatures are looked up in the accessories of the artifact
+	Verify(ctx context.Context, art *artifact.Artifact, publicKeys string) (bool, error)
+	// IsAccessory checks whether the artifact is the signature, attestation or SBOM attached by cosign.
+	// Only the artifacts whose layers are all in the media types produced by cosign are treated as accessories,
+	// so the images tagged or referring the subject in the cosign style aren't counted
+	IsAccessory(ctx context.Context, art *artifact.Artifact) (bool, error)
+}
+
+// NewVerifier returns an instance of the default verifier, the verification results are cached
+// in the default cache if it's initialized
+func NewVerifier() Verifier {
+	return &verifier{
+		accessoryMgr: accessory.Mgr,
+		regCli:       registry.Cli,
+		cache:        cache.Default(),
+	}
+}
+
+type verifier struct {
+	accessoryMgr accessory.Manager
+	regCli       registry.Client
+	cache        cache.Cache
+}
+
+func (v *verifier) Verify(ctx context.Context, art *artifact.Artifact, publicKeys string) (bool, error) {
+	signatures, err := v.accessoryMgr.List(ctx, q.New(q.KeyWords{
+		"SubjectArtifactID": art.ID,
+		"Type":              accessorymodel.TypeCosignSignature,
+	}))
+	if err != nil {
+		return false, err
+	}
+	if len(signatures) == 0 {
+		return false, nil
+	}
+
+	// the signatures and the public keys are part of the cache key, so adding signatures
+	// or changing the keys of project makes the cached result outdated automatically
+	key := cacheKey(art.Digest, publicKeys, signatures)
+	if v.cache != nil {
+		var verified bool
+		if err := v.cache.Fetch(key, &verified); err == nil {
+			return verified, nil
+		}
+	}
+
+	keys, err := ParsePublicKeys(publicKeys)
+	if err != nil {
+		return false, err
+	}
+
+	verified := false
+	for _, sig := range signatures {
+		verified, err = v.verifySignatureArtifact(art.RepositoryName, sig.Digest, art.Digest, keys)
+		if err != nil {
+			// don't cache the result when got error
+			return false, err
+		}
+		if verified {
+			break
+		}
+	}
+
+	if v.cache != nil {
+		if err := v.cache.Save(key, verified, cacheExpiration); err != nil {
+			log.G(ctx).Warningf("failed to cache the cosign verification result of %s@%s: %v", art.RepositoryName, art.Digest, err)
+		}
+	}
+	return verified, nil
+}
+
+func (v *verifier) IsAccessory(ctx context.Context, art *artifact.Artifact) (bool, error) {
+	accessories, err := v.accessoryMgr.List(ctx, q.New(q.KeyWords{"ArtifactID": art.ID}))
+	if err != nil {
+		return false, err
+	}
+	for _, acc := range accessories {
+		mediaTypes, exist := accessoryLayerMediaTypes[acc.Type]
+		if !exist {
+			continue
+		}
+		mani, _, err := v.regCli.PullManifest(art.RepositoryName, art.Digest)
+		if err != nil {
+			return false, err
+		}
+		mediaType, content, err := mani.Payload()
+		if err != nil {
+			return false, err
+		}
+		if mediaType != v1.MediaTypeImageManifest && mediaType != schema2.MediaTypeManifest {
+			return false, nil
+		}
+		manifest := &v1.Manifest{}
+		if err = json.Unmarshal(content, manifest); err != nil {
+			return false, err
+		}
+		if len(manifest.Layers) == 0 {
+			return false, nil
+		}
+		for _, layer := range manifest.Layers {
+			if _, exist := mediaTypes[layer.MediaType]; !exist {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// verify the signatures contained in the layers of the cosign signature manifest
+func (v *verifier) verifySignatureArtifact(repository, sigDigest, subjectDigest string, keys []crypto.PublicKey) (bool, error) {
+	mani, _, err := v.regCli.PullManifest(repository, sigDigest)
+	if err != nil {
+		return false, err
+	}
+	_, content, err := mani.Payload()
+	if err != nil {
+		return false, err
+	}
+	manifest := &v1.Manifest{}
+	if err = json.Unmarshal(content, manifest); err != nil {
+		return false, err
+	}
+
+	for _, layer := range manifest.Layers {
+		encoded, exist := layer.Annotations[SignatureAnnotation]
+		if !exist {
+			continue
+		}
+		signature, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			log.Warningf("invalid cosign signature in layer %s of %s@%s: %v", layer.Digest, repository, sigDigest, err)
+			continue
+		}
+		payload, err := v.pullPayload(repository, layer.Digest)
+		if err != nil {
+			return false, err
+		}
+		// make sure the payload is the one stored in the layer and signs the subject artifact
+		if digest.FromBytes(payload) != layer.Digest {
+			log.Warningf("the payload of layer %s of %s@%s is corrupted", layer.Digest, repository, sigDigest)
+			continue
+		}
+		ss := &simpleSigning{}
+		if err = json.Unmarshal(payload, ss); err != nil {
+			log.Warningf("invalid simple signing payload in layer %s of %s@%s: %v", layer.Digest, repository, sigDigest, err)
+			continue
+		}
+		if ss.Critical.Image.DockerManifestDigest != subjectDigest {
+			continue
+		}
+		for _, key := range keys {
+			if verify(key, payload, signature) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (v *verifier) pullPayload(repository string, dgt digest.Digest) ([]byte, error) {
+	_, blob, err := v.regCli.PullBlob(repository, dgt.String())
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Close()
+	return ioutil.ReadAll(io.LimitReader(blob, maxPayloadSize))
+}
+
+func cacheKey(subjectDigest, publicKeys string, signatures []*accessorymodel.Accessory) string {
+	var digests []string
+	for _, sig := range signatures {
+		digests = append(digests, sig.Digest)
+	}
+	sort.Strings(digests)
+
+	hash := sha256.New()
+	hash.Write([]byte(publicKeys))
+	for _, d := range digests {
+		hash.Write([]byte(d))
+	}
+	return fmt.Sprintf("%s:%s:%x", cacheKeyPrefix, subjectDigest, hash.Sum(nil))
+}
@@ -0,0 +1,201 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/distribution"
+	_ "github.com/docker/distribution/manifest/ocischema"
+	"github.com/goharbor/harbor/src/lib/cache"
+	_ "github.com/goharbor/harbor/src/lib/cache/memory"
+	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/testing/mock"
+	accessorytesting "github.com/goharbor/harbor/src/testing/pkg/accessory"
+	"github.com/goharbor/harbor/src/testing/pkg/registry"
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+)
+
+func signECDSA(key *ecdsa.PrivateKey, payload []byte) ([]byte, error) {
+	hash := sha256.Sum256(payload)
+	return ecdsa.SignASN1(rand.Reader, key, hash[:])
+}
+
+type verifierTestSuite struct {
+	suite.Suite
+	accessoryMgr *accessorytesting.Manager
+	regCli       *registry.FakeClient
+	verifier     *verifier
+	key          *ecdsa.PrivateKey
+	art          *artifact.Artifact
+}
+
+func (v *verifierTestSuite) SetupTest() {
+	v.accessoryMgr = &accessorytesting.Manager{}
+	v.regCli = &registry.FakeClient{}
+	c, err := cache.New(cache.Memory)
+	v.Require().Nil(err)
+	v.verifier = &verifier{
+		accessoryMgr: v.accessoryMgr,
+		regCli:       v.regCli,
+		cache:        c,
+	}
+	v.key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	v.Require().Nil(err)
+	v.art = &artifact.Artifact{
+		ID:             1,
+		RepositoryName: "library/hello-world",
+		Digest:         "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180",
+	}
+}
+
+// mock the signature manifest and the payload signed by the key
+func (v *verifierTestSuite) mockSignature(key *ecdsa.PrivateKey, subjectDigest string) {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"library/hello-world"},`+
+		`"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, subjectDigest))
+	signature, err := signECDSA(key, payload)
+	v.Require().Nil(err)
+	content := fmt.Sprintf(`{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "config": {
+    "mediaType": "application/vnd.oci.image.config.v1+json",
+    "size": 233,
+    "digest": "sha256:d4e6059ece7bea95266fd7766353130d4bf3dc21048b8a9783c98b8412618c38"
+  },
+  "layers": [
+    {
+      "mediaType": "application/vnd.dev.cosign.simplesigning.v1+json",
+      "size": %d,
+      "digest": "%s",
+      "annotations": {
+        "%s": "%s"
+      }
+    }
+  ]
+}`, len(payload), digest.FromBytes(payload), SignatureAnnotation, base64.StdEncoding.EncodeToString(signature))
+	manifest, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, []byte(content))
+	v.Require().Nil(err)
+	v.regCli.On("PullManifest").Return(manifest, "", nil)
+	v.regCli.On("PullBlob").Return(len(payload), ioutil.NopCloser(bytes.NewReader(payload)), nil)
+}
+
+func (v *verifierTestSuite) TestNoSignature() {
+	mock.OnAnything(v.accessoryMgr, "List").Return([]*accessorymodel.Accessory{}, nil)
+	verified, err := v.verifier.Verify(nil, v.art, encodePublicKey(&v.key.PublicKey))
+	v.Require().Nil(err)
+	v.False(verified)
+}
+
+func (v *verifierTestSuite) TestVerified() {
+	mock.OnAnything(v.accessoryMgr, "List").Return([]*accessorymodel.Accessory{
+		{ArtifactID: 2, SubjectArtifactID: 1, Type: accessorymodel.TypeCosignSignature, Digest: "sha256:signature"},
+	}, nil)
+	v.mockSignature(v.key, v.art.Digest)
+	verified, err := v.verifier.Verify(nil, v.art, encodePublicKey(&v.key.PublicKey))
+	v.Require().Nil(err)
+	v.True(verified)
+
+	// the result is cached
+	verified, err = v.verifier.Verify(nil, v.art, encodePublicKey(&v.key.PublicKey))
+	v.Require().Nil(err)
+	v.True(verified)
+	v.regCli.AssertNumberOfCalls(v.T(), "PullManifest", 1)
+}
+
+func (v *verifierTestSuite) TestSignedByUnknownKey() {
+	mock.OnAnything(v.accessoryMgr, "List").Return([]*accessorymodel.Accessory{
+		{ArtifactID: 2, SubjectArtifactID: 1, Type: accessorymodel.TypeCosignSignature, Digest: "sha256:signature"},
+	}, nil)
+	another, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	v.Require().Nil(err)
+	v.mockSignature(another, v.art.Digest)
+	verified, err := v.verifier.Verify(nil, v.art, encodePublicKey(&v.key.PublicKey))
+	v.Require().Nil(err)
+	v.False(verified)
+}
+
+func (v *verifierTestSuite) TestSignatureOfAnotherArtifact() {
+	mock.OnAnything(v.accessoryMgr, "List").Return([]*accessorymodel.Accessory{
+		{ArtifactID: 2, SubjectArtifactID: 1, Type: accessorymodel.TypeCosignSignature, Digest: "sha256:signature"},
+	}, nil)
+	v.mockSignature(v.key, "sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f")
+	verified, err := v.verifier.Verify(nil, v.art, encodePublicKey(&v.key.PublicKey))
+	v.Require().Nil(err)
+	v.False(verified)
+}
+
+func (v *verifierTestSuite) TestIsAccessory() {
+	mock.OnAnything(v.accessoryMgr, "List").Return([]*accessorymodel.Accessory{
+		{ArtifactID: 1, SubjectArtifactID: 2, Type: accessorymodel.TypeCosignSignature, Digest: v.art.Digest},
+	}, nil)
+	v.mockSignature(v.key, "sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f")
+	is, err := v.verifier.IsAccessory(nil, v.art)
+	v.Require().Nil(err)
+	v.True(is)
+}
+
+func (v *verifierTestSuite) TestIsAccessoryWithImageLayers() {
+	mock.OnAnything(v.accessoryMgr, "List").Return([]*accessorymodel.Accessory{
+		{ArtifactID: 1, SubjectArtifactID: 2, Type: accessorymodel.TypeCosignSignature, Digest: v.art.Digest},
+	}, nil)
+	content := `{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "config": {
+    "mediaType": "application/vnd.oci.image.config.v1+json",
+    "size": 233,
+    "digest": "sha256:d4e6059ece7bea95266fd7766353130d4bf3dc21048b8a9783c98b8412618c38"
+  },
+  "layers": [
+    {
+      "mediaType": "application/vnd.oci.image.layer.v1.tar+gzip",
+      "size": 2479,
+      "digest": "sha256:b04784fba78d739b526e27edc02a5a8cd07b1052e9283f5fc155828f4b614c28"
+    }
+  ]
+}`
+	manifest, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, []byte(content))
+	v.Require().Nil(err)
+	v.regCli.On("PullManifest").Return(manifest, "", nil)
+	is, err := v.verifier.IsAccessory(nil, v.art)
+	v.Require().Nil(err)
+	v.False(is)
+}
+
+func (v *verifierTestSuite) TestIsAccessoryOfSubject() {
+	mock.OnAnything(v.accessoryMgr, "List").Return([]*accessorymodel.Accessory{
+		{ArtifactID: 1, SubjectArtifactID: 2, Type: accessorymodel.TypeSubject, Digest: v.art.Digest},
+	}, nil)
+	is, err := v.verifier.IsAccessory(nil, v.art)
+	v.Require().Nil(err)
+	v.False(is)
+	v.regCli.AssertNotCalled(v.T(), "PullManifest")
+}
+
+func TestVerifierTestSuite(t *testing.T) {
+	suite.Run(t, &verifierTestSuite{})
+}
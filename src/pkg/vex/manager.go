@@ -0,0 +1,202 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vex
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	"github.com/goharbor/harbor/src/pkg/vex/dao"
+	"github.com/goharbor/harbor/src/pkg/vex/model"
+)
+
+// Mgr is the global VEX document manager instance
+var Mgr = New()
+
+// Manager is used for VEX document management
+type Manager interface {
+	// Create parses the content of the VEX document and creates it
+	Create(ctx context.Context, doc *model.Document) (id int64, err error)
+	// Count returns the total count of VEX documents according to the query
+	Count(ctx context.Context, query *q.Query) (total int64, err error)
+	// List VEX documents according to the query
+	List(ctx context.Context, query *q.Query) (docs []*model.Document, err error)
+	// Get the VEX document specified by ID
+	Get(ctx context.Context, id int64) (doc *model.Document, err error)
+	// Delete the VEX document specified by ID
+	Delete(ctx context.Context, id int64) (err error)
+	// GetSuppressions returns the vulnerabilities declared as not affected by the VEX documents
+	// of the repository and the artifact specified by the digest
+	GetSuppressions(ctx context.Context, repositoryName, digest string) (suppressions vuln.Suppressions, err error)
+}
+
+// New returns a default implementation of Manager
+func New() Manager {
+	return &manager{
+		dao: dao.New(),
+	}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+// Create ...
+func (m *manager) Create(ctx context.Context, doc *model.Document) (int64, error) {
+	format, statements, err := Parse([]byte(doc.Content))
+	if err != nil {
+		return 0, err
+	}
+	data, err := json.Marshal(statements)
+	if err != nil {
+		return 0, err
+	}
+	doc.Format = format
+	doc.Statements = statements
+	doc.StatementsText = string(data)
+	return m.dao.Create(ctx, doc)
+}
+
+// Count ...
+func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.Count(ctx, query)
+}
+
+// List ...
+func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.Document, error) {
+	docs, err := m.dao.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		if err := decodeStatements(doc); err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
+
+// Get ...
+func (m *manager) Get(ctx context.Context, id int64) (*model.Document, error) {
+	doc, err := m.dao.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := decodeStatements(doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Delete ...
+func (m *manager) Delete(ctx context.Context, id int64) error {
+	return m.dao.Delete(ctx, id)
+}
+
+// GetSuppressions ...
+func (m *manager) GetSuppressions(ctx context.Context, repositoryName, digest string) (vuln.Suppressions, error) {
+	query := q.New(q.KeyWords{
+		"repository_name": repositoryName,
+		"digest":          &q.OrList{Values: []interface{}{"", digest}},
+	})
+	docs, err := m.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	suppressions := vuln.Suppressions{}
+	// the documents are sorted by the creation time in descending order, so the statement
+	// in the newer document overrides the one in the older document. The vulnerability is
+	// decided either for all the subcomponents or per subcomponent
+	decided := map[string]bool{}
+	decidedSubcomponents := map[string]map[string]bool{}
+	for _, doc := range docs {
+		for _, st := range doc.Statements {
+			id := st.VulnerabilityID
+			if decided[id] || !appliesTo(st, digest) {
+				continue
+			}
+			notAffected := st.Status == model.StatusNotAffected
+			if len(st.Subcomponents) == 0 {
+				decided[id] = true
+				if !notAffected {
+					continue
+				}
+				// don't widen the suppression over the subcomponents declared as affected by newer statements
+				if affectedSubcomponent(decidedSubcomponents[id]) {
+					continue
+				}
+				suppressions[id] = &vuln.Suppression{Justification: st.Justification}
+				continue
+			}
+			if decidedSubcomponents[id] == nil {
+				decidedSubcomponents[id] = map[string]bool{}
+			}
+			for _, sub := range st.Subcomponents {
+				if _, exist := decidedSubcomponents[id][sub]; exist {
+					continue
+				}
+				decidedSubcomponents[id][sub] = notAffected
+				if !notAffected {
+					continue
+				}
+				if _, exist := suppressions[id]; !exist {
+					suppressions[id] = &vuln.Suppression{Justification: st.Justification}
+				}
+				suppressions[id].Packages = append(suppressions[id].Packages, sub)
+			}
+		}
+	}
+	return suppressions, nil
+}
+
+// appliesTo checks whether the statement applies to the artifact specified by the digest.
+// The statement without products applies to all the artifacts that the document attached to,
+// otherwise one of the products must identify the artifact by digest, e.g. "pkg:oci/app@sha256%3A..."
+func appliesTo(st *model.Statement, digest string) bool {
+	if len(st.Products) == 0 {
+		return true
+	}
+	for _, product := range st.Products {
+		if unescaped, err := url.PathUnescape(product); err == nil {
+			product = unescaped
+		}
+		if strings.Contains(product, digest) {
+			return true
+		}
+	}
+	return false
+}
+
+func affectedSubcomponent(subcomponents map[string]bool) bool {
+	for _, notAffected := range subcomponents {
+		if !notAffected {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeStatements(doc *model.Document) error {
+	doc.Statements = []*model.Statement{}
+	if len(doc.StatementsText) == 0 {
+		return nil
+	}
+	return json.Unmarshal([]byte(doc.StatementsText), &doc.Statements)
+}
@@ -0,0 +1,154 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	"github.com/goharbor/harbor/src/pkg/vex/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeDao struct {
+	mock.Mock
+}
+
+func (f *fakeDao) Create(ctx context.Context, doc *model.Document) (int64, error) {
+	args := f.Called(doc)
+	return int64(args.Int(0)), args.Error(1)
+}
+func (f *fakeDao) Count(ctx context.Context, query *q.Query) (int64, error) {
+	args := f.Called()
+	return int64(args.Int(0)), args.Error(1)
+}
+func (f *fakeDao) List(ctx context.Context, query *q.Query) ([]*model.Document, error) {
+	args := f.Called()
+	return args.Get(0).([]*model.Document), args.Error(1)
+}
+func (f *fakeDao) Get(ctx context.Context, id int64) (*model.Document, error) {
+	args := f.Called()
+	return args.Get(0).(*model.Document), args.Error(1)
+}
+func (f *fakeDao) Delete(ctx context.Context, id int64) error {
+	args := f.Called()
+	return args.Error(0)
+}
+
+type managerTestSuite struct {
+	suite.Suite
+	mgr *manager
+	dao *fakeDao
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &fakeDao{}
+	m.mgr = &manager{
+		dao: m.dao,
+	}
+}
+
+func (m *managerTestSuite) TestCreate() {
+	m.dao.On("Create", mock.MatchedBy(func(doc *model.Document) bool {
+		return doc.Format == model.FormatCycloneDX &&
+			doc.StatementsText == `[{"vulnerability_id":"CVE-2021-44228","status":"not_affected","justification":"code_not_reachable"}]`
+	})).Return(1, nil)
+	id, err := m.mgr.Create(context.Background(), &model.Document{
+		Content: `{"bomFormat": "CycloneDX", "vulnerabilities": [{"id": "CVE-2021-44228", "analysis": {"state": "not_affected", "justification": "code_not_reachable"}}]}`,
+	})
+	m.Require().Nil(err)
+	m.Equal(int64(1), id)
+	m.dao.AssertExpectations(m.T())
+
+	_, err = m.mgr.Create(context.Background(), &model.Document{Content: `{}`})
+	m.NotNil(err)
+}
+
+func (m *managerTestSuite) TestGetSuppressions() {
+	m.dao.On("List").Return([]*model.Document{
+		{
+			ID:             2,
+			StatementsText: `[{"vulnerability_id":"CVE-2021-44228","status":"affected"},{"vulnerability_id":"CVE-2021-45046","status":"not_affected","justification":"component_not_present"}]`,
+		},
+		{
+			ID:             1,
+			StatementsText: `[{"vulnerability_id":"CVE-2021-44228","status":"not_affected"},{"vulnerability_id":"CVE-2022-22965","status":"not_affected"}]`,
+		},
+	}, nil)
+
+	suppressions, err := m.mgr.GetSuppressions(context.Background(), "library/photon", "sha256:digest")
+	m.Require().Nil(err)
+	m.Equal(vuln.Suppressions{
+		"CVE-2021-45046": {Justification: "component_not_present"},
+		"CVE-2022-22965": {},
+	}, suppressions)
+}
+
+func (m *managerTestSuite) TestGetSuppressionsOfProducts() {
+	m.dao.On("List").Return([]*model.Document{
+		{
+			ID: 2,
+			StatementsText: `[{"vulnerability_id":"CVE-2021-44228","status":"not_affected","products":["pkg:oci/photon@sha256%3Aanother"]},` +
+				`{"vulnerability_id":"CVE-2021-45046","status":"not_affected","products":["library/photon@sha256:another"]}]`,
+		},
+		{
+			ID: 1,
+			StatementsText: `[{"vulnerability_id":"CVE-2021-44228","status":"affected","products":["pkg:oci/photon@sha256%3Adigest"]},` +
+				`{"vulnerability_id":"CVE-2022-22965","status":"not_affected","products":["pkg:oci/photon@sha256%3Adigest?repository_url=harbor.local/library/photon"]}]`,
+		},
+	}, nil)
+
+	// the statements of other products are ignored
+	suppressions, err := m.mgr.GetSuppressions(context.Background(), "library/photon", "sha256:digest")
+	m.Require().Nil(err)
+	m.Equal(vuln.Suppressions{
+		"CVE-2022-22965": {},
+	}, suppressions)
+}
+
+func (m *managerTestSuite) TestGetSuppressionsOfSubcomponents() {
+	m.dao.On("List").Return([]*model.Document{
+		{
+			ID: 3,
+			StatementsText: `[{"vulnerability_id":"CVE-2022-1304","status":"affected","subcomponents":["pkg:deb/debian/libss2@1.46.2"]},` +
+				`{"vulnerability_id":"CVE-2021-3711","status":"not_affected","subcomponents":["pkg:deb/debian/openssl@1.1.1k"]}]`,
+		},
+		{
+			ID: 2,
+			StatementsText: `[{"vulnerability_id":"CVE-2022-1304","status":"not_affected","justification":"vulnerable_code_not_present",` +
+				`"subcomponents":["pkg:deb/debian/e2fsprogs@1.46.2","pkg:deb/debian/libss2@1.46.2"]}]`,
+		},
+		{
+			ID:             1,
+			StatementsText: `[{"vulnerability_id":"CVE-2022-1304","status":"not_affected"},{"vulnerability_id":"CVE-2021-3711","status":"not_affected"}]`,
+		},
+	}, nil)
+
+	suppressions, err := m.mgr.GetSuppressions(context.Background(), "library/photon", "sha256:digest")
+	m.Require().Nil(err)
+	m.Equal(vuln.Suppressions{
+		// the libss2 is declared as affected by the newer statement
+		"CVE-2022-1304": {Justification: "vulnerable_code_not_present", Packages: []string{"pkg:deb/debian/e2fsprogs@1.46.2"}},
+		// widened by the older statement for all the subcomponents
+		"CVE-2021-3711": {},
+	}, suppressions)
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
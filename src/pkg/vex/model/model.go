@@ -0,0 +1,68 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/orm"
+)
+
+func init() {
+	orm.RegisterModel(&Document{})
+}
+
+const (
+	// FormatOpenVEX is the OpenVEX document format
+	FormatOpenVEX = "openvex"
+	// FormatCycloneDX is the CycloneDX VEX document format
+	FormatCycloneDX = "cyclonedx"
+
+	// StatusNotAffected is the status of the statement declaring the artifact isn't affected by the vulnerability
+	StatusNotAffected = "not_affected"
+)
+
+// Statement is the status of a vulnerability declared in the VEX document
+type Statement struct {
+	VulnerabilityID string `json:"vulnerability_id"`
+	Status          string `json:"status"`
+	Justification   string `json:"justification,omitempty"`
+	// the identifiers of the products which the statement applies to, e.g. the package URL
+	// "pkg:oci/app@sha256%3A..." or the digest, empty means all the artifacts the document attached to
+	Products []string `json:"products,omitempty"`
+	// the identifiers of the subcomponents of the products which the statement is limited to,
+	// empty means all the subcomponents
+	Subcomponents []string `json:"subcomponents,omitempty"`
+}
+
+// Document is the VEX document uploaded for the repository or the artifact
+type Document struct {
+	ID             int64        `orm:"pk;auto;column(id)" json:"id"`
+	ProjectID      int64        `orm:"column(project_id)" json:"project_id"`
+	RepositoryID   int64        `orm:"column(repository_id)" json:"repository_id"`
+	RepositoryName string       `orm:"column(repository_name)" json:"repository_name"`
+	Digest         string       `orm:"column(digest)" json:"digest"`
+	Format         string       `orm:"column(format)" json:"format"`
+	Statements     []*Statement `orm:"-" json:"statements"`
+	StatementsText string       `orm:"column(statements)" json:"-"`
+	Content        string       `orm:"column(content)" json:"-"`
+	Creator        string       `orm:"column(creator)" json:"creator"`
+	CreationTime   time.Time    `orm:"column(creation_time);auto_now_add" json:"creation_time" sort:"default:desc"`
+}
+
+// TableName for VEX document
+func (d *Document) TableName() string {
+	return "vex_document"
+}
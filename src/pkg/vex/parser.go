@@ -0,0 +1,252 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vex
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/vex/model"
+)
+
+// openVEXDocument is the subset of the OpenVEX document, see https://github.com/openvex/spec
+type openVEXDocument struct {
+	Statements []struct {
+		// the vulnerability is a string in the early versions of the spec and an object since v0.2.0
+		Vulnerability json.RawMessage `json:"vulnerability"`
+		// the products and subcomponents are strings in the early versions of the spec and objects since v0.2.0
+		Products        []json.RawMessage `json:"products"`
+		Subcomponents   []json.RawMessage `json:"subcomponents"`
+		Status          string            `json:"status"`
+		Justification   string            `json:"justification"`
+		ImpactStatement string            `json:"impact_statement"`
+	} `json:"statements"`
+}
+
+// openVEXComponent is the product or subcomponent in the OpenVEX document since v0.2.0
+type openVEXComponent struct {
+	ID            string            `json:"@id"`
+	Identifiers   map[string]string `json:"identifiers"`
+	Subcomponents []json.RawMessage `json:"subcomponents"`
+}
+
+// cycloneDXComponent is the subset of the component in the CycloneDX document
+type cycloneDXComponent struct {
+	BOMRef string `json:"bom-ref"`
+	PURL   string `json:"purl"`
+}
+
+// cycloneDXDocument is the subset of the CycloneDX VEX document, see https://cyclonedx.org/capabilities/vex/
+type cycloneDXDocument struct {
+	BOMFormat string `json:"bomFormat"`
+	Metadata  struct {
+		Component *cycloneDXComponent `json:"component"`
+	} `json:"metadata"`
+	Components      []*cycloneDXComponent `json:"components"`
+	Vulnerabilities []struct {
+		ID       string `json:"id"`
+		Analysis struct {
+			State         string `json:"state"`
+			Justification string `json:"justification"`
+			Detail        string `json:"detail"`
+		} `json:"analysis"`
+		Affects []struct {
+			Ref string `json:"ref"`
+		} `json:"affects"`
+	} `json:"vulnerabilities"`
+}
+
+// Parse parses the OpenVEX or CycloneDX VEX document and returns its format and statements
+func Parse(data []byte) (string, []*model.Statement, error) {
+	probe := map[string]interface{}{}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return "", nil, errors.BadRequestError(err).WithMessage("invalid VEX document: %v", err)
+	}
+
+	if ctx, ok := probe["@context"].(string); ok && strings.Contains(ctx, "openvex") {
+		statements, err := parseOpenVEX(data)
+		return model.FormatOpenVEX, statements, err
+	}
+
+	if format, ok := probe["bomFormat"].(string); ok && format == "CycloneDX" {
+		statements, err := parseCycloneDX(data)
+		return model.FormatCycloneDX, statements, err
+	}
+
+	return "", nil, errors.BadRequestError(nil).WithMessage("unsupported VEX document, only OpenVEX and CycloneDX are supported")
+}
+
+func parseOpenVEX(data []byte) ([]*model.Statement, error) {
+	doc := &openVEXDocument{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, errors.BadRequestError(err).WithMessage("invalid OpenVEX document: %v", err)
+	}
+
+	var statements []*model.Statement
+	for _, st := range doc.Statements {
+		id, err := parseOpenVEXVulnerability(st.Vulnerability)
+		if err != nil {
+			return nil, err
+		}
+		justification := st.Justification
+		if len(justification) == 0 {
+			justification = st.ImpactStatement
+		}
+		subcomponents, err := parseOpenVEXSubcomponents(st.Subcomponents)
+		if err != nil {
+			return nil, err
+		}
+		if len(st.Products) == 0 {
+			statements = append(statements, &model.Statement{
+				VulnerabilityID: id,
+				Status:          st.Status,
+				Justification:   justification,
+				Subcomponents:   subcomponents,
+			})
+			continue
+		}
+		// the subcomponents may be declared per product since v0.2.0, so split the statement by products
+		for _, raw := range st.Products {
+			statement := &model.Statement{
+				VulnerabilityID: id,
+				Status:          st.Status,
+				Justification:   justification,
+				Subcomponents:   subcomponents,
+			}
+			var product string
+			if err := json.Unmarshal(raw, &product); err == nil {
+				statement.Products = []string{product}
+			} else {
+				component := &openVEXComponent{}
+				if err := json.Unmarshal(raw, component); err != nil {
+					return nil, errors.BadRequestError(err).WithMessage("invalid OpenVEX document: invalid product: %v", err)
+				}
+				statement.Products = component.identifiers()
+				subs, err := parseOpenVEXSubcomponents(component.Subcomponents)
+				if err != nil {
+					return nil, err
+				}
+				statement.Subcomponents = append(statement.Subcomponents, subs...)
+			}
+			if len(statement.Products) == 0 {
+				return nil, errors.BadRequestError(nil).WithMessage("invalid OpenVEX document: the identifier of the product is missing")
+			}
+			statements = append(statements, statement)
+		}
+	}
+	return statements, nil
+}
+
+// identifiers returns the ID and the identifiers, e.g. purl, of the component
+func (c *openVEXComponent) identifiers() []string {
+	var ids []string
+	for _, id := range c.Identifiers {
+		if len(id) > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if len(c.ID) > 0 {
+		ids = append([]string{c.ID}, ids...)
+	}
+	return ids
+}
+
+func parseOpenVEXSubcomponents(raws []json.RawMessage) ([]string, error) {
+	var subcomponents []string
+	for _, raw := range raws {
+		var sub string
+		if err := json.Unmarshal(raw, &sub); err == nil {
+			subcomponents = append(subcomponents, sub)
+			continue
+		}
+		component := &openVEXComponent{}
+		if err := json.Unmarshal(raw, component); err != nil {
+			return nil, errors.BadRequestError(err).WithMessage("invalid OpenVEX document: invalid subcomponent: %v", err)
+		}
+		ids := component.identifiers()
+		if len(ids) == 0 {
+			return nil, errors.BadRequestError(nil).WithMessage("invalid OpenVEX document: the identifier of the subcomponent is missing")
+		}
+		subcomponents = append(subcomponents, ids...)
+	}
+	return subcomponents, nil
+}
+
+func parseOpenVEXVulnerability(raw json.RawMessage) (string, error) {
+	var id string
+	if err := json.Unmarshal(raw, &id); err == nil && len(id) > 0 {
+		return id, nil
+	}
+
+	vul := struct {
+		Name string `json:"name"`
+		ID   string `json:"@id"`
+	}{}
+	if err := json.Unmarshal(raw, &vul); err == nil {
+		if len(vul.Name) > 0 {
+			return vul.Name, nil
+		}
+		if len(vul.ID) > 0 {
+			return vul.ID, nil
+		}
+	}
+
+	return "", errors.BadRequestError(nil).WithMessage("invalid OpenVEX document: the vulnerability of the statement is missing")
+}
+
+func parseCycloneDX(data []byte) ([]*model.Statement, error) {
+	doc := &cycloneDXDocument{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, errors.BadRequestError(err).WithMessage("invalid CycloneDX document: %v", err)
+	}
+
+	// the affected products are referred by the bom-ref of the components
+	purls := map[string]string{}
+	for _, component := range append(doc.Components, doc.Metadata.Component) {
+		if component != nil && len(component.BOMRef) > 0 && len(component.PURL) > 0 {
+			purls[component.BOMRef] = component.PURL
+		}
+	}
+
+	var statements []*model.Statement
+	for _, vul := range doc.Vulnerabilities {
+		if len(vul.ID) == 0 {
+			return nil, errors.BadRequestError(nil).WithMessage("invalid CycloneDX document: the id of the vulnerability is missing")
+		}
+		justification := vul.Analysis.Justification
+		if len(justification) == 0 {
+			justification = vul.Analysis.Detail
+		}
+		statement := &model.Statement{
+			VulnerabilityID: vul.ID,
+			Status:          vul.Analysis.State,
+			Justification:   justification,
+		}
+		for _, affect := range vul.Affects {
+			if len(affect.Ref) == 0 {
+				continue
+			}
+			statement.Products = append(statement.Products, affect.Ref)
+			if purl, exist := purls[affect.Ref]; exist && purl != affect.Ref {
+				statement.Products = append(statement.Products, purl)
+			}
+		}
+		statements = append(statements, statement)
+	}
+	return statements, nil
+}
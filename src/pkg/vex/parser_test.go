@@ -0,0 +1,160 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vex
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/vex/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type parserTestSuite struct {
+	suite.Suite
+}
+
+func (p *parserTestSuite) TestParseOpenVEX() {
+	data := `{
+  "@context": "https://openvex.dev/ns/v0.2.0",
+  "@id": "https://openvex.dev/docs/example/vex-9fb3463de1b57",
+  "author": "Wolfi J Inkinson",
+  "timestamp": "2023-01-08T18:02:03.647787998-06:00",
+  "version": 1,
+  "statements": [
+    {
+      "vulnerability": {"name": "CVE-2014-123456"},
+      "products": [{"@id": "pkg:oci/example@sha256:a0a7"}],
+      "status": "not_affected",
+      "justification": "inline_mitigations_already_exist"
+    },
+    {
+      "vulnerability": "CVE-2021-44228",
+      "status": "affected"
+    }
+  ]
+}`
+	format, statements, err := Parse([]byte(data))
+	p.Require().Nil(err)
+	p.Equal(model.FormatOpenVEX, format)
+	p.Require().Len(statements, 2)
+	p.Equal("CVE-2014-123456", statements[0].VulnerabilityID)
+	p.Equal(model.StatusNotAffected, statements[0].Status)
+	p.Equal("inline_mitigations_already_exist", statements[0].Justification)
+	p.Equal([]string{"pkg:oci/example@sha256:a0a7"}, statements[0].Products)
+	p.Equal("CVE-2021-44228", statements[1].VulnerabilityID)
+	p.Equal("affected", statements[1].Status)
+	p.Empty(statements[1].Products)
+}
+
+func (p *parserTestSuite) TestParseOpenVEXProducts() {
+	data := `{
+  "@context": "https://openvex.dev/ns/v0.2.0",
+  "statements": [
+    {
+      "vulnerability": {"name": "CVE-2022-1304"},
+      "products": [
+        {
+          "@id": "registry.example.com/app@sha256:a0a7",
+          "identifiers": {"purl": "pkg:oci/app@sha256%3Aa0a7"},
+          "subcomponents": [{"@id": "pkg:deb/debian/e2fsprogs@1.46.2"}]
+        },
+        {
+          "@id": "registry.example.com/app@sha256:b1b8"
+        }
+      ],
+      "subcomponents": ["pkg:deb/debian/libss2@1.46.2"],
+      "status": "not_affected"
+    }
+  ]
+}`
+	_, statements, err := Parse([]byte(data))
+	p.Require().Nil(err)
+	p.Require().Len(statements, 2)
+	p.Equal([]string{"registry.example.com/app@sha256:a0a7", "pkg:oci/app@sha256%3Aa0a7"}, statements[0].Products)
+	p.Equal([]string{"pkg:deb/debian/libss2@1.46.2", "pkg:deb/debian/e2fsprogs@1.46.2"}, statements[0].Subcomponents)
+	p.Equal([]string{"registry.example.com/app@sha256:b1b8"}, statements[1].Products)
+	p.Equal([]string{"pkg:deb/debian/libss2@1.46.2"}, statements[1].Subcomponents)
+
+	// missing the identifier of the product
+	_, _, err = Parse([]byte(`{"@context": "https://openvex.dev/ns", "statements": [{"vulnerability": "CVE-2022-1304", "products": [{}]}]}`))
+	p.True(errors.IsErr(err, errors.BadRequestCode))
+}
+
+func (p *parserTestSuite) TestParseCycloneDX() {
+	data := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "version": 1,
+  "vulnerabilities": [
+    {
+      "id": "CVE-2021-44228",
+      "source": {"name": "NVD", "url": "https://nvd.nist.gov/vuln/detail/CVE-2021-44228"},
+      "analysis": {
+        "state": "not_affected",
+        "justification": "code_not_reachable",
+        "detail": "the JNDI lookup is disabled"
+      }
+    }
+  ]
+}`
+	format, statements, err := Parse([]byte(data))
+	p.Require().Nil(err)
+	p.Equal(model.FormatCycloneDX, format)
+	p.Require().Len(statements, 1)
+	p.Equal("CVE-2021-44228", statements[0].VulnerabilityID)
+	p.Equal(model.StatusNotAffected, statements[0].Status)
+	p.Equal("code_not_reachable", statements[0].Justification)
+	p.Empty(statements[0].Products)
+}
+
+func (p *parserTestSuite) TestParseCycloneDXAffects() {
+	data := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "metadata": {
+    "component": {"bom-ref": "app", "purl": "pkg:oci/app@sha256%3Aa0a7"}
+  },
+  "vulnerabilities": [
+    {
+      "id": "CVE-2021-44228",
+      "analysis": {"state": "not_affected"},
+      "affects": [{"ref": "app"}, {"ref": "urn:cdx:3e671687-395b-41f5-a30f-a58921a69b79/1#app"}]
+    }
+  ]
+}`
+	_, statements, err := Parse([]byte(data))
+	p.Require().Nil(err)
+	p.Require().Len(statements, 1)
+	p.Equal([]string{"app", "pkg:oci/app@sha256%3Aa0a7", "urn:cdx:3e671687-395b-41f5-a30f-a58921a69b79/1#app"}, statements[0].Products)
+}
+
+func (p *parserTestSuite) TestParseInvalid() {
+	// invalid JSON
+	_, _, err := Parse([]byte(`{`))
+	p.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// unsupported format
+	_, _, err = Parse([]byte(`{"spdxVersion": "SPDX-2.2"}`))
+	p.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// missing vulnerability
+	_, _, err = Parse([]byte(`{"@context": "https://openvex.dev/ns", "statements": [{"status": "not_affected"}]}`))
+	p.True(errors.IsErr(err, errors.BadRequestCode))
+}
+
+func TestParserTestSuite(t *testing.T) {
+	suite.Run(t, &parserTestSuite{})
+}
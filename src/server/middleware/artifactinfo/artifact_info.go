@@ -41,6 +41,7 @@ var (
 	urlPatterns = map[string]*regexp.Regexp{
 		"manifest":    lib.V2ManifestURLRe,
 		"tag_list":    lib.V2TagListURLRe,
+		"referrers":   lib.V2ReferrersURLRe,
 		"blob_upload": lib.V2BlobUploadURLRe,
 		"blob":        lib.V2BlobURLRe,
 	}
@@ -0,0 +1,130 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	pkgart "github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/pkg/signature/cosign"
+	"github.com/goharbor/harbor/src/server/middleware"
+	"github.com/goharbor/harbor/src/server/middleware/util"
+)
+
+var (
+	// isArtifactVerified checks whether the artifact has a valid cosign signature signed by one of the public keys,
+	// the verification results are cached per digest
+	isArtifactVerified = func(ctx context.Context, art *artifact.Artifact, publicKeys string) (bool, error) {
+		return cosign.NewVerifier().Verify(ctx, &art.Artifact, publicKeys)
+	}
+	// isCosignAccessory checks whether the artifact is the signature, attestation or SBOM attached by cosign
+	isCosignAccessory = func(ctx context.Context, art *artifact.Artifact) (bool, error) {
+		return cosign.NewVerifier().IsAccessory(ctx, &art.Artifact)
+	}
+)
+
+// Middleware checks the cosign signatures of the pulling artifact against the public keys configured in the project
+func Middleware() func(http.Handler) http.Handler {
+	return middleware.BeforeRequest(func(r *http.Request) error {
+		ctx := r.Context()
+
+		logger := log.G(ctx)
+
+		none := lib.ArtifactInfo{}
+		af := lib.GetArtifactInfo(ctx)
+		if af == none {
+			return errors.New("artifactinfo middleware required before this middleware").WithCode(errors.NotFoundCode)
+		}
+
+		pro, err := project.Ctl.GetByName(ctx, af.ProjectName)
+		if err != nil {
+			return err
+		}
+
+		if util.SkipPolicyChecking(ctx, pro.ProjectID) {
+			// the artifact is pulling by the scanner, skip the checking
+			logger.Debugf("artifact %s:%s is pulling by the scanner, skip the checking", af.Repository, af.Reference)
+			return nil
+		}
+
+		if !pro.CosignVerificationEnabled() {
+			return nil
+		}
+
+		art, err := artifact.Ctl.GetByReference(ctx, af.Repository, af.Reference, nil)
+		if err != nil {
+			return err
+		}
+
+		// the signatures and other cosign accessories must be pullable to verify the subject artifact on the client side
+		accessory, err := isCosignAccessory(ctx, art)
+		if err != nil {
+			return err
+		}
+		if accessory {
+			return nil
+		}
+
+		if len(pro.CosignPublicKeys()) == 0 {
+			return errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).
+				WithMessage("The cosign verification is enabled but no public key is configured for the project.")
+		}
+		verified, err := isVerified(ctx, art, pro.CosignPublicKeys(), map[int64]struct{}{})
+		if err != nil {
+			return err
+		}
+		if !verified {
+			return errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).
+				WithMessage("The image is not signed by the trusted cosign keys of the project.")
+		}
+		return nil
+	})
+}
+
+// isVerified checks the signatures of the artifact, the artifact referenced by a verified index
+// is treated as verified as the clients pull the children by digest after pulling the signed index
+func isVerified(ctx context.Context, art *artifact.Artifact, publicKeys string, visited map[int64]struct{}) (bool, error) {
+	visited[art.ID] = struct{}{}
+	verified, err := isArtifactVerified(ctx, art, publicKeys)
+	if err != nil || verified {
+		return verified, err
+	}
+
+	references, err := pkgart.Mgr.ListReferences(ctx, q.New(q.KeyWords{"ChildID": art.ID}))
+	if err != nil {
+		return false, err
+	}
+	for _, reference := range references {
+		if _, exist := visited[reference.ParentID]; exist {
+			continue
+		}
+		parent, err := artifact.Ctl.Get(ctx, reference.ParentID, nil)
+		if err != nil {
+			return false, err
+		}
+		verified, err = isVerified(ctx, parent, publicKeys, visited)
+		if err != nil || verified {
+			return verified, err
+		}
+	}
+	return false, nil
+}
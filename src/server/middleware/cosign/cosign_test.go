@@ -0,0 +1,226 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib"
+	pkgart "github.com/goharbor/harbor/src/pkg/artifact"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	securitytesting "github.com/goharbor/harbor/src/testing/common/security"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	"github.com/goharbor/harbor/src/testing/mock"
+	arttesting "github.com/goharbor/harbor/src/testing/pkg/artifact"
+	"github.com/stretchr/testify/suite"
+)
+
+type MiddlewareTestSuite struct {
+	suite.Suite
+
+	originalArtifactController artifact.Controller
+	artifactController         *artifacttesting.Controller
+
+	originalProjectController project.Controller
+	projectController         *projecttesting.Controller
+
+	originalArtifactMgr pkgart.Manager
+	artifactMgr         *arttesting.Manager
+
+	artifact *artifact.Artifact
+	project  *proModels.Project
+
+	isArtifactVerified func(ctx context.Context, art *artifact.Artifact, publicKeys string) (bool, error)
+	isCosignAccessory  func(ctx context.Context, art *artifact.Artifact) (bool, error)
+	next               http.Handler
+}
+
+func (suite *MiddlewareTestSuite) SetupTest() {
+	suite.originalArtifactController = artifact.Ctl
+	suite.artifactController = &artifacttesting.Controller{}
+	artifact.Ctl = suite.artifactController
+
+	suite.originalProjectController = project.Ctl
+	suite.projectController = &projecttesting.Controller{}
+	project.Ctl = suite.projectController
+
+	suite.originalArtifactMgr = pkgart.Mgr
+	suite.artifactMgr = &arttesting.Manager{}
+	pkgart.Mgr = suite.artifactMgr
+
+	suite.isArtifactVerified = isArtifactVerified
+	suite.isCosignAccessory = isCosignAccessory
+	suite.artifact = &artifact.Artifact{}
+	suite.artifact.ID = 1
+	suite.artifact.ProjectID = 1
+	suite.artifact.RepositoryName = "library/photon"
+	suite.artifact.Digest = "digest"
+
+	suite.project = &proModels.Project{
+		ProjectID: suite.artifact.ProjectID,
+		Name:      "library",
+		Metadata: map[string]string{
+			proModels.ProMetaEnableCosignVerification: "true",
+			proModels.ProMetaCosignPublicKeys:         "public keys",
+		},
+	}
+
+	suite.next = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	isArtifactVerified = func(ctx context.Context, art *artifact.Artifact, publicKeys string) (bool, error) {
+		return false, nil
+	}
+	isCosignAccessory = func(ctx context.Context, art *artifact.Artifact) (bool, error) {
+		return false, nil
+	}
+}
+
+func (suite *MiddlewareTestSuite) TearDownTest() {
+	artifact.Ctl = suite.originalArtifactController
+	project.Ctl = suite.originalProjectController
+	pkgart.Mgr = suite.originalArtifactMgr
+	isArtifactVerified = suite.isArtifactVerified
+	isCosignAccessory = suite.isCosignAccessory
+}
+
+func (suite *MiddlewareTestSuite) makeRequest() *http.Request {
+	req := httptest.NewRequest("GET", "/v2/library/photon/manifests/2.0", nil)
+	info := lib.ArtifactInfo{
+		ProjectName: "library",
+		Repository:  "library/photon",
+		Reference:   "2.0",
+		Tag:         "2.0",
+	}
+	return req.WithContext(lib.WithArtifactInfo(req.Context(), info))
+}
+
+func (suite *MiddlewareTestSuite) TestNoneArtifact() {
+	req := httptest.NewRequest("GET", "/v2/library/photon/manifests/nonexist", nil)
+	rr := httptest.NewRecorder()
+
+	Middleware()(suite.next).ServeHTTP(rr, req)
+	suite.Equal(http.StatusNotFound, rr.Code)
+}
+
+func (suite *MiddlewareTestSuite) TestGetProjectFailed() {
+	mock.OnAnything(suite.projectController, "GetByName").Return(nil, fmt.Errorf("err"))
+
+	rr := httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest())
+	suite.Equal(http.StatusInternalServerError, rr.Code)
+}
+
+func (suite *MiddlewareTestSuite) TestVerificationDisabled() {
+	suite.project.Metadata[proModels.ProMetaEnableCosignVerification] = "false"
+	mock.OnAnything(suite.projectController, "GetByName").Return(suite.project, nil)
+
+	rr := httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest())
+	suite.Equal(http.StatusOK, rr.Code)
+	suite.artifactController.AssertNotCalled(suite.T(), "GetByReference", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *MiddlewareTestSuite) TestPullAccessory() {
+	mock.OnAnything(suite.projectController, "GetByName").Return(suite.project, nil)
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+	isCosignAccessory = func(ctx context.Context, art *artifact.Artifact) (bool, error) {
+		return true, nil
+	}
+
+	rr := httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest())
+	suite.Equal(http.StatusOK, rr.Code)
+}
+
+func (suite *MiddlewareTestSuite) TestNoPublicKey() {
+	delete(suite.project.Metadata, proModels.ProMetaCosignPublicKeys)
+	mock.OnAnything(suite.projectController, "GetByName").Return(suite.project, nil)
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+
+	rr := httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest())
+	suite.Equal(http.StatusPreconditionFailed, rr.Code)
+}
+
+func (suite *MiddlewareTestSuite) TestNotVerified() {
+	mock.OnAnything(suite.projectController, "GetByName").Return(suite.project, nil)
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+
+	mock.OnAnything(suite.artifactMgr, "ListReferences").Return(nil, nil)
+
+	rr := httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest())
+	suite.Equal(http.StatusPreconditionFailed, rr.Code)
+}
+
+func (suite *MiddlewareTestSuite) TestVerified() {
+	mock.OnAnything(suite.projectController, "GetByName").Return(suite.project, nil)
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+	isArtifactVerified = func(ctx context.Context, art *artifact.Artifact, publicKeys string) (bool, error) {
+		return publicKeys == "public keys", nil
+	}
+
+	rr := httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest())
+	suite.Equal(http.StatusOK, rr.Code)
+}
+
+func (suite *MiddlewareTestSuite) TestParentVerified() {
+	index := &artifact.Artifact{}
+	index.ID = 2
+	index.RepositoryName = "library/photon"
+	index.Digest = "index"
+	mock.OnAnything(suite.projectController, "GetByName").Return(suite.project, nil)
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+	mock.OnAnything(suite.artifactController, "Get").Return(index, nil)
+	mock.OnAnything(suite.artifactMgr, "ListReferences").Return([]*pkgart.Reference{
+		{ParentID: index.ID, ChildID: suite.artifact.ID},
+	}, nil)
+	isArtifactVerified = func(ctx context.Context, art *artifact.Artifact, publicKeys string) (bool, error) {
+		return art.Digest == "index", nil
+	}
+
+	rr := httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest())
+	suite.Equal(http.StatusOK, rr.Code)
+}
+
+func (suite *MiddlewareTestSuite) TestScannerPull() {
+	mock.OnAnything(suite.projectController, "GetByName").Return(suite.project, nil)
+	securityCtx := &securitytesting.Context{}
+	mock.OnAnything(securityCtx, "Name").Return("v2token")
+	mock.OnAnything(securityCtx, "Can").Return(true, nil)
+
+	req := suite.makeRequest()
+	req = req.WithContext(security.NewContext(req.Context(), securityCtx))
+	rr := httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, req)
+	suite.Equal(http.StatusOK, rr.Code)
+	suite.artifactController.AssertNotCalled(suite.T(), "GetByReference", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, &MiddlewareTestSuite{})
+}
@@ -26,6 +26,8 @@ const (
 	BlobsOperationID = "v2_blob"
 	// BlobsUploadOperationID ...
 	BlobsUploadOperationID = "v2_blob_upload"
+	// ReferrersOperationID ...
+	ReferrersOperationID = "v2_referrers"
 	// OthersOperationID ...
 	OthersOperationID = "v2_others"
 )
@@ -15,12 +15,17 @@
 package notification
 
 import (
+	"net"
 	"net/http"
+	"strings"
 
+	evt "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/event/metadata"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/pkg/notification"
 	"github.com/goharbor/harbor/src/pkg/notifier/event"
 	"github.com/goharbor/harbor/src/server/middleware"
+	"github.com/goharbor/harbor/src/server/middleware/requestid"
 )
 
 // Middleware sends the notification after transaction success
@@ -29,10 +34,37 @@ func Middleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler
 		res := lib.NewResponseRecorder(w)
 		evc := notification.NewEventCtx()
 		next.ServeHTTP(res, r.WithContext(notification.NewContext(r.Context(), evc)))
-		if res.Success() || evc.MustNotify {
-			for e := evc.Events.Front(); e != nil; e = e.Next() {
-				event.BuildAndPublish(e.Value.(event.Metadata))
-			}
+		// the events of the failed request are still published to be recorded in the audit logs
+		meta := &evt.RequestMeta{
+			ID:         r.Header.Get(requestid.HeaderXRequestID),
+			ClientIP:   clientIP(r),
+			UserAgent:  r.UserAgent(),
+			AuthMethod: evc.AuthMethod,
+			Failed:     !res.Success() && !evc.MustNotify,
+		}
+		for e := evc.Events.Front(); e != nil; e = e.Next() {
+			event.BuildAndPublish(e.Value.(event.Metadata), &metadata.RequestMetaData{Meta: meta})
 		}
 	}, skippers...)
 }
+
+// clientIP returns the IP of the client. The "X-Real-IP" header set by the bundled proxy to the address
+// of the peer is preferred, then the last hop appended to the "X-Forwarded-For" header by the proxy, as the
+// leading entries of "X-Forwarded-For" are passed through from the client and can be spoofed
+func clientIP(r *http.Request) string {
+	if ip := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(ip) != nil {
+		return ip
+	}
+	if forwarded := r.Header.Get("X-Forwarded-For"); len(forwarded) > 0 {
+		hops := strings.Split(forwarded, ",")
+		ip := strings.TrimSpace(hops[len(hops)-1])
+		if net.ParseIP(ip) != nil {
+			return ip
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
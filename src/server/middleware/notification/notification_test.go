@@ -75,6 +75,24 @@ func (suite *NotificationMiddlewareTestSuite) TestMiddlewareMustNotify() {
 	suite.Equal(http.StatusInternalServerError, res.Code)
 }
 
+func (suite *NotificationMiddlewareTestSuite) TestClientIP() {
+	req := httptest.NewRequest(http.MethodGet, "/api/v2.0/projects", nil)
+	req.RemoteAddr = "192.168.0.1:12345"
+	suite.Equal("192.168.0.1", clientIP(req))
+
+	// the last hop appended by the proxy is used, the leading ones are sent by the client
+	req.Header.Set("X-Forwarded-For", "10.0.0.1, 172.16.0.1")
+	suite.Equal("172.16.0.1", clientIP(req))
+
+	req.Header.Set("X-Forwarded-For", "unknown")
+	suite.Equal("192.168.0.1", clientIP(req))
+
+	// the X-Real-IP set by the proxy is preferred
+	req.Header.Set("X-Forwarded-For", "10.0.0.1, 172.16.0.1")
+	req.Header.Set("X-Real-IP", "10.0.0.2")
+	suite.Equal("10.0.0.2", clientIP(req))
+}
+
 func TestNotificationMiddlewareTestSuite(t *testing.T) {
 	suite.Run(t, &NotificationMiddlewareTestSuite{})
 }
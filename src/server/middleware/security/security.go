@@ -56,6 +56,7 @@ func Middleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler
 		for _, generator := range generators {
 			if ctx := generator.Generate(r); ctx != nil {
 				r = r.WithContext(security.NewContext(r.Context(), ctx))
+				r = r.WithContext(lib.WithAuthMethod(r.Context(), authMethod(generator)))
 				break
 			}
 		}
@@ -63,6 +64,32 @@ func Middleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler
 	}, skippers...)
 }
 
+// authMethod returns the method by which the request is authenticated with the generator
+func authMethod(g generator) string {
+	switch g.(type) {
+	case *secret:
+		return "secret"
+	case *oidcCli:
+		return "oidc_cli_secret"
+	case *v2Token:
+		return "token"
+	case *idToken:
+		return "id_token"
+	case *authProxy:
+		return "auth_proxy"
+	case *robot:
+		return "robot"
+	case *basicAuth:
+		return "basic"
+	case *session:
+		return "session"
+	case *proxyCacheSecret:
+		return "proxy_cache_secret"
+	default:
+		return ""
+	}
+}
+
 // UnauthorizedMiddleware returns a security context middleware
 // that populates the unauthorized security context when not security context found in the request context
 func UnauthorizedMiddleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler {
@@ -43,3 +43,11 @@ func TestSecurity(t *testing.T) {
 	require.True(t, exist)
 	assert.NotNil(t, ctx)
 }
+
+func TestAuthMethod(t *testing.T) {
+	assert.Equal(t, "basic", authMethod(&basicAuth{}))
+	assert.Equal(t, "robot", authMethod(&robot{}))
+	assert.Equal(t, "oidc_cli_secret", authMethod(&oidcCli{}))
+	assert.Equal(t, "session", authMethod(&session{}))
+	assert.Equal(t, "", authMethod(&unauthorized{}))
+}
@@ -89,7 +89,7 @@ func Middleware() func(http.Handler) http.Handler {
 			return nil
 		}
 
-		allowlist := proj.CVEAllowlist.CVESet()
+		allowlist := proj.CVEAllowlist.CVESetFor(art.RepositoryName)
 
 		projectSeverity := vuln.ParseSeverityVersion3(proj.Severity())
 
@@ -140,6 +140,9 @@ func Middleware() func(http.Handler) http.Handler {
 		for _, cve := range vulnerable.CVEBypassed {
 			logger.Infof("Vulnerable policy check: bypassed CVE %s", cve)
 		}
+		for _, cve := range vulnerable.CVESuppressed {
+			logger.Infof("Vulnerable policy check: suppressed CVE %s by VEX statement", cve)
+		}
 
 		return nil
 	})
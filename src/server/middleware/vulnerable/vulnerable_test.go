@@ -29,6 +29,7 @@ import (
 	"github.com/goharbor/harbor/src/controller/scan"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
+	allowlist "github.com/goharbor/harbor/src/pkg/allowlist/models"
 	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 	securitytesting "github.com/goharbor/harbor/src/testing/common/security"
 	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
@@ -353,6 +354,33 @@ func (suite *MiddlewareTestSuite) TestArtifactIsImageIndex() {
 	suite.Equal(rr.Code, http.StatusOK)
 }
 
+func (suite *MiddlewareTestSuite) TestRepositoryScopedAllowlist() {
+	expired := int64(1)
+	suite.project.CVEAllowlist = allowlist.CVEAllowlist{
+		ProjectID: suite.project.ProjectID,
+		Items: []allowlist.CVEAllowlistItem{
+			{CVEID: "CVE-2021-44228", Repository: "library/photon", Justification: "not exploitable", Author: "admin"},
+			{CVEID: "CVE-2021-45046", Repository: "library/nginx"},
+			{CVEID: "CVE-2022-22965", ExpiresAt: &expired},
+		},
+	}
+
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+	mock.OnAnything(suite.checker, "IsScannable").Return(true, nil)
+	suite.scanController.On("GetVulnerable", mock.Anything, suite.artifact, allowlist.CVESet{"CVE-2021-44228": {}}).Return(&scan.Vulnerable{
+		ScanStatus:  "Success",
+		CVEBypassed: []string{"CVE-2021-44228"},
+	}, nil)
+
+	req := suite.makeRequest()
+	rr := httptest.NewRecorder()
+
+	Middleware()(suite.next).ServeHTTP(rr, req)
+	suite.Equal(rr.Code, http.StatusOK)
+	suite.scanController.AssertExpectations(suite.T())
+}
+
 func TestMiddlewareTestSuite(t *testing.T) {
 	suite.Run(t, &MiddlewareTestSuite{})
 }
@@ -0,0 +1,154 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/blob"
+	"github.com/goharbor/harbor/src/lib/errors"
+	lib_http "github.com/goharbor/harbor/src/lib/http"
+	"github.com/goharbor/harbor/src/lib/log"
+	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/server/router"
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// the query parameter and header defined in OCI distribution spec 1.1 to filter the referrers
+	artifactTypeParam    = "artifactType"
+	filtersAppliedHeader = "OCI-Filters-Applied"
+)
+
+func newReferrersHandler() http.Handler {
+	return &referrersHandler{
+		artCtl:  artifact.Ctl,
+		blobCtl: blob.Ctl,
+	}
+}
+
+type referrersHandler struct {
+	artCtl  artifact.Controller
+	blobCtl blob.Controller
+}
+
+// ServeHTTP returns the manifests which declare the specified artifact as the subject
+//
+// Content-Type: application/vnd.oci.image.index.v1+json
+// OCI-Filters-Applied: artifactType (only when filtered by the artifact type)
+//
+//	{
+//	   "schemaVersion": 2,
+//	   "mediaType": "application/vnd.oci.image.index.v1+json",
+//	   "manifests": [
+//	     {
+//	       "mediaType": "<media type of the referrer>",
+//	       "digest": "<digest of the referrer>",
+//	       "size": <size of the referrer manifest>,
+//	       "artifactType": "<artifact type of the referrer>",
+//	       "annotations": {...}
+//	     },
+//	     ...
+//	   ]
+//	}
+func (r *referrersHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	repository := router.Param(ctx, ":splat")
+	reference := router.Param(ctx, ":digest")
+	if _, err := digest.Parse(reference); err != nil {
+		lib_http.SendError(w, errors.Wrapf(err, "invalid digest %s", reference).WithCode(errors.BadRequestCode))
+		return
+	}
+	artifactType := req.URL.Query().Get(artifactTypeParam)
+
+	manifests := make([]*referrerDescriptor, 0)
+	subject, err := r.artCtl.GetByReference(ctx, repository, reference, &artifact.Option{WithAccessory: true})
+	if err != nil {
+		// the referrers may be pushed before the subject, return an empty list in this case
+		if !errors.IsNotFoundErr(err) {
+			lib_http.SendError(w, err)
+			return
+		}
+		r.sendResponse(w, artifactType, manifests)
+		return
+	}
+
+	for _, acc := range subject.Accessories {
+		if acc.Type != accessorymodel.TypeSubject {
+			continue
+		}
+		referrer, err := r.artCtl.Get(ctx, acc.ArtifactID, nil)
+		if err != nil {
+			// the referrer may be deleted during the listing
+			if errors.IsNotFoundErr(err) {
+				continue
+			}
+			lib_http.SendError(w, err)
+			return
+		}
+		if len(artifactType) > 0 && referrer.ArtifactType != artifactType {
+			continue
+		}
+		// the size in the descriptor is the size of the manifest itself which is recorded as a blob
+		mani, err := r.blobCtl.Get(ctx, referrer.Digest)
+		if err != nil {
+			log.G(ctx).Errorf("failed to get the manifest blob of referrer %s: %v", referrer.Digest, err)
+			lib_http.SendError(w, err)
+			return
+		}
+		manifests = append(manifests, &referrerDescriptor{
+			MediaType:    referrer.ManifestMediaType,
+			Digest:       referrer.Digest,
+			Size:         mani.Size,
+			ArtifactType: referrer.ArtifactType,
+			Annotations:  referrer.Annotations,
+		})
+	}
+	r.sendResponse(w, artifactType, manifests)
+}
+
+func (r *referrersHandler) sendResponse(w http.ResponseWriter, artifactType string, manifests []*referrerDescriptor) {
+	if len(artifactType) > 0 {
+		w.Header().Set(filtersAppliedHeader, artifactTypeParam)
+	}
+	w.Header().Set("Content-Type", v1.MediaTypeImageIndex)
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(referrersAPIResponse{
+		SchemaVersion: 2,
+		MediaType:     v1.MediaTypeImageIndex,
+		Manifests:     manifests,
+	}); err != nil {
+		lib_http.SendError(w, err)
+		return
+	}
+}
+
+type referrersAPIResponse struct {
+	SchemaVersion int                   `json:"schemaVersion"`
+	MediaType     string                `json:"mediaType"`
+	Manifests     []*referrerDescriptor `json:"manifests"`
+}
+
+// the vendored image spec doesn't contain the "artifactType" in the descriptor
+type referrerDescriptor struct {
+	MediaType    string            `json:"mediaType"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
@@ -0,0 +1,152 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	beegocontext "github.com/astaxie/beego/context"
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/blob"
+	"github.com/goharbor/harbor/src/lib/errors"
+	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	pkg_artifact "github.com/goharbor/harbor/src/pkg/artifact"
+	pkg_blob "github.com/goharbor/harbor/src/pkg/blob/models"
+	"github.com/goharbor/harbor/src/server/router"
+	arttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	blobtesting "github.com/goharbor/harbor/src/testing/controller/blob"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type referrersTestSuite struct {
+	suite.Suite
+	originalArtCtl  artifact.Controller
+	originalBlobCtl blob.Controller
+	artCtl          *arttesting.Controller
+	blobCtl         *blobtesting.Controller
+}
+
+func (r *referrersTestSuite) SetupSuite() {
+	r.originalArtCtl = artifact.Ctl
+	r.originalBlobCtl = blob.Ctl
+}
+
+func (r *referrersTestSuite) SetupTest() {
+	r.artCtl = &arttesting.Controller{}
+	r.blobCtl = &blobtesting.Controller{}
+	artifact.Ctl = r.artCtl
+	blob.Ctl = r.blobCtl
+}
+
+func (r *referrersTestSuite) TearDownSuite() {
+	artifact.Ctl = r.originalArtCtl
+	blob.Ctl = r.originalBlobCtl
+}
+
+func (r *referrersTestSuite) newRequest(url, digest string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	input := &beegocontext.BeegoInput{}
+	input.SetParam(":splat", "library/hello-world")
+	input.SetParam(":digest", digest)
+	return req.WithContext(context.WithValue(req.Context(), router.ContextKeyInput{}, input))
+}
+
+func (r *referrersTestSuite) TestInvalidDigest() {
+	req := r.newRequest("/v2/library/hello-world/referrers/latest", "latest")
+	w := httptest.NewRecorder()
+	newReferrersHandler().ServeHTTP(w, req)
+	r.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (r *referrersTestSuite) TestSubjectNotFound() {
+	digest := "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180"
+	req := r.newRequest("/v2/library/hello-world/referrers/"+digest, digest)
+	w := httptest.NewRecorder()
+	mock.OnAnything(r.artCtl, "GetByReference").Return(nil, errors.NotFoundError(nil))
+	newReferrersHandler().ServeHTTP(w, req)
+	r.Equal(http.StatusOK, w.Code)
+
+	resp := &referrersAPIResponse{}
+	r.Require().Nil(json.NewDecoder(w.Body).Decode(resp))
+	r.Equal(2, resp.SchemaVersion)
+	r.Len(resp.Manifests, 0)
+}
+
+func (r *referrersTestSuite) TestListReferrers() {
+	digest := "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180"
+	subject := &artifact.Artifact{
+		Accessories: []*accessorymodel.Accessory{
+			{ArtifactID: 2, SubjectArtifactID: 1, Type: accessorymodel.TypeSubject},
+			{ArtifactID: 3, SubjectArtifactID: 1, Type: accessorymodel.TypeSubject},
+			{ArtifactID: 4, SubjectArtifactID: 1, Type: accessorymodel.TypeCosignSignature},
+		},
+	}
+	subject.ID = 1
+	sbom := &artifact.Artifact{Artifact: pkg_artifact.Artifact{
+		ID:                2,
+		Digest:            "sha256:sbom",
+		ManifestMediaType: "application/vnd.oci.image.manifest.v1+json",
+		ArtifactType:      "application/vnd.example.sbom.v1",
+		Annotations:       map[string]string{"key": "value"},
+	}}
+	sig := &artifact.Artifact{Artifact: pkg_artifact.Artifact{
+		ID:                3,
+		Digest:            "sha256:signature",
+		ManifestMediaType: "application/vnd.oci.image.manifest.v1+json",
+		ArtifactType:      "application/vnd.example.signature.v1",
+	}}
+	mock.OnAnything(r.artCtl, "GetByReference").Return(subject, nil)
+	r.artCtl.On("Get", mock.Anything, int64(2), mock.Anything).Return(sbom, nil)
+	r.artCtl.On("Get", mock.Anything, int64(3), mock.Anything).Return(sig, nil)
+	r.blobCtl.On("Get", mock.Anything, "sha256:sbom").Return(&pkg_blob.Blob{Size: 100}, nil)
+	r.blobCtl.On("Get", mock.Anything, "sha256:signature").Return(&pkg_blob.Blob{Size: 200}, nil)
+
+	// without filter
+	req := r.newRequest("/v2/library/hello-world/referrers/"+digest, digest)
+	w := httptest.NewRecorder()
+	newReferrersHandler().ServeHTTP(w, req)
+	r.Equal(http.StatusOK, w.Code)
+	r.Equal("application/vnd.oci.image.index.v1+json", w.Header().Get("Content-Type"))
+	r.Empty(w.Header().Get(filtersAppliedHeader))
+	resp := &referrersAPIResponse{}
+	r.Require().Nil(json.NewDecoder(w.Body).Decode(resp))
+	r.Require().Len(resp.Manifests, 2)
+	r.Equal("sha256:sbom", resp.Manifests[0].Digest)
+	r.Equal(int64(100), resp.Manifests[0].Size)
+	r.Equal("application/vnd.example.sbom.v1", resp.Manifests[0].ArtifactType)
+	r.Equal("value", resp.Manifests[0].Annotations["key"])
+	r.Equal("sha256:signature", resp.Manifests[1].Digest)
+
+	// filter by the artifact type
+	req = r.newRequest("/v2/library/hello-world/referrers/"+digest+"?artifactType=application/vnd.example.signature.v1", digest)
+	w = httptest.NewRecorder()
+	newReferrersHandler().ServeHTTP(w, req)
+	r.Equal(http.StatusOK, w.Code)
+	r.Equal(artifactTypeParam, w.Header().Get(filtersAppliedHeader))
+	resp = &referrersAPIResponse{}
+	r.Require().Nil(json.NewDecoder(w.Body).Decode(resp))
+	r.Require().Len(resp.Manifests, 1)
+	r.Equal("sha256:signature", resp.Manifests[0].Digest)
+	r.Equal(int64(200), resp.Manifests[0].Size)
+}
+
+func TestReferrersTestSuite(t *testing.T) {
+	suite.Run(t, &referrersTestSuite{})
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/goharbor/harbor/src/server/middleware/blob"
 	"github.com/goharbor/harbor/src/server/middleware/contenttrust"
+	"github.com/goharbor/harbor/src/server/middleware/cosign"
 	"github.com/goharbor/harbor/src/server/middleware/immutable"
 	"github.com/goharbor/harbor/src/server/middleware/metric"
 	"github.com/goharbor/harbor/src/server/middleware/quota"
@@ -45,6 +46,12 @@ func RegisterRoutes() {
 		Path("/*/tags/list").
 		Middleware(metric.InjectOpIDMiddleware(metric.ListTagOperationID)).
 		Handler(newTagHandler())
+	// list referrers
+	root.NewRoute().
+		Method(http.MethodGet).
+		Path("/*/referrers/:digest").
+		Middleware(metric.InjectOpIDMiddleware(metric.ReferrersOperationID)).
+		Handler(newReferrersHandler())
 	// manifest
 	root.NewRoute().
 		Method(http.MethodGet).
@@ -52,6 +59,7 @@ func RegisterRoutes() {
 		Middleware(metric.InjectOpIDMiddleware(metric.ManifestOperationID)).
 		Middleware(repoproxy.ManifestMiddleware()).
 		Middleware(contenttrust.Middleware()).
+		Middleware(cosign.Middleware()).
 		Middleware(vulnerable.Middleware()).
 		HandlerFunc(getManifest)
 	root.NewRoute().
@@ -60,6 +68,7 @@ func RegisterRoutes() {
 		Middleware(metric.InjectOpIDMiddleware(metric.ManifestOperationID)).
 		Middleware(repoproxy.ManifestMiddleware()).
 		Middleware(contenttrust.Middleware()).
+		Middleware(cosign.Middleware()).
 		Middleware(vulnerable.Middleware()).
 		HandlerFunc(getManifest)
 	root.NewRoute().
@@ -37,6 +37,8 @@ import (
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg/notification"
 	"github.com/goharbor/harbor/src/pkg/scan/report"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	"github.com/goharbor/harbor/src/pkg/vex"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/assembler"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
@@ -51,6 +53,7 @@ func newArtifactAPI() *artifactAPI {
 		repoCtl: repository.Ctl,
 		scanCtl: scan.DefaultController,
 		tagCtl:  tag.Ctl,
+		vexMgr:  vex.Mgr,
 	}
 }
 
@@ -61,6 +64,7 @@ type artifactAPI struct {
 	repoCtl repository.Controller
 	scanCtl scan.Controller
 	tagCtl  tag.Controller
+	vexMgr  vex.Manager
 }
 
 func (a *artifactAPI) Prepare(ctx context.Context, operation string, params interface{}) middleware.Responder {
@@ -85,7 +89,7 @@ func (a *artifactAPI) ListArtifacts(ctx context.Context, params operation.ListAr
 
 	// set option
 	option := option(params.WithTag, params.WithImmutableStatus,
-		params.WithLabel, params.WithSignature)
+		params.WithLabel, params.WithSignature, params.WithAccessory)
 
 	// get the total count of artifacts
 	total, err := a.artCtl.Count(ctx, query)
@@ -119,7 +123,7 @@ func (a *artifactAPI) GetArtifact(ctx context.Context, params operation.GetArtif
 	}
 	// set option
 	option := option(params.WithTag, params.WithImmutableStatus,
-		params.WithLabel, params.WithSignature)
+		params.WithLabel, params.WithSignature, params.WithAccessory)
 
 	// get the artifact
 	artifact, err := a.artCtl.GetByReference(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName), params.Reference, option)
@@ -360,6 +364,15 @@ func (a *artifactAPI) GetVulnerabilitiesAddition(ctx context.Context, params ope
 			continue
 		}
 
+		// mark the vulnerabilities declared as not affected by the VEX documents
+		if rp, ok := vrp.(*vuln.Report); ok {
+			suppressions, err := a.vexMgr.GetSuppressions(ctx, artifact.RepositoryName, artifact.Digest)
+			if err != nil {
+				return a.SendError(ctx, err)
+			}
+			rp.Suppress(suppressions)
+		}
+
 		vulnerabilities[mimeType] = vrp
 
 		if len(vulnerabilities) != 0 {
@@ -375,6 +388,42 @@ func (a *artifactAPI) GetVulnerabilitiesAddition(ctx context.Context, params ope
 	})
 }
 
+func (a *artifactAPI) GetSbomAddition(ctx context.Context, params operation.GetSbomAdditionParams) middleware.Responder {
+	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionRead, rbac.ResourceArtifactAddition); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	artifact, err := a.artCtl.GetByReference(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName), params.Reference, nil)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	for _, mimeType := range parseSBOMMimeTypes(params.XAcceptSBOM) {
+		reports, err := a.scanCtl.GetReport(ctx, artifact, []string{mimeType})
+		if err != nil {
+			return a.SendError(ctx, err)
+		}
+
+		for _, rp := range reports {
+			// the SBOM is generated for the artifact itself rather than its children
+			if rp.Digest != artifact.Digest || len(rp.Report) == 0 {
+				continue
+			}
+
+			content := []byte(rp.Report)
+			return middleware.ResponderFunc(func(w http.ResponseWriter, p runtime.Producer) {
+				w.Header().Set("Content-Type", mimeType)
+				if params.Download != nil && *params.Download {
+					w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, sbomFileName(params.RepositoryName, artifact.Digest, mimeType)))
+				}
+				w.Write(content)
+			})
+		}
+	}
+
+	return a.SendError(ctx, errors.NotFoundError(nil).WithMessage("SBOM of %s/%s@%s not found", params.ProjectName, params.RepositoryName, params.Reference))
+}
+
 func (a *artifactAPI) GetAddition(ctx context.Context, params operation.GetAdditionParams) middleware.Responder {
 	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionRead, rbac.ResourceArtifactAddition); err != nil {
 		return a.SendError(ctx, err)
@@ -424,10 +473,11 @@ func (a *artifactAPI) RemoveLabel(ctx context.Context, params operation.RemoveLa
 	return operation.NewRemoveLabelOK()
 }
 
-func option(withTag, withImmutableStatus, withLabel, withSignature *bool) *artifact.Option {
+func option(withTag, withImmutableStatus, withLabel, withSignature, withAccessory *bool) *artifact.Option {
 	option := &artifact.Option{
-		WithTag:   true, // return the tag by default
-		WithLabel: lib.BoolValue(withLabel),
+		WithTag:       true, // return the tag by default
+		WithLabel:     lib.BoolValue(withLabel),
+		WithAccessory: lib.BoolValue(withAccessory),
 	}
 
 	if withTag != nil {
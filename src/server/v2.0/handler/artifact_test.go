@@ -25,6 +25,7 @@ import (
 	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
 	scantesting "github.com/goharbor/harbor/src/testing/controller/scan"
 	"github.com/goharbor/harbor/src/testing/mock"
+	vextesting "github.com/goharbor/harbor/src/testing/pkg/vex"
 	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -67,6 +68,7 @@ type ArtifactTestSuite struct {
 
 	artCtl  *artifacttesting.Controller
 	scanCtl *scantesting.Controller
+	vexMgr  *vextesting.Manager
 
 	report1 *scan.Report
 	report2 *scan.Report
@@ -75,11 +77,14 @@ type ArtifactTestSuite struct {
 func (suite *ArtifactTestSuite) SetupSuite() {
 	suite.artCtl = &artifacttesting.Controller{}
 	suite.scanCtl = &scantesting.Controller{}
+	suite.vexMgr = &vextesting.Manager{}
+	mock.OnAnything(suite.vexMgr, "GetSuppressions").Return(nil, nil)
 
 	suite.Config = &restapi.Config{
 		ArtifactAPI: &artifactAPI{
 			artCtl:  suite.artCtl,
 			scanCtl: suite.scanCtl,
+			vexMgr:  suite.vexMgr,
 		},
 	}
 
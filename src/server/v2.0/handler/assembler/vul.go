@@ -20,11 +20,13 @@ import (
 	"github.com/goharbor/harbor/src/controller/scan"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/scan/report"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
 )
 
 const (
 	vulnerabilitiesAddition = "vulnerabilities"
+	sbomAddition            = "sbom"
 )
 
 // NewVulAssembler returns vul assembler
@@ -73,6 +75,11 @@ func (assembler *VulAssembler) Assemble(ctx context.Context) error {
 		artifact.SetAdditionLink(vulnerabilitiesAddition, version)
 
 		if assembler.withScanOverview {
+			// looking up the SBOM reports costs a query per artifact, so it's done only along with the scan overview
+			if assembler.hasSBOM(ctx, artifact) {
+				artifact.SetAdditionLink(sbomAddition, version)
+			}
+
 			for _, mimeType := range assembler.mimeTypes {
 				overview, err := assembler.scanCtl.GetSummary(ctx, &artifact.Artifact, []string{mimeType})
 				if err != nil {
@@ -87,3 +94,20 @@ func (assembler *VulAssembler) Assemble(ctx context.Context) error {
 
 	return nil
 }
+
+// hasSBOM returns true when the SBOM report of the artifact is generated by the scanner
+func (assembler *VulAssembler) hasSBOM(ctx context.Context, artifact *model.Artifact) bool {
+	reports, err := assembler.scanCtl.GetReport(ctx, &artifact.Artifact, report.SBOMMimeTypes)
+	if err != nil {
+		log.Debugf("get SBOM reports of artifact %s@%s failed, error:%v", artifact.RepositoryName, artifact.Digest, err)
+		return false
+	}
+
+	for _, rp := range reports {
+		if rp.Digest == artifact.Digest && len(rp.Report) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
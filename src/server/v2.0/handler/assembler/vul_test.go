@@ -18,6 +18,8 @@ import (
 	"context"
 	"testing"
 
+	daoscan "github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
 	"github.com/goharbor/harbor/src/testing/controller/scan"
 	"github.com/goharbor/harbor/src/testing/mock"
@@ -43,6 +45,7 @@ func (suite *VulAssemblerTestSuite) TestScannable() {
 
 	summary := map[string]interface{}{"key": "value"}
 	mock.OnAnything(scanCtl, "GetSummary").Return(summary, nil)
+	mock.OnAnything(scanCtl, "GetReport").Return(nil, nil)
 
 	var artifact model.Artifact
 
@@ -51,6 +54,48 @@ func (suite *VulAssemblerTestSuite) TestScannable() {
 	suite.Equal(artifact.ScanOverview, summary)
 }
 
+func (suite *VulAssemblerTestSuite) TestSBOM() {
+	checker := &scan.Checker{}
+	scanCtl := &scan.Controller{}
+
+	assembler := VulAssembler{
+		scanChecker:      checker,
+		scanCtl:          scanCtl,
+		withScanOverview: true,
+	}
+
+	mock.OnAnything(checker, "IsScannable").Return(true, nil)
+	mock.OnAnything(scanCtl, "GetReport").Return([]*daoscan.Report{
+		{Digest: "sha256:digest", MimeType: v1.MimeTypeSPDXReport, Report: `{"spdxVersion": "SPDX-2.2"}`},
+	}, nil)
+
+	var art model.Artifact
+	art.Digest = "sha256:digest"
+
+	suite.Nil(assembler.WithArtifacts(&art).Assemble(context.TODO()))
+	suite.Len(art.AdditionLinks, 2)
+	suite.Contains(art.AdditionLinks, sbomAddition)
+}
+
+func (suite *VulAssemblerTestSuite) TestSBOMWithoutScanOverview() {
+	checker := &scan.Checker{}
+	scanCtl := &scan.Controller{}
+
+	assembler := VulAssembler{
+		scanChecker: checker,
+		scanCtl:     scanCtl,
+	}
+
+	mock.OnAnything(checker, "IsScannable").Return(true, nil)
+
+	var art model.Artifact
+	art.Digest = "sha256:digest"
+
+	suite.Nil(assembler.WithArtifacts(&art).Assemble(context.TODO()))
+	suite.Len(art.AdditionLinks, 1)
+	scanCtl.AssertNotCalled(suite.T(), "GetReport")
+}
+
 func (suite *VulAssemblerTestSuite) TestNotScannable() {
 	checker := &scan.Checker{}
 	scanCtl := &scan.Controller{}
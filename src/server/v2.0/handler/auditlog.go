@@ -3,15 +3,19 @@ package handler
 import (
 	"context"
 	"fmt"
+	"net/http"
+
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
-	"github.com/go-openapi/strfmt"
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/common/security"
 	"github.com/goharbor/harbor/src/common/security/local"
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/audit"
+	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	"github.com/goharbor/harbor/src/server/v2.0/restapi/operations/auditlog"
 	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/auditlog"
@@ -31,43 +35,12 @@ type auditlogAPI struct {
 }
 
 func (a *auditlogAPI) ListAuditLogs(ctx context.Context, params auditlog.ListAuditLogsParams) middleware.Responder {
-	secCtx, ok := security.FromContext(ctx)
-	if !ok {
-		return a.SendError(ctx, errors.UnauthorizedError(errors.New("security context not found")))
-	}
-	if !secCtx.IsAuthenticated() {
-		return a.SendError(ctx, errors.UnauthorizedError(nil).WithMessage(secCtx.GetUsername()))
-	}
 	query, err := a.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
 	if err != nil {
 		return a.SendError(ctx, err)
 	}
-
-	if err := a.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceAuditLog); err != nil {
-		ol := &q.OrList{}
-		if sc, ok := secCtx.(*local.SecurityContext); ok && sc.IsAuthenticated() {
-			user := sc.User()
-			member := &project.MemberQuery{
-				UserID:   user.UserID,
-				GroupIDs: user.GroupIDs,
-			}
-
-			projects, err := a.projectCtl.List(ctx, q.New(q.KeyWords{"member": member}), project.Metadata(false))
-			if err != nil {
-				return a.SendError(ctx, fmt.Errorf(
-					"failed to get projects of user %s: %v", secCtx.GetUsername(), err))
-			}
-			for _, project := range projects {
-				if a.HasProjectPermission(ctx, project.ProjectID, rbac.ActionList, rbac.ResourceLog) {
-					ol.Values = append(ol.Values, project.ProjectID)
-				}
-			}
-		}
-		// make sure no project will be selected with the query
-		if len(ol.Values) == 0 {
-			ol.Values = append(ol.Values, -1)
-		}
-		query.Keywords["ProjectID"] = ol
+	if err := a.scopeQuery(ctx, query); err != nil {
+		return a.SendError(ctx, err)
 	}
 
 	total, err := a.auditMgr.Count(ctx, query)
@@ -81,17 +54,78 @@ func (a *auditlogAPI) ListAuditLogs(ctx context.Context, params auditlog.ListAud
 
 	var auditLogs []*models.AuditLog
 	for _, log := range logs {
-		auditLogs = append(auditLogs, &models.AuditLog{
-			ID:           log.ID,
-			Resource:     log.Resource,
-			ResourceType: log.ResourceType,
-			Username:     log.Username,
-			Operation:    log.Operation,
-			OpTime:       strfmt.DateTime(log.OpTime),
-		})
+		auditLogs = append(auditLogs, model.NewAuditLog(log).ToSwagger())
 	}
 	return operation.NewListAuditLogsOK().
 		WithXTotalCount(total).
 		WithLink(a.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
 		WithPayload(auditLogs)
 }
+
+func (a *auditlogAPI) ExportAuditLogs(ctx context.Context, params auditlog.ExportAuditLogsParams) middleware.Responder {
+	query, err := a.BuildQuery(ctx, params.Q, params.Sort, nil, nil)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	if err := a.scopeQuery(ctx, query); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	format := audit.ExportFormatJSONL
+	if params.Format != nil {
+		format = *params.Format
+	}
+	contentType := "application/x-ndjson"
+	if format == audit.ExportFormatCSV {
+		contentType = "text/csv"
+	}
+
+	return middleware.ResponderFunc(func(w http.ResponseWriter, p runtime.Producer) {
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="audit-logs.%s"`, format))
+		// the response is streamed, so the error can only be logged once the writing started
+		if err := a.auditMgr.Export(ctx, query, format, w); err != nil {
+			log.G(ctx).Errorf("failed to export the audit logs: %v", err)
+		}
+	})
+}
+
+// scopeQuery limits the query to the audit logs of the projects that the user has permission to
+// when the user has no permission to list all the audit logs
+func (a *auditlogAPI) scopeQuery(ctx context.Context, query *q.Query) error {
+	secCtx, ok := security.FromContext(ctx)
+	if !ok {
+		return errors.UnauthorizedError(errors.New("security context not found"))
+	}
+	if !secCtx.IsAuthenticated() {
+		return errors.UnauthorizedError(nil).WithMessage(secCtx.GetUsername())
+	}
+	if err := a.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceAuditLog); err == nil {
+		return nil
+	}
+
+	ol := &q.OrList{}
+	if sc, ok := secCtx.(*local.SecurityContext); ok && sc.IsAuthenticated() {
+		user := sc.User()
+		member := &project.MemberQuery{
+			UserID:   user.UserID,
+			GroupIDs: user.GroupIDs,
+		}
+
+		projects, err := a.projectCtl.List(ctx, q.New(q.KeyWords{"member": member}), project.Metadata(false))
+		if err != nil {
+			return fmt.Errorf("failed to get projects of user %s: %v", secCtx.GetUsername(), err)
+		}
+		for _, project := range projects {
+			if a.HasProjectPermission(ctx, project.ProjectID, rbac.ActionList, rbac.ResourceLog) {
+				ol.Values = append(ol.Values, project.ProjectID)
+			}
+		}
+	}
+	// make sure no project will be selected with the query
+	if len(ol.Values) == 0 {
+		ol.Values = append(ol.Values, -1)
+	}
+	query.Keywords["ProjectID"] = ol
+	return nil
+}
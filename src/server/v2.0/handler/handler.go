@@ -51,6 +51,7 @@ func New() http.Handler {
 		LdapAPI:               newLdapAPI(),
 		LabelAPI:              newLabelAPI(),
 		GCAPI:                 newGCAPI(),
+		PurgeAPI:              newPurgeAPI(),
 		QuotaAPI:              newQuotaAPI(),
 		RetentionAPI:          newRetentionAPI(),
 		WebhookAPI:            newNotificationPolicyAPI(),
@@ -65,6 +66,8 @@ func New() http.Handler {
 		StatisticAPI:          newStatisticAPI(),
 		ProjectMetadataAPI:    newProjectMetadaAPI(),
 		RequestAPI:            newRequestsAPI(),
+		VulnerabilityAPI:      newVulnerabilityAPI(),
+		VexAPI:                newVexAPI(),
 	})
 	if err != nil {
 		log.Fatal(err)
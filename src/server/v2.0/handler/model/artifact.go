@@ -20,6 +20,7 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/lib/log"
+	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
 	pkg_art "github.com/goharbor/harbor/src/pkg/artifact"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 )
@@ -63,6 +64,9 @@ func (a *Artifact) ToSwagger() *models.Artifact {
 	for _, label := range a.Labels {
 		art.Labels = append(art.Labels, NewLabel(label).ToSwagger())
 	}
+	for _, acc := range a.Accessories {
+		art.Accessories = append(art.Accessories, NewAccessory(acc).ToSwagger())
+	}
 	if len(a.ScanOverview) > 0 {
 		art.ScanOverview = models.ScanOverview{}
 		for key, value := range a.ScanOverview {
@@ -131,3 +135,26 @@ func (r *Reference) ToSwagger() *models.Reference {
 func NewReference(r *pkg_art.Reference) *Reference {
 	return &Reference{Reference: r}
 }
+
+// Accessory is the accessory attached to the subject artifact
+type Accessory struct {
+	*accessorymodel.Accessory
+}
+
+// ToSwagger converts the accessory to the swagger model
+func (a *Accessory) ToSwagger() *models.Accessory {
+	return &models.Accessory{
+		ID:                a.ID,
+		ArtifactID:        a.ArtifactID,
+		SubjectArtifactID: a.SubjectArtifactID,
+		Type:              a.Type,
+		Size:              a.Size,
+		Digest:            a.Digest,
+		CreationTime:      strfmt.DateTime(a.CreationTime),
+	}
+}
+
+// NewAccessory ...
+func NewAccessory(a *accessorymodel.Accessory) *Accessory {
+	return &Accessory{Accessory: a}
+}
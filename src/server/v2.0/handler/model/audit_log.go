@@ -0,0 +1,49 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/go-openapi/strfmt"
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+// AuditLog model
+type AuditLog struct {
+	*model.AuditLog
+}
+
+// ToSwagger converts the audit log to the swagger model
+func (a *AuditLog) ToSwagger() *models.AuditLog {
+	return &models.AuditLog{
+		ID:           a.ID,
+		Resource:     a.Resource,
+		ResourceType: a.ResourceType,
+		Username:     a.Username,
+		Operation:    a.Operation,
+		Reason:       a.Reason,
+		ClientIP:     a.ClientIP,
+		UserAgent:    a.UserAgent,
+		RequestID:    a.RequestID,
+		AuthMethod:   a.AuthMethod,
+		Success:      a.Success,
+		OpTime:       strfmt.DateTime(a.OpTime),
+	}
+}
+
+// NewAuditLog ...
+func NewAuditLog(a *model.AuditLog) *AuditLog {
+	return &AuditLog{AuditLog: a}
+}
@@ -23,7 +23,11 @@ func (l *CVEAllowlist) ToSwagger() *svrmodels.CVEAllowlist {
 	}
 	for _, it := range l.Items {
 		cveItem := &svrmodels.CVEAllowlistItem{
-			CVEID: it.CVEID,
+			CVEID:         it.CVEID,
+			ExpiresAt:     it.ExpiresAt,
+			Justification: it.Justification,
+			Author:        it.Author,
+			Repository:    it.Repository,
 		}
 		res.Items = append(res.Items, cveItem)
 	}
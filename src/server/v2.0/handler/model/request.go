@@ -15,8 +15,14 @@
 package model
 
 import (
+	"strings"
+
 	"github.com/go-openapi/strfmt"
 	"github.com/goharbor/harbor/src/controller/request"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/log"
+	reqModels "github.com/goharbor/harbor/src/pkg/request/models"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 )
 
@@ -27,6 +33,44 @@ type Request struct {
 
 // ToSwagger converts the request to the swagger model
 func (r *Request) ToSwagger() *models.Request {
+	var md *models.ProjectMetadata
+	if len(r.Metadata) > 0 {
+		var m models.ProjectMetadata
+		if err := lib.JSONCopy(&m, r.Metadata); err != nil {
+			log.Warningf("failed to copy metadata form %T", r.Metadata)
+		}
+
+		// Transform the severity to severity of CVSS v3.0 Ratings
+		if m.Severity != nil {
+			severity := strings.ToLower(vuln.ParseSeverityVersion3(*m.Severity).String())
+			m.Severity = &severity
+		}
+
+		md = &m
+	}
+
+	var registryID *int64
+	if r.RegistryID != 0 {
+		registryID = &r.RegistryID
+	}
+
+	var members []*models.ProjectMember
+	for _, m := range r.Members {
+		members = append(members, &models.ProjectMember{
+			RoleID: int64(m.Role),
+			MemberUser: &models.UserEntity{
+				UserID:   int64(m.UserID),
+				Username: m.Username,
+			},
+			MemberGroup: &models.UserGroup{
+				ID:          int64(m.GroupID),
+				GroupName:   m.GroupName,
+				GroupType:   int64(m.GroupType),
+				LdapGroupDn: m.LdapGroupDN,
+			},
+		})
+	}
+
 	return &models.Request{
 		CreationTime: strfmt.DateTime(r.CreationTime),
 		Name:         r.Name,
@@ -35,11 +79,56 @@ func (r *Request) ToSwagger() *models.Request {
 		RequestID:    int32(r.RequestID),
 		UpdateTime:   strfmt.DateTime(r.UpdateTime),
 		IsApproved:   int32(r.IsApproved),
+		Status:       reqModels.StateName(r.IsApproved),
 		StorageQuota: &r.StorageQuota,
+		Comment:      r.Comment,
+		Metadata:     md,
+		RegistryID:   registryID,
+		Members:      members,
+	}
+}
+
+// NewRequestMember converts the swagger project member to the initial member of the request
+func NewRequestMember(m *models.ProjectMember) *reqModels.Member {
+	member := &reqModels.Member{Role: int(m.RoleID)}
+	if m.MemberUser != nil {
+		member.UserID = int(m.MemberUser.UserID)
+		member.Username = m.MemberUser.Username
+	}
+	if m.MemberGroup != nil {
+		member.GroupID = int(m.MemberGroup.ID)
+		member.GroupName = m.MemberGroup.GroupName
+		member.GroupType = int(m.MemberGroup.GroupType)
+		member.LdapGroupDN = m.MemberGroup.LdapGroupDn
 	}
+	return member
 }
 
 // NewRequest ...
 func NewRequest(p *request.Request) *Request {
 	return &Request{p}
 }
+
+// RequestHistory model
+type RequestHistory struct {
+	*reqModels.History
+}
+
+// ToSwagger converts the request history to the swagger model
+func (h *RequestHistory) ToSwagger() *models.RequestHistory {
+	return &models.RequestHistory{
+		ID:           h.ID,
+		RequestID:    h.RequestID,
+		FromState:    reqModels.StateName(h.FromState),
+		ToState:      reqModels.StateName(h.ToState),
+		Operator:     h.Operator,
+		Comment:      h.Comment,
+		Rule:         h.Rule,
+		CreationTime: strfmt.DateTime(h.CreationTime),
+	}
+}
+
+// NewRequestHistory ...
+func NewRequestHistory(h *reqModels.History) *RequestHistory {
+	return &RequestHistory{h}
+}
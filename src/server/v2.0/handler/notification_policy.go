@@ -7,6 +7,7 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/controller/event"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
@@ -14,10 +15,12 @@ import (
 	"github.com/goharbor/harbor/src/pkg/notification/job"
 	"github.com/goharbor/harbor/src/pkg/notification/policy"
 	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	notifierModel "github.com/goharbor/harbor/src/pkg/notifier/model"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	"github.com/goharbor/harbor/src/server/v2.0/restapi/operations/webhook"
 	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/webhook"
+	"net/mail"
 	"strings"
 	"time"
 )
@@ -88,6 +91,9 @@ func (n *notificationPolicyAPI) CreateWebhookPolicyOfProject(ctx context.Context
 	if ok, err := n.validateEventTypes(policy); !ok {
 		return n.SendError(ctx, err)
 	}
+	if err := n.requireSysAdminForRequestEvents(ctx, policy, rbac.ActionCreate); err != nil {
+		return n.SendError(ctx, err)
+	}
 	if ok, err := n.validateTargets(policy); !ok {
 		return n.SendError(ctx, err)
 	}
@@ -118,6 +124,9 @@ func (n *notificationPolicyAPI) UpdateWebhookPolicyOfProject(ctx context.Context
 	if ok, err := n.validateEventTypes(policy); !ok {
 		return n.SendError(ctx, err)
 	}
+	if err := n.requireSysAdminForRequestEvents(ctx, policy, rbac.ActionUpdate); err != nil {
+		return n.SendError(ctx, err)
+	}
 	if ok, err := n.validateTargets(policy); !ok {
 		return n.SendError(ctx, err)
 	}
@@ -225,6 +234,14 @@ func (n *notificationPolicyAPI) validateTargets(policy *policy_model.Policy) (bo
 		return false, errors.New(nil).WithMessage("empty notification target with policy %s", policy.Name).WithCode(errors.BadRequestCode)
 	}
 	for _, target := range policy.Targets {
+		if target.Type == notifierModel.NotifyTypeEmail {
+			// the address of email target is the comma separated recipients
+			if _, err := mail.ParseAddressList(target.Address); err != nil {
+				return false, errors.New(err).WithMessage("invalid email address %s with policy %s", target.Address, policy.Name).WithCode(errors.BadRequestCode)
+			}
+			continue
+		}
+
 		url, err := utils.ParseEndpoint(target.Address)
 		if err != nil {
 			return false, errors.New(err).WithCode(errors.BadRequestCode)
@@ -253,6 +270,18 @@ func (n *notificationPolicyAPI) validateEventTypes(policy *policy_model.Policy)
 	return true, nil
 }
 
+// requireSysAdminForRequestEvents requires the system admin to subscribe the project request events,
+// as they are sent by the policies of all the projects
+func (n *notificationPolicyAPI) requireSysAdminForRequestEvents(ctx context.Context, policy *policy_model.Policy, action rbac.Action) error {
+	for _, eventType := range policy.EventTypes {
+		switch eventType {
+		case event.TopicCreateRequest, event.TopicApproveRequest, event.TopicRejectRequest:
+			return n.RequireSystemAccess(ctx, action, rbac.ResourceRequest)
+		}
+	}
+	return nil
+}
+
 // constructPolicyWithTriggerTime construct notification policy information displayed in UI
 // including event type, enabled, creation time, last trigger time
 func (n *notificationPolicyAPI) constructPolicyWithTriggerTime(ctx context.Context, policies []*policy_model.Policy) ([]*models.WebhookLastTrigger, error) {
@@ -34,6 +34,7 @@ import (
 	"github.com/goharbor/harbor/src/controller/registry"
 	"github.com/goharbor/harbor/src/controller/repository"
 	"github.com/goharbor/harbor/src/controller/retention"
+	"github.com/goharbor/harbor/src/controller/scan"
 	"github.com/goharbor/harbor/src/controller/scanner"
 	"github.com/goharbor/harbor/src/controller/user"
 	"github.com/goharbor/harbor/src/core/api"
@@ -43,6 +44,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
+	allowlistModels "github.com/goharbor/harbor/src/pkg/allowlist/models"
 	"github.com/goharbor/harbor/src/pkg/audit"
 	"github.com/goharbor/harbor/src/pkg/member"
 	"github.com/goharbor/harbor/src/pkg/project/metadata"
@@ -50,6 +52,9 @@ import (
 	"github.com/goharbor/harbor/src/pkg/quota/types"
 	"github.com/goharbor/harbor/src/pkg/retention/policy"
 	"github.com/goharbor/harbor/src/pkg/robot"
+	scanPolicy "github.com/goharbor/harbor/src/pkg/scan/policy"
+	"github.com/goharbor/harbor/src/pkg/signature/cosign"
+	"github.com/goharbor/harbor/src/pkg/task"
 	userModels "github.com/goharbor/harbor/src/pkg/user/models"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
@@ -72,6 +77,7 @@ func newProjectAPI() *projectAPI {
 		preheatCtl:    preheat.Ctl,
 		retentionCtl:  retention.Ctl,
 		scannerCtl:    scanner.DefaultController,
+		scanCtl:       scan.DefaultController,
 	}
 }
 
@@ -88,6 +94,7 @@ type projectAPI struct {
 	preheatCtl    preheat.Controller
 	retentionCtl  retention.Controller
 	scannerCtl    scanner.Controller
+	scanCtl       scan.Controller
 }
 
 func (a *projectAPI) CreateProject(ctx context.Context, params operation.CreateProjectParams) middleware.Responder {
@@ -276,6 +283,11 @@ func (a *projectAPI) DeleteProject(ctx context.Context, params operation.DeleteP
 		return a.SendError(ctx, err)
 	}
 
+	// the re-scan policy is removed by the cascade deletion, but its schedule and executions are not
+	if err = a.scanCtl.DeleteRescanPolicy(ctx, p.ProjectID); err != nil && !errors.IsNotFoundErr(err) {
+		return a.SendError(ctx, err)
+	}
+
 	return operation.NewDeleteProjectOK()
 }
 
@@ -304,14 +316,7 @@ func (a *projectAPI) GetLogs(ctx context.Context, params operation.GetLogsParams
 
 	var auditLogs []*models.AuditLog
 	for _, log := range logs {
-		auditLogs = append(auditLogs, &models.AuditLog{
-			ID:           log.ID,
-			Resource:     log.Resource,
-			ResourceType: log.ResourceType,
-			Username:     log.Username,
-			Operation:    log.Operation,
-			OpTime:       strfmt.DateTime(log.OpTime),
-		})
+		auditLogs = append(auditLogs, model.NewAuditLog(log).ToSwagger())
 	}
 	return operation.NewGetLogsOK().
 		WithXTotalCount(total).
@@ -519,11 +524,14 @@ func (a *projectAPI) UpdateProject(ctx context.Context, params operation.UpdateP
 		return a.SendError(ctx, err)
 	}
 
-	p, err := a.projectCtl.Get(ctx, projectNameOrID, project.Metadata(false))
+	p, err := a.projectCtl.Get(ctx, projectNameOrID, project.Metadata(false), project.WithCVEAllowlist())
 	if err != nil {
 		return a.SendError(ctx, err)
 	}
 
+	// the allowlist is loaded to keep the authors of the unchanged items and is updated only when it's in the request
+	stored := p.CVEAllowlist
+	p.CVEAllowlist = allowlistModels.CVEAllowlist{}
 	if params.Project.CVEAllowlist != nil {
 		if params.Project.CVEAllowlist.ProjectID == 0 {
 			// project_id in cve_allowlist not provided or provided as 0, let it to be the id of the project which will be updating
@@ -536,6 +544,9 @@ func (a *projectAPI) UpdateProject(ctx context.Context, params operation.UpdateP
 		if err := lib.JSONCopy(&p.CVEAllowlist, params.Project.CVEAllowlist); err != nil {
 			return a.SendError(ctx, errors.UnknownError(nil).WithMessage("failed to process cve_allowlist, error: %v", err))
 		}
+		if err := prepareCVEAllowlist(ctx, &p.CVEAllowlist, &stored); err != nil {
+			return a.SendError(ctx, err)
+		}
 	}
 
 	// ignore enable_content_trust metadata for proxy cache project
@@ -543,6 +554,9 @@ func (a *projectAPI) UpdateProject(ctx context.Context, params operation.UpdateP
 	if params.Project.Metadata != nil && p.IsProxy() {
 		params.Project.Metadata.EnableContentTrust = nil
 	}
+	if err := validateCosignPublicKeys(params.Project.Metadata); err != nil {
+		return a.SendError(ctx, err)
+	}
 	lib.JSONCopy(&p.Metadata, params.Project.Metadata)
 
 	if err := a.projectCtl.Update(ctx, p); err != nil {
@@ -633,6 +647,97 @@ func (a *projectAPI) SetScannerOfProject(ctx context.Context, params operation.S
 	return operation.NewSetScannerOfProjectOK()
 }
 
+func (a *projectAPI) GetRescanPolicy(ctx context.Context, params operation.GetRescanPolicyParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionRead, rbac.ResourceScanner); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID, project.Metadata(false))
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	pl, err := a.scanCtl.GetRescanPolicy(ctx, p.ProjectID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewGetRescanPolicyOK().WithPayload(&models.RescanPolicy{
+		Enabled:           pl.Enabled,
+		Cron:              pl.Cron,
+		StaleDays:         int64(pl.StaleDays),
+		OutdatedScanner:   pl.OutdatedScanner,
+		PulledWithinDays:  int64(pl.PulledWithinDays),
+		MaxConcurrentJobs: int64(pl.MaxConcurrentJobs),
+		CreationTime:      strfmt.DateTime(pl.CreationTime),
+		UpdateTime:        strfmt.DateTime(pl.UpdateTime),
+	})
+}
+
+func (a *projectAPI) SetRescanPolicy(ctx context.Context, params operation.SetRescanPolicyParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionCreate, rbac.ResourceScanner); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID, project.Metadata(false))
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	pl := &scanPolicy.Policy{
+		ProjectID:         p.ProjectID,
+		Enabled:           params.Policy.Enabled,
+		Cron:              params.Policy.Cron,
+		StaleDays:         int(params.Policy.StaleDays),
+		OutdatedScanner:   params.Policy.OutdatedScanner,
+		PulledWithinDays:  int(params.Policy.PulledWithinDays),
+		MaxConcurrentJobs: int(params.Policy.MaxConcurrentJobs),
+	}
+	if err := a.scanCtl.SetRescanPolicy(ctx, pl); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewSetRescanPolicyOK()
+}
+
+func (a *projectAPI) DeleteRescanPolicy(ctx context.Context, params operation.DeleteRescanPolicyParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionCreate, rbac.ResourceScanner); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID, project.Metadata(false))
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	if err := a.scanCtl.DeleteRescanPolicy(ctx, p.ProjectID); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewDeleteRescanPolicyOK()
+}
+
+func (a *projectAPI) TriggerRescan(ctx context.Context, params operation.TriggerRescanParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionCreate, rbac.ResourceScan); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID, project.Metadata(false))
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	if _, err := a.scanCtl.Rescan(ctx, p.ProjectID, task.ExecutionTriggerManual, true); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewTriggerRescanAccepted()
+}
+
 func (a *projectAPI) deletable(ctx context.Context, projectNameOrID interface{}) (*project.Project, *models.ProjectDeletable, error) {
 	p, err := a.getProject(ctx, projectNameOrID)
 	if err != nil {
@@ -693,7 +798,16 @@ func (a *projectAPI) validateProjectReq(ctx context.Context, req *models.Project
 		}
 	}
 
-	return nil
+	return validateCosignPublicKeys(req.Metadata)
+}
+
+// validateCosignPublicKeys validates the cosign public keys in the metadata of the project
+func validateCosignPublicKeys(metadata *models.ProjectMetadata) error {
+	if metadata == nil || metadata.CosignPublicKeys == nil || len(*metadata.CosignPublicKeys) == 0 {
+		return nil
+	}
+	_, err := cosign.ParsePublicKeys(*metadata.CosignPublicKeys)
+	return err
 }
 
 func (a *projectAPI) populateProperties(ctx context.Context, p *project.Project) error {
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/purge"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/purge"
+)
+
+type purgeAPI struct {
+	BaseAPI
+	purgeCtr purge.Controller
+}
+
+func newPurgeAPI() *purgeAPI {
+	return &purgeAPI{
+		purgeCtr: purge.Ctl,
+	}
+}
+
+func (p *purgeAPI) Prepare(ctx context.Context, operation string, params interface{}) middleware.Responder {
+	return nil
+}
+
+func (p *purgeAPI) CreatePurgeSchedule(ctx context.Context, params operation.CreatePurgeScheduleParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourcePurgeAuditLog); err != nil {
+		return p.SendError(ctx, err)
+	}
+	id, err := p.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, params.Schedule.Parameters)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	// replace the /api/v2.0/system/purgeaudit/schedule/{id} to /api/v2.0/system/purgeaudit/{id}
+	lastSlashIndex := strings.LastIndex(params.HTTPRequest.URL.Path, "/")
+	if lastSlashIndex != -1 {
+		location := fmt.Sprintf("%s/%d", params.HTTPRequest.URL.Path[:lastSlashIndex], id)
+		return operation.NewCreatePurgeScheduleCreated().WithLocation(location)
+	}
+	return operation.NewCreatePurgeScheduleCreated()
+}
+
+func (p *purgeAPI) UpdatePurgeSchedule(ctx context.Context, params operation.UpdatePurgeScheduleParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourcePurgeAuditLog); err != nil {
+		return p.SendError(ctx, err)
+	}
+	_, err := p.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, params.Schedule.Parameters)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewUpdatePurgeScheduleOK()
+}
+
+func (p *purgeAPI) kick(ctx context.Context, scheType string, cron string, parameters map[string]interface{}) (int64, error) {
+	if parameters == nil {
+		parameters = make(map[string]interface{})
+	}
+	var err error
+	var id int64
+	switch scheType {
+	case ScheduleManual:
+		id, err = p.purgeCtr.Start(ctx, purgePolicy(parameters), task.ExecutionTriggerManual)
+	case ScheduleNone:
+		err = p.purgeCtr.DeleteSchedule(ctx)
+	case ScheduleHourly, ScheduleDaily, ScheduleWeekly, ScheduleCustom:
+		err = p.updateSchedule(ctx, scheType, cron, purgePolicy(parameters))
+	}
+	return id, err
+}
+
+func (p *purgeAPI) updateSchedule(ctx context.Context, cronType, cron string, policy purge.Policy) error {
+	if cron == "" {
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("empty cron string for purge schedule")
+	}
+	if err := p.purgeCtr.DeleteSchedule(ctx); err != nil {
+		return err
+	}
+	_, err := p.purgeCtr.CreateSchedule(ctx, cronType, cron, policy)
+	return err
+}
+
+func (p *purgeAPI) GetPurgeSchedule(ctx context.Context, params operation.GetPurgeScheduleParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourcePurgeAuditLog); err != nil {
+		return p.SendError(ctx, err)
+	}
+	schedule, err := p.purgeCtr.GetSchedule(ctx)
+	if errors.IsNotFoundErr(err) {
+		return operation.NewGetPurgeScheduleOK()
+	}
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+
+	return operation.NewGetPurgeScheduleOK().WithPayload(model.NewPurgeSchedule(schedule).ToSwagger())
+}
+
+func (p *purgeAPI) GetPurgeHistory(ctx context.Context, params operation.GetPurgeHistoryParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourcePurgeAuditLog); err != nil {
+		return p.SendError(ctx, err)
+	}
+	query, err := p.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	total, err := p.purgeCtr.ExecutionCount(ctx, query)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	execs, err := p.purgeCtr.ListExecutions(ctx, query)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+
+	var results []*models.ExecHistory
+	for _, exec := range execs {
+		h, err := execHistory(exec)
+		if err != nil {
+			return p.SendError(ctx, err)
+		}
+		results = append(results, h.ToSwagger())
+	}
+
+	return operation.NewGetPurgeHistoryOK().
+		WithXTotalCount(total).
+		WithLink(p.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(results)
+}
+
+func (p *purgeAPI) GetPurgeJob(ctx context.Context, params operation.GetPurgeJobParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourcePurgeAuditLog); err != nil {
+		return p.SendError(ctx, err)
+	}
+	exec, err := p.purgeCtr.GetExecution(ctx, params.PurgeID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	h, err := execHistory(exec)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewGetPurgeJobOK().WithPayload(h.ToSwagger())
+}
+
+func (p *purgeAPI) GetPurgeJobLog(ctx context.Context, params operation.GetPurgeJobLogParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourcePurgeAuditLog); err != nil {
+		return p.SendError(ctx, err)
+	}
+	tasks, err := p.purgeCtr.ListTasks(ctx, q.New(q.KeyWords{
+		"ExecutionID": params.PurgeID,
+	}))
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	if len(tasks) == 0 {
+		return p.SendError(ctx, errors.New(nil).WithCode(errors.NotFoundCode).WithMessage("purge job %d log is not found", params.PurgeID))
+	}
+	log, err := p.purgeCtr.GetTaskLog(ctx, tasks[0].ID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewGetPurgeJobLogOK().WithPayload(string(log))
+}
+
+func purgePolicy(parameters map[string]interface{}) purge.Policy {
+	policy := purge.Policy{
+		ExtraAttrs: parameters,
+	}
+	if hour, ok := parameters["audit_retention_hour"].(float64); ok {
+		policy.RetentionHour = int(hour)
+	}
+	if ops, ok := parameters["include_operations"].(string); ok {
+		policy.IncludeOperations = ops
+	}
+	if hours, ok := parameters["operation_retention_hours"].(map[string]interface{}); ok {
+		policy.OperationRetentionHours = make(map[string]int)
+		for op, hour := range hours {
+			// the invalid hours are kept as 0 and rejected by the controller
+			h, _ := hour.(float64)
+			policy.OperationRetentionHours[op] = int(h)
+		}
+	}
+	if dryRun, ok := parameters["dry_run"].(bool); ok {
+		policy.DryRun = dryRun
+	}
+	return policy
+}
+
+func execHistory(exec *purge.Execution) (*model.ExecHistory, error) {
+	extraAttrsString, err := json.Marshal(exec.ExtraAttrs)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ExecHistory{
+		ID:         exec.ID,
+		Name:       purge.VendorType,
+		Kind:       exec.Trigger,
+		Parameters: string(extraAttrsString),
+		Status:     exec.Status,
+		Schedule: &model.ScheduleParam{
+			Type: exec.Trigger,
+		},
+		CreationTime: exec.StartTime,
+		UpdateTime:   exec.EndTime,
+	}, nil
+}
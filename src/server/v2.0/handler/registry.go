@@ -51,6 +51,10 @@ func (r *registryAPI) CreateRegistry(ctx context.Context, params operation.Creat
 		Type:        params.Registry.Type,
 		URL:         params.Registry.URL,
 		Insecure:    params.Registry.Insecure,
+		Speed:       params.Registry.Speed,
+	}
+	if len(params.Registry.BandwidthSchedule) > 0 {
+		registry.BandwidthSchedule = toBandwidthSchedule(params.Registry.BandwidthSchedule)
 	}
 	if params.Registry.Credential != nil {
 		registry.Credential = &model.Credential{
@@ -142,6 +146,13 @@ func (r *registryAPI) UpdateRegistry(ctx context.Context, params operation.Updat
 		if params.Registry.Insecure != nil {
 			registry.Insecure = *params.Registry.Insecure
 		}
+		if params.Registry.Speed != nil {
+			registry.Speed = *params.Registry.Speed
+		}
+		// the schedule is replaced only when it is specified
+		if params.Registry.BandwidthSchedule != nil {
+			registry.BandwidthSchedule = toBandwidthSchedule(params.Registry.BandwidthSchedule)
+		}
 		if registry.Credential == nil {
 			registry.Credential = &model.Credential{}
 		}
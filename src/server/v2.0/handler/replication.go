@@ -107,6 +107,22 @@ func (r *replicationAPI) CreateReplicationPolicy(ctx context.Context, params ope
 		}
 		policy.Speed = *params.Policy.Speed
 	}
+	if len(params.Policy.BandwidthSchedule) > 0 {
+		policy.BandwidthSchedule = toBandwidthSchedule(params.Policy.BandwidthSchedule)
+	}
+	for _, rule := range params.Policy.RewriteRules {
+		if rule == nil {
+			continue
+		}
+		policy.RewriteRules = append(policy.RewriteRules, &model.RewriteRule{
+			Type:        rule.Type,
+			Kind:        rule.Kind,
+			Pattern:     rule.Pattern,
+			Replacement: rule.Replacement,
+		})
+	}
+	policy.Sync = params.Policy.Sync
+	policy.ConflictResolution = params.Policy.ConflictResolution
 	id, err := r.ctl.CreatePolicy(ctx, policy)
 	if err != nil {
 		return r.SendError(ctx, err)
@@ -170,6 +186,22 @@ func (r *replicationAPI) UpdateReplicationPolicy(ctx context.Context, params ope
 		}
 		policy.Speed = *params.Policy.Speed
 	}
+	if len(params.Policy.BandwidthSchedule) > 0 {
+		policy.BandwidthSchedule = toBandwidthSchedule(params.Policy.BandwidthSchedule)
+	}
+	for _, rule := range params.Policy.RewriteRules {
+		if rule == nil {
+			continue
+		}
+		policy.RewriteRules = append(policy.RewriteRules, &model.RewriteRule{
+			Type:        rule.Type,
+			Kind:        rule.Kind,
+			Pattern:     rule.Pattern,
+			Replacement: rule.Replacement,
+		})
+	}
+	policy.Sync = params.Policy.Sync
+	policy.ConflictResolution = params.Policy.ConflictResolution
 	if err := r.ctl.UpdatePolicy(ctx, policy); err != nil {
 		return r.SendError(ctx, err)
 	}
@@ -228,6 +260,21 @@ func (r *replicationAPI) DeleteReplicationPolicy(ctx context.Context, params ope
 	return operation.NewDeleteReplicationPolicyOK()
 }
 
+func (r *replicationAPI) DryRunReplicationPolicy(ctx context.Context, params operation.DryRunReplicationPolicyParams) middleware.Responder {
+	if err := r.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourceReplication); err != nil {
+		return r.SendError(ctx, err)
+	}
+	policy, err := r.ctl.GetPolicy(ctx, params.ID)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	plan, err := r.ctl.DryRun(ctx, policy, nil)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	return operation.NewDryRunReplicationPolicyOK().WithPayload(convertReplicationPlan(plan))
+}
+
 func (r *replicationAPI) StartReplication(ctx context.Context, params operation.StartReplicationParams) middleware.Responder {
 	if err := r.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourceReplication); err != nil {
 		return r.SendError(ctx, err)
@@ -427,6 +474,8 @@ func convertReplicationPolicy(policy *repctlmodel.Policy) *models.ReplicationPol
 		Override:                  policy.Override,
 		ReplicateDeletion:         policy.ReplicateDeletion,
 		Speed:                     &policy.Speed,
+		Sync:                      policy.Sync,
+		ConflictResolution:        policy.ConflictResolution,
 		UpdateTime:                strfmt.DateTime(policy.UpdateTime),
 	}
 	if policy.SrcRegistry != nil {
@@ -455,9 +504,97 @@ func convertReplicationPolicy(policy *repctlmodel.Policy) *models.ReplicationPol
 		}
 		p.Trigger = trigger
 	}
+	p.BandwidthSchedule = convertBandwidthSchedule(policy.BandwidthSchedule)
+	for _, rule := range policy.RewriteRules {
+		p.RewriteRules = append(p.RewriteRules, &models.ReplicationRewriteRule{
+			Type:        rule.Type,
+			Kind:        rule.Kind,
+			Pattern:     rule.Pattern,
+			Replacement: rule.Replacement,
+		})
+	}
 	return p
 }
 
+func convertReplicationPlan(plan *repctlmodel.Plan) *models.ReplicationPlan {
+	p := &models.ReplicationPlan{
+		Copies:    []*models.ReplicationPlanItem{},
+		Deletions: []*models.ReplicationPlanItem{},
+		Skips:     []*models.ReplicationPlanItem{},
+		TotalSize: plan.TotalSize,
+	}
+	for _, item := range plan.Copies {
+		p.Copies = append(p.Copies, convertReplicationPlanItem(item))
+	}
+	for _, item := range plan.Deletions {
+		p.Deletions = append(p.Deletions, convertReplicationPlanItem(item))
+	}
+	for _, item := range plan.Skips {
+		p.Skips = append(p.Skips, convertReplicationPlanItem(item))
+	}
+	p.Conflicts = convertSyncConflicts(plan.Conflicts)
+	return p
+}
+
+func convertSyncConflicts(conflicts []*repctlmodel.SyncConflict) []*models.ReplicationSyncConflict {
+	var cs []*models.ReplicationSyncConflict
+	for _, conflict := range conflicts {
+		cs = append(cs, &models.ReplicationSyncConflict{
+			Repository:        conflict.Repository,
+			Tag:               conflict.Tag,
+			SourceDigest:      conflict.SourceDigest,
+			DestinationDigest: conflict.DestinationDigest,
+			Winner:            conflict.Winner,
+		})
+	}
+	return cs
+}
+
+func convertReplicationPlanItem(item *repctlmodel.PlanItem) *models.ReplicationPlanItem {
+	i := &models.ReplicationPlanItem{
+		Operation:           item.Operation,
+		ResourceType:        item.ResourceType,
+		SourceResource:      item.SourceResource,
+		DestinationResource: item.DestinationResource,
+		Size:                item.Size,
+	}
+	for _, artifact := range item.Artifacts {
+		i.Artifacts = append(i.Artifacts, &models.ReplicationPlanArtifact{
+			Digest: artifact.Digest,
+			Tags:   artifact.Tags,
+			Size:   artifact.Size,
+		})
+	}
+	return i
+}
+
+func toBandwidthSchedule(windows []*models.BandwidthWindow) model.BandwidthSchedule {
+	schedule := model.BandwidthSchedule{}
+	for _, window := range windows {
+		if window == nil {
+			continue
+		}
+		schedule = append(schedule, &model.BandwidthWindow{
+			Start: window.Start,
+			End:   window.End,
+			Speed: window.Speed,
+		})
+	}
+	return schedule
+}
+
+func convertBandwidthSchedule(schedule model.BandwidthSchedule) []*models.BandwidthWindow {
+	var windows []*models.BandwidthWindow
+	for _, window := range schedule {
+		windows = append(windows, &models.BandwidthWindow{
+			Start: window.Start,
+			End:   window.End,
+			Speed: window.Speed,
+		})
+	}
+	return windows
+}
+
 func convertRegistry(registry *model.Registry) *models.Registry {
 	r := &models.Registry{
 		CreationTime: strfmt.DateTime(registry.CreationTime),
@@ -465,11 +602,13 @@ func convertRegistry(registry *model.Registry) *models.Registry {
 		ID:           registry.ID,
 		Insecure:     registry.Insecure,
 		Name:         registry.Name,
+		Speed:        registry.Speed,
 		Status:       registry.Status,
 		Type:         string(registry.Type),
 		UpdateTime:   strfmt.DateTime(registry.UpdateTime),
 		URL:          registry.URL,
 	}
+	r.BandwidthSchedule = convertBandwidthSchedule(registry.BandwidthSchedule)
 	if registry.Credential != nil {
 		credential := &models.RegistryCredential{
 			AccessKey: registry.Credential.AccessKey,
@@ -500,6 +639,7 @@ func convertExecution(execution *replication.Execution) *models.ReplicationExecu
 			execution.Metrics.ScheduledTaskCount + execution.Metrics.RunningTaskCount
 		exec.Stopped = execution.Metrics.StoppedTaskCount
 	}
+	exec.Conflicts = convertSyncConflicts(execution.Conflicts)
 	switch execution.Trigger {
 	case task.ExecutionTriggerManual:
 		exec.Trigger = "manual"
@@ -17,14 +17,10 @@ package handler
 import (
 	"context"
 	"fmt"
-	"github.com/goharbor/harbor/src/common/security/robot"
-	robotCtr "github.com/goharbor/harbor/src/controller/robot"
-	pkgModels "github.com/goharbor/harbor/src/pkg/project/models"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/common/security"
-	"github.com/goharbor/harbor/src/common/security/local"
 	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/event/metadata"
 	"github.com/goharbor/harbor/src/controller/project"
@@ -73,7 +69,7 @@ func (r *repositoryAPI) ListAllRepositories(ctx context.Context, params operatio
 		return r.SendError(ctx, errors.UnauthorizedError(errors.New("security context not found")))
 	}
 	if !secCtx.IsSysAdmin() && !secCtx.IsSolutionUser() {
-		projectIDs, err := r.listAuthorizedProjectIDs(ctx)
+		projectIDs, err := listAuthorizedProjectIDs(ctx, r.proCtl)
 		if err != nil {
 			return r.SendError(ctx, err)
 		}
@@ -109,60 +105,6 @@ func (r *repositoryAPI) ListAllRepositories(ctx context.Context, params operatio
 		WithPayload(repos)
 }
 
-func (r *repositoryAPI) listAuthorizedProjectIDs(ctx context.Context) ([]int64, error) {
-	secCtx, ok := security.FromContext(ctx)
-	if !ok {
-		return nil, errors.UnauthorizedError(errors.New("security context not found"))
-	}
-	query := &q.Query{
-		Keywords: map[string]interface{}{},
-	}
-	if secCtx.IsAuthenticated() {
-		switch secCtx.(type) {
-		case *local.SecurityContext:
-			currentUser := secCtx.(*local.SecurityContext).User()
-			query.Keywords["member"] = &project.MemberQuery{
-				UserID:     currentUser.UserID,
-				GroupIDs:   currentUser.GroupIDs,
-				WithPublic: true,
-			}
-		case *robot.SecurityContext:
-			// for the system level robot that covers all the project, see it as the system admin.
-			var coverAll bool
-			var names []string
-			r := secCtx.(*robot.SecurityContext).User()
-			for _, p := range r.Permissions {
-				if p.Scope == robotCtr.SCOPEALLPROJECT {
-					coverAll = true
-					break
-				}
-				names = append(names, p.Namespace)
-			}
-			if !coverAll {
-				namesQuery := &pkgModels.NamesQuery{
-					Names:      names,
-					WithPublic: true,
-				}
-				query.Keywords["names"] = namesQuery
-			}
-		default:
-			query.Keywords["public"] = true
-		}
-	} else {
-		query.Keywords["public"] = true
-	}
-
-	projects, err := r.proCtl.List(ctx, query)
-	if err != nil {
-		return nil, err
-	}
-	var ids []int64
-	for _, project := range projects {
-		ids = append(ids, project.ProjectID)
-	}
-	return ids, nil
-}
-
 func (r *repositoryAPI) ListRepositories(ctx context.Context, params operation.ListRepositoriesParams) middleware.Responder {
 	if err := r.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionList, rbac.ResourceRepository); err != nil {
 		return r.SendError(ctx, err)
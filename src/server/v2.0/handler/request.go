@@ -43,8 +43,6 @@ import (
 type requestsAPI struct {
 	BaseAPI
 	requestCtl request.Controller
-	projectCtl project.Controller
-	quotaCtl   quota.Controller
 	userCtl    user.Controller
 	getAuth    func(ctx context.Context) (string, error) // For testing
 }
@@ -52,8 +50,6 @@ type requestsAPI struct {
 func newRequestsAPI() *requestsAPI {
 	return &requestsAPI{
 		requestCtl: request.Ctl,
-		projectCtl: project.Ctl,
-		quotaCtl:   quota.Ctl,
 		userCtl:    user.Ctl,
 		getAuth:    config.AuthMode,
 	}
@@ -95,13 +91,35 @@ func (a *requestsAPI) CreateRequest(ctx context.Context, params operation.Create
 		Name:         req.Name,
 		OwnerID:      ownerID,
 		OwnerName:    ownerName,
-		StorageQuota: *req.StorageQuota,
+		StorageQuota: lib.Int64Value(req.StorageQuota),
+		RegistryID:   lib.Int64Value(req.RegistryID),
+	}
+	if req.Metadata != nil {
+		if err := lib.JSONCopy(&p.Metadata, req.Metadata); err != nil {
+			return a.SendError(ctx, err)
+		}
+	}
+	for _, m := range req.Members {
+		p.Members = append(p.Members, model.NewRequestMember(m))
 	}
 
 	requestID, err := a.requestCtl.Create(ctx, p)
 	if err != nil {
 		return a.SendError(ctx, err)
 	}
+	p.RequestID = requestID
+
+	var groupIDs []int
+	if l, ok := secCtx.(*local.SecurityContext); ok {
+		groupIDs = l.User().GroupIDs
+	}
+	// the request is left to the administrators when failed to approve it automatically
+	rule, err := a.requestCtl.AutoApprove(ctx, p, groupIDs)
+	if err != nil {
+		log.Errorf("failed to approve the request %s automatically: %v", p.Name, err)
+	} else if rule != "" {
+		log.Debugf("the request %s is approved automatically by rule %s", p.Name, rule)
+	}
 
 	var location string
 	if lib.BoolValue(params.XResourceNameInLocation) {
@@ -136,7 +154,21 @@ func (a *requestsAPI) DeleteRequest(ctx context.Context, params operation.Delete
 }
 
 func (a *requestsAPI) GetRequest(ctx context.Context, params operation.GetRequestParams) middleware.Responder {
-	panic("implement me")
+	if err := a.RequireAuthenticated(ctx); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	requestNameOrID := parseRequestNameOrID(params.RequestNameOrID, params.XIsResourceName)
+	req, err := a.getRequest(ctx, requestNameOrID, request.WithOwner())
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	if err := a.requireOwnerOrSysAdmin(ctx, req, rbac.ActionRead); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewGetRequestOK().WithPayload(model.NewRequest(req).ToSwagger())
 }
 
 func (a *requestsAPI) ListRequests(ctx context.Context, params operation.ListRequestsParams) middleware.Responder {
@@ -234,35 +266,11 @@ func (a *requestsAPI) ApproveRequest(ctx context.Context, params operation.Appro
 		return a.SendError(ctx, err)
 	}
 
-	projectID, err := a.projectCtl.Create(ctx, &project.Project{
-		Name:    req.Name,
-		OwnerID: req.OwnerID,
-	})
-	if err != nil {
-		return a.SendError(ctx, err)
-	}
-
-	if req.StorageQuota != 0 {
-		referenceID := quota.ReferenceID(projectID)
-		hardLimits := types.ResourceList{types.ResourceStorage: req.StorageQuota}
-		if _, err := a.quotaCtl.Create(ctx, quota.ProjectReference, referenceID, hardLimits); err != nil {
-			return a.SendError(ctx, fmt.Errorf("failed to create quota for project: %v", err))
-		}
-	} else if config.QuotaPerProjectEnable(ctx) {
-		// the security context is not sys admin, set the StorageLimit the global StoragePerProject
-		setting, err := config.QuotaSetting(ctx)
-		if err != nil {
-			log.Errorf("failed to get quota setting: %v", err)
-			return a.SendError(ctx, fmt.Errorf("failed to get quota setting: %v", err))
-		}
-		referenceID := quota.ReferenceID(projectID)
-		hardLimits := types.ResourceList{types.ResourceStorage: setting.StoragePerProject}
-		if _, err := a.quotaCtl.Create(ctx, quota.ProjectReference, referenceID, hardLimits); err != nil {
-			return a.SendError(ctx, fmt.Errorf("failed to create quota for project: %v", err))
-		}
+	if params.Decision != nil {
+		req.Comment = params.Decision.Comment
 	}
 
-	if err := a.requestCtl.Approve(ctx, req); err != nil {
+	if _, err := a.requestCtl.Approve(ctx, req); err != nil {
 		return a.SendError(ctx, err)
 	}
 	return operation.NewApproveRequestOK()
@@ -290,12 +298,80 @@ func (a *requestsAPI) RejectRequest(ctx context.Context, params operation.Reject
 		return a.SendError(ctx, err)
 	}
 
+	if params.Decision != nil {
+		req.Comment = params.Decision.Comment
+	}
+
 	if err := a.requestCtl.Reject(ctx, req); err != nil {
 		return a.SendError(ctx, err)
 	}
 	return operation.NewRejectRequestOK()
 }
 
+func (a *requestsAPI) CancelRequest(ctx context.Context, params operation.CancelRequestParams) middleware.Responder {
+	if err := a.RequireAuthenticated(ctx); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	requestNameOrID := parseRequestNameOrID(params.RequestNameOrID, params.XIsResourceName)
+	req, err := a.getRequest(ctx, requestNameOrID, request.WithOwner())
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	// only the requester can withdraw the request
+	secCtx, _ := security.FromContext(ctx)
+	if secCtx.GetUsername() != req.OwnerName {
+		return a.SendError(ctx, errors.ForbiddenError(nil).WithMessage("only the requester can cancel the request"))
+	}
+
+	if err := a.requestCtl.Cancel(ctx, req); err != nil {
+		return a.SendError(ctx, err)
+	}
+	return operation.NewCancelRequestOK()
+}
+
+func (a *requestsAPI) ListRequestHistories(ctx context.Context, params operation.ListRequestHistoriesParams) middleware.Responder {
+	if err := a.RequireAuthenticated(ctx); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	requestNameOrID := parseRequestNameOrID(params.RequestNameOrID, params.XIsResourceName)
+	req, err := a.getRequest(ctx, requestNameOrID, request.WithOwner())
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	if err := a.requireOwnerOrSysAdmin(ctx, req, rbac.ActionList); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	query, err := a.BuildQuery(ctx, nil, nil, params.Page, params.PageSize)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	total, err := a.requestCtl.CountHistories(ctx, req.RequestID, query)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	histories, err := a.requestCtl.ListHistories(ctx, req.RequestID, query)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	payload := []*models.RequestHistory{}
+	for _, h := range histories {
+		payload = append(payload, model.NewRequestHistory(h).ToSwagger())
+	}
+
+	return operation.NewListRequestHistoriesOK().
+		WithXTotalCount(total).
+		WithLink(a.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(payload)
+}
+
 func (a *requestsAPI) isSysAdmin(ctx context.Context, action rbac.Action) bool {
 	if err := a.RequireSystemAccess(ctx, action, rbac.ResourceRequest); err != nil {
 		return false
@@ -303,6 +379,15 @@ func (a *requestsAPI) isSysAdmin(ctx context.Context, action rbac.Action) bool {
 	return true
 }
 
+// requireOwnerOrSysAdmin returns nil when the current user is the requester of the request or the system admin
+func (a *requestsAPI) requireOwnerOrSysAdmin(ctx context.Context, req *request.Request, action rbac.Action) error {
+	secCtx, _ := security.FromContext(ctx)
+	if secCtx.GetUsername() == req.OwnerName || a.isSysAdmin(ctx, action) {
+		return nil
+	}
+	return errors.ForbiddenError(nil).WithMessage("only the requester or system admin can access the request")
+}
+
 func (a *requestsAPI) validateRequestReq(ctx context.Context, req *models.Request) error {
 	if req.StorageQuota != nil {
 		hardLimits := types.ResourceList{types.ResourceStorage: *req.StorageQuota}
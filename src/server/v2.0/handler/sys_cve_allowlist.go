@@ -44,7 +44,19 @@ func (s systemCVEAllowListAPI) PutSystemCVEAllowlist(ctx context.Context, params
 	l := models.CVEAllowlist{}
 	l.ExpiresAt = params.Allowlist.ExpiresAt
 	for _, it := range params.Allowlist.Items {
-		l.Items = append(l.Items, models.CVEAllowlistItem{CVEID: it.CVEID})
+		l.Items = append(l.Items, models.CVEAllowlistItem{
+			CVEID:         it.CVEID,
+			ExpiresAt:     it.ExpiresAt,
+			Justification: it.Justification,
+			Repository:    it.Repository,
+		})
+	}
+	stored, err := s.mgr.GetSys(ctx)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	if err := prepareCVEAllowlist(ctx, &l, stored); err != nil {
+		return s.SendError(ctx, err)
 	}
 	if err := s.mgr.SetSys(ctx, l); err != nil {
 		return s.SendError(ctx, err)
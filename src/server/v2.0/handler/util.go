@@ -17,15 +17,24 @@ package handler
 import (
 	"context"
 	"fmt"
-	"github.com/goharbor/harbor/src/controller/project"
 	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
 
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/common/security/local"
+	"github.com/goharbor/harbor/src/common/security/robot"
+	"github.com/goharbor/harbor/src/controller/project"
+	robotCtr "github.com/goharbor/harbor/src/controller/robot"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/allowlist"
+	allowlistModels "github.com/goharbor/harbor/src/pkg/allowlist/models"
+	pkgModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/scan/report"
 	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
 )
 
@@ -44,6 +53,101 @@ const (
 	ScheduleNone = "None"
 )
 
+// listAuthorizedProjectIDs returns the IDs of the projects which the current user can access,
+// including the public projects
+func listAuthorizedProjectIDs(ctx context.Context, proCtl project.Controller) ([]int64, error) {
+	secCtx, ok := security.FromContext(ctx)
+	if !ok {
+		return nil, errors.UnauthorizedError(errors.New("security context not found"))
+	}
+	query := &q.Query{
+		Keywords: map[string]interface{}{},
+	}
+	if secCtx.IsAuthenticated() {
+		switch secCtx.(type) {
+		case *local.SecurityContext:
+			currentUser := secCtx.(*local.SecurityContext).User()
+			query.Keywords["member"] = &project.MemberQuery{
+				UserID:     currentUser.UserID,
+				GroupIDs:   currentUser.GroupIDs,
+				WithPublic: true,
+			}
+		case *robot.SecurityContext:
+			// for the system level robot that covers all the project, see it as the system admin.
+			var coverAll bool
+			var names []string
+			r := secCtx.(*robot.SecurityContext).User()
+			for _, p := range r.Permissions {
+				if p.Scope == robotCtr.SCOPEALLPROJECT {
+					coverAll = true
+					break
+				}
+				names = append(names, p.Namespace)
+			}
+			if !coverAll {
+				namesQuery := &pkgModels.NamesQuery{
+					Names:      names,
+					WithPublic: true,
+				}
+				query.Keywords["names"] = namesQuery
+			}
+		default:
+			query.Keywords["public"] = true
+		}
+	} else {
+		query.Keywords["public"] = true
+	}
+
+	projects, err := proCtl.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int64
+	for _, project := range projects {
+		ids = append(ids, project.ProjectID)
+	}
+	return ids, nil
+}
+
+// prepareCVEAllowlist validates the CVE allowlist in the request and sets the authors of the items on the server side,
+// the authors in the request are ignored: the items unchanged from the stored allowlist keep their authors,
+// and the others are authored by the current user
+func prepareCVEAllowlist(ctx context.Context, l *allowlistModels.CVEAllowlist, stored *allowlistModels.CVEAllowlist) error {
+	if err := allowlist.Validate(*l); err != nil {
+		return errors.BadRequestError(nil).WithMessage(err.Error())
+	}
+
+	var username string
+	if secCtx, ok := security.FromContext(ctx); ok {
+		username = secCtx.GetUsername()
+	}
+	for i := range l.Items {
+		item := &l.Items[i]
+		item.Author = username
+		if stored == nil {
+			continue
+		}
+		for _, s := range stored.Items {
+			if sameCVEAllowlistItem(s, *item) {
+				item.Author = s.Author
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// sameCVEAllowlistItem checks whether the items are the same regardless of the authors
+func sameCVEAllowlistItem(a, b allowlistModels.CVEAllowlistItem) bool {
+	if a.CVEID != b.CVEID || a.Repository != b.Repository || a.Justification != b.Justification {
+		return false
+	}
+	if a.ExpiresAt == nil || b.ExpiresAt == nil {
+		return a.ExpiresAt == nil && b.ExpiresAt == nil
+	}
+	return *a.ExpiresAt == *b.ExpiresAt
+}
+
 func parseScanReportMimeTypes(header *string) []string {
 	var mimeTypes []string
 
@@ -64,6 +168,43 @@ func parseScanReportMimeTypes(header *string) []string {
 	return mimeTypes
 }
 
+func parseSBOMMimeTypes(header *string) []string {
+	var mimeTypes []string
+
+	if header != nil {
+		for _, mimeType := range strings.Split(*header, ",") {
+			mimeType = strings.TrimSpace(mimeType)
+			if report.IsSBOMMimeType(mimeType) {
+				mimeTypes = append(mimeTypes, mimeType)
+			}
+		}
+	}
+
+	if len(mimeTypes) == 0 {
+		mimeTypes = append(mimeTypes, report.SBOMMimeTypes...)
+	}
+
+	return mimeTypes
+}
+
+// sbomFileName returns the file name of the downloaded SBOM document, e.g. library_photon_1a2b3c4d5e6f.spdx.json
+func sbomFileName(repositoryName, digest, mimeType string) string {
+	name := strings.ReplaceAll(repositoryName, "/", "_")
+	if i := strings.Index(digest, ":"); i >= 0 {
+		digest = digest[i+1:]
+	}
+	if len(digest) > 12 {
+		digest = digest[:12]
+	}
+
+	ext := "spdx.json"
+	if mimeType == v1.MimeTypeCycloneDXReport {
+		ext = "cdx.json"
+	}
+
+	return fmt.Sprintf("%s_%s.%s", name, digest, ext)
+}
+
 func unescapePathParams(params interface{}, fieldNames ...string) error {
 	val := reflect.ValueOf(params)
 	if val.Kind() != reflect.Ptr {
@@ -15,7 +15,15 @@
 package handler
 
 import (
+	"context"
+	"reflect"
 	"testing"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/common/security/local"
+	allowlistModels "github.com/goharbor/harbor/src/pkg/allowlist/models"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
 )
 
 func Test_unescapePathParams(t *testing.T) {
@@ -58,3 +66,65 @@ func Test_unescapePathParams(t *testing.T) {
 		}
 	})
 }
+
+func Test_parseSBOMMimeTypes(t *testing.T) {
+	cyclonedx := v1.MimeTypeCycloneDXReport
+	unknown := "application/json"
+
+	tests := []struct {
+		name   string
+		header *string
+		want   []string
+	}{
+		{"nil header", nil, []string{v1.MimeTypeSPDXReport, v1.MimeTypeCycloneDXReport}},
+		{"unknown mime type", &unknown, []string{v1.MimeTypeSPDXReport, v1.MimeTypeCycloneDXReport}},
+		{"cyclonedx", &cyclonedx, []string{v1.MimeTypeCycloneDXReport}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseSBOMMimeTypes(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSBOMMimeTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sbomFileName(t *testing.T) {
+	digest := "sha256:1a2b3c4d5e6f7a8b9c0d"
+	if got := sbomFileName("library/photon", digest, v1.MimeTypeSPDXReport); got != "library_photon_1a2b3c4d5e6f.spdx.json" {
+		t.Errorf("sbomFileName() = %v", got)
+	}
+	if got := sbomFileName("photon", digest, v1.MimeTypeCycloneDXReport); got != "photon_1a2b3c4d5e6f.cdx.json" {
+		t.Errorf("sbomFileName() = %v", got)
+	}
+}
+
+func Test_prepareCVEAllowlist(t *testing.T) {
+	ctx := security.NewContext(context.TODO(), local.NewSecurityContext(&models.User{Username: "dev"}))
+	expiresAt := int64(1893456000)
+	stored := &allowlistModels.CVEAllowlist{
+		Items: []allowlistModels.CVEAllowlistItem{
+			{CVEID: "CVE-2021-0001", Author: "admin"},
+			{CVEID: "CVE-2021-0002", Author: "admin", ExpiresAt: &expiresAt},
+		},
+	}
+	changedExpiresAt := expiresAt + 1
+	l := &allowlistModels.CVEAllowlist{
+		Items: []allowlistModels.CVEAllowlistItem{
+			// unchanged, the author in the request is ignored
+			{CVEID: "CVE-2021-0001", Author: "someone"},
+			// changed
+			{CVEID: "CVE-2021-0002", Author: "admin", ExpiresAt: &changedExpiresAt},
+			// new
+			{CVEID: "CVE-2021-0003", Author: "admin"},
+		},
+	}
+	if err := prepareCVEAllowlist(ctx, l, stored); err != nil {
+		t.Fatalf("prepareCVEAllowlist() error = %v", err)
+	}
+	for i, want := range []string{"admin", "dev", "dev"} {
+		if got := l.Items[i].Author; got != want {
+			t.Errorf("prepareCVEAllowlist() author of %s = %v, want %v", l.Items[i].CVEID, got, want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/repository"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/vex"
+	"github.com/goharbor/harbor/src/pkg/vex/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/vex"
+)
+
+func newVexAPI() *vexAPI {
+	return &vexAPI{
+		artCtl:  artifact.Ctl,
+		repoCtl: repository.Ctl,
+		vexMgr:  vex.Mgr,
+	}
+}
+
+type vexAPI struct {
+	BaseAPI
+	artCtl  artifact.Controller
+	repoCtl repository.Controller
+	vexMgr  vex.Manager
+}
+
+func (v *vexAPI) Prepare(ctx context.Context, operation string, params interface{}) middleware.Responder {
+	if err := unescapePathParams(params, "RepositoryName"); err != nil {
+		v.SendError(ctx, err)
+	}
+
+	return nil
+}
+
+func (v *vexAPI) UploadVexDocument(ctx context.Context, params operation.UploadVexDocumentParams) middleware.Responder {
+	// the statements suppress the vulnerabilities like the CVE allowlist, so require the same permission
+	if err := v.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionUpdate, rbac.ResourceConfiguration); err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	repositoryName := fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName)
+	repo, err := v.repoCtl.GetByName(ctx, repositoryName)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	content, err := json.Marshal(params.Document)
+	if err != nil {
+		return v.SendError(ctx, errors.BadRequestError(err))
+	}
+
+	doc := &model.Document{
+		ProjectID:      repo.ProjectID,
+		RepositoryID:   repo.RepositoryID,
+		RepositoryName: repo.Name,
+		Content:        string(content),
+	}
+	if secCtx, ok := security.FromContext(ctx); ok {
+		doc.Creator = secCtx.GetUsername()
+	}
+	// attach the document to the artifact, the tag is resolved to the digest
+	// as the statements are about the content rather than the tag
+	if params.Reference != nil && len(*params.Reference) > 0 {
+		art, err := v.artCtl.GetByReference(ctx, repositoryName, *params.Reference, nil)
+		if err != nil {
+			return v.SendError(ctx, err)
+		}
+		doc.Digest = art.Digest
+	}
+
+	id, err := v.vexMgr.Create(ctx, doc)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	return operation.NewUploadVexDocumentCreated().WithLocation(location)
+}
+
+func (v *vexAPI) ListVexDocuments(ctx context.Context, params operation.ListVexDocumentsParams) middleware.Responder {
+	if err := v.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionRead, rbac.ResourceRepository); err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	query, err := v.BuildQuery(ctx, nil, nil, params.Page, params.PageSize)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+	query.Keywords["RepositoryName"] = fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName)
+
+	total, err := v.vexMgr.Count(ctx, query)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+	docs, err := v.vexMgr.List(ctx, query)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	var payload []*models.VexDocument
+	for _, doc := range docs {
+		payload = append(payload, toVexDocumentModel(doc))
+	}
+
+	return operation.NewListVexDocumentsOK().
+		WithXTotalCount(total).
+		WithLink(v.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(payload)
+}
+
+func (v *vexAPI) DeleteVexDocument(ctx context.Context, params operation.DeleteVexDocumentParams) middleware.Responder {
+	// the statements suppress the vulnerabilities like the CVE allowlist, so require the same permission
+	if err := v.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionUpdate, rbac.ResourceConfiguration); err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	doc, err := v.vexMgr.Get(ctx, params.VexID)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+	// make sure the document belongs to the repository which the permission is checked against
+	if doc.RepositoryName != fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName) {
+		return v.SendError(ctx, errors.NotFoundError(nil).WithMessage("VEX document %d not found", params.VexID))
+	}
+
+	if err := v.vexMgr.Delete(ctx, params.VexID); err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	return operation.NewDeleteVexDocumentOK()
+}
+
+func toVexDocumentModel(doc *model.Document) *models.VexDocument {
+	m := &models.VexDocument{
+		ID:             doc.ID,
+		RepositoryName: doc.RepositoryName,
+		Digest:         doc.Digest,
+		Format:         doc.Format,
+		Creator:        doc.Creator,
+		CreationTime:   strfmt.DateTime(doc.CreationTime),
+	}
+	for _, st := range doc.Statements {
+		m.Statements = append(m.Statements, &models.VexStatement{
+			VulnerabilityID: st.VulnerabilityID,
+			Status:          st.Status,
+			Justification:   st.Justification,
+			Products:        st.Products,
+			Subcomponents:   st.Subcomponents,
+		})
+	}
+	return m
+}
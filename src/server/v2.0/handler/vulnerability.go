@@ -0,0 +1,246 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/scan"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/vulnerability"
+)
+
+// the query keys supported to search the vulnerable artifacts
+var vulnerableArtifactQueryKeys = map[string]bool{
+	"cve_id":          true,
+	"package":         true,
+	"package_version": true,
+	"severity":        true,
+}
+
+func newVulnerabilityAPI() *vulnerabilityAPI {
+	return &vulnerabilityAPI{
+		artCtl:  artifact.Ctl,
+		proCtl:  project.Ctl,
+		scanCtl: scan.DefaultController,
+	}
+}
+
+type vulnerabilityAPI struct {
+	BaseAPI
+	artCtl  artifact.Controller
+	proCtl  project.Controller
+	scanCtl scan.Controller
+}
+
+func (v *vulnerabilityAPI) ListVulnerableArtifacts(ctx context.Context, params operation.ListVulnerableArtifactsParams) middleware.Responder {
+	query, err := v.BuildQuery(ctx, params.Q, nil, params.Page, params.PageSize)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+	var raw map[string][]string
+	if params.Q != nil {
+		raw = rawQueryValues(*params.Q)
+	}
+	for key, value := range query.Keywords {
+		if !vulnerableArtifactQueryKeys[key] {
+			return v.SendError(ctx, errors.BadRequestError(nil).WithMessage("unsupported query key %s", key))
+		}
+		// all the supported keys are strings, e.g. the package version "007" shouldn't be parsed as the integer 7,
+		// so the parsed values are replaced by the raw ones in the query string
+		switch val := value.(type) {
+		case string, *q.FuzzyMatchValue:
+		case *q.OrList:
+			val.Values = nil
+			for _, s := range raw[key] {
+				val.Values = append(val.Values, s)
+			}
+		case *q.Range, *q.AndList:
+			return v.SendError(ctx, errors.BadRequestError(nil).WithMessage("only the exact match, fuzzy match and or list are supported for the query key %s", key))
+		default:
+			// the exact value parsed as an integer or time
+			if len(raw[key]) == 0 {
+				return v.SendError(ctx, errors.BadRequestError(nil).WithMessage("invalid value of the query key %s", key))
+			}
+			query.Keywords[key] = raw[key][0]
+		}
+	}
+	if err := v.scopeQuery(ctx, query); err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	total, err := v.scanCtl.CountVulnerableArtifacts(ctx, query)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+	artifacts, err := v.scanCtl.ListVulnerableArtifacts(ctx, query)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	var payload []*models.VulnerableArtifact
+	for _, art := range artifacts {
+		payload = append(payload, &models.VulnerableArtifact{
+			ProjectID:      art.ProjectID,
+			RepositoryName: art.RepositoryName,
+			Digest:         art.Digest,
+			CveID:          art.CVEID,
+			Package:        art.Package,
+			Version:        art.PackageVersion,
+			PackageType:    art.PackageType,
+			FixVersion:     art.Fix,
+			Severity:       art.Severity,
+			CvssV3Score:    art.CVE3Score,
+		})
+	}
+
+	return operation.NewListVulnerableArtifactsOK().
+		WithXTotalCount(total).
+		WithLink(v.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(payload)
+}
+
+func (v *vulnerabilityAPI) GetVulnerabilityDiff(ctx context.Context, params operation.GetVulnerabilityDiffParams) middleware.Responder {
+	base, err := v.getArtifact(ctx, params.Base)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+	target, err := v.getArtifact(ctx, params.Target)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	diff, err := v.scanCtl.DiffReports(ctx, base, target)
+	if err != nil {
+		return v.SendError(ctx, err)
+	}
+
+	payload := &models.VulnerabilityDiff{
+		Base:            params.Base,
+		Target:          params.Target,
+		Added:           []*models.VulnerabilityDiffItem{},
+		Removed:         []*models.VulnerabilityDiffItem{},
+		SeverityChanged: []*models.VulnerabilityDiffItem{},
+	}
+	for _, item := range diff.Added {
+		payload.Added = append(payload.Added, toVulnerabilityDiffItem(item))
+	}
+	for _, item := range diff.Removed {
+		payload.Removed = append(payload.Removed, toVulnerabilityDiffItem(item))
+	}
+	for _, change := range diff.SeverityChanged {
+		item := toVulnerabilityDiffItem(change.VulnerabilityItem)
+		item.PreviousSeverity = change.PreviousSeverity.String()
+		payload.SeverityChanged = append(payload.SeverityChanged, item)
+	}
+
+	return operation.NewGetVulnerabilityDiffOK().WithPayload(payload)
+}
+
+// getArtifact returns the artifact specified by the reference in the format of "repository:tag" or "repository@digest"
+// when the scan reports of the artifact can be read by the current user
+func (v *vulnerabilityAPI) getArtifact(ctx context.Context, s string) (*artifact.Artifact, error) {
+	repository, reference, err := parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(reference) == 0 {
+		return nil, errors.BadRequestError(nil).WithMessage("no tag or digest specified in %s", s)
+	}
+
+	projectName, _ := utils.ParseRepository(repository)
+	if err := v.RequireProjectAccess(ctx, projectName, rbac.ActionRead, rbac.ResourceArtifactAddition); err != nil {
+		return nil, err
+	}
+
+	return v.artCtl.GetByReference(ctx, repository, reference, nil)
+}
+
+func toVulnerabilityDiffItem(item *vuln.VulnerabilityItem) *models.VulnerabilityDiffItem {
+	return &models.VulnerabilityDiffItem{
+		ID:          item.ID,
+		Package:     item.Package,
+		Version:     item.Version,
+		FixVersion:  item.FixVersion,
+		Severity:    item.Severity.String(),
+		Description: item.Description,
+		Links:       item.Links,
+		Suppressed:  item.Suppressed,
+	}
+}
+
+// rawQueryValues returns the raw values of the keys in the query string without parsing them as
+// integers or times, the values of the or list "k={v1 v2}" are split
+func rawQueryValues(query string) map[string][]string {
+	if unescaped, err := url.QueryUnescape(query); err == nil {
+		query = unescaped
+	}
+	values := map[string][]string{}
+	for _, param := range strings.Split(query, ",") {
+		strs := strings.SplitN(param, "=", 2)
+		if len(strs) != 2 {
+			continue
+		}
+		value := strs[1]
+		if len(value) < 2 || value[0] != '{' || value[len(value)-1] != '}' {
+			values[strs[0]] = []string{value}
+			continue
+		}
+		for _, v := range strings.Split(value[1:len(value)-1], " ") {
+			v = strings.Trim(strings.TrimSpace(v), `"'`)
+			if len(v) > 0 {
+				values[strs[0]] = append(values[strs[0]], v)
+			}
+		}
+	}
+	return values
+}
+
+// scopeQuery limits the query to the projects whose artifact additions can be read by the current user
+func (v *vulnerabilityAPI) scopeQuery(ctx context.Context, query *q.Query) error {
+	secCtx, ok := security.FromContext(ctx)
+	if !ok {
+		return errors.UnauthorizedError(errors.New("security context not found"))
+	}
+	if secCtx.IsSysAdmin() || secCtx.IsSolutionUser() {
+		return nil
+	}
+
+	projectIDs, err := listAuthorizedProjectIDs(ctx, v.proCtl)
+	if err != nil {
+		return err
+	}
+
+	ol := &q.OrList{}
+	for _, projectID := range projectIDs {
+		if v.HasProjectPermission(ctx, projectID, rbac.ActionRead, rbac.ResourceArtifactAddition) {
+			ol.Values = append(ol.Values, projectID)
+		}
+	}
+	// no project will be selected when the OrList is empty
+	query.Keywords["project_id"] = ol
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	daoscan "github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	scantesting "github.com/goharbor/harbor/src/testing/controller/scan"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type VulnerabilityTestSuite struct {
+	htesting.Suite
+
+	scanCtl *scantesting.Controller
+}
+
+func (suite *VulnerabilityTestSuite) SetupSuite() {
+	suite.scanCtl = &scantesting.Controller{}
+
+	suite.Config = &restapi.Config{
+		VulnerabilityAPI: &vulnerabilityAPI{
+			scanCtl: suite.scanCtl,
+		},
+	}
+
+	suite.Suite.SetupSuite()
+}
+
+func (suite *VulnerabilityTestSuite) TestListVulnerableArtifacts() {
+	suite.Security.On("IsAuthenticated").Return(true)
+	suite.Security.On("IsSysAdmin").Return(true)
+
+	// the package version "007" is searched as the raw string rather than the integer 7
+	matchVersion := mock.MatchedBy(func(query *q.Query) bool {
+		return query.Keywords["package_version"] == "007"
+	})
+	suite.scanCtl.On("CountVulnerableArtifacts", mock.Anything, matchVersion).Return(int64(1), nil).Once()
+	suite.scanCtl.On("ListVulnerableArtifacts", mock.Anything, matchVersion).Return([]*daoscan.VulnerableArtifact{
+		{
+			RepositoryName: "library/hello-world",
+			Package:        "openssl",
+			PackageVersion: "007",
+		},
+	}, nil).Once()
+
+	var artifacts []*models.VulnerableArtifact
+	res, err := suite.GetJSON("/vulnerabilities/artifacts?q=package_version=007", &artifacts)
+	suite.NoError(err)
+	suite.Equal(200, res.StatusCode)
+	suite.Require().Len(artifacts, 1)
+	suite.Equal("007", artifacts[0].Version)
+	suite.scanCtl.AssertExpectations(suite.T())
+
+	// the range isn't supported
+	res, err = suite.Get("/vulnerabilities/artifacts?q=package_version=[1~2]")
+	suite.NoError(err)
+	suite.Equal(400, res.StatusCode)
+}
+
+func TestVulnerabilityTestSuite(t *testing.T) {
+	suite.Run(t, &VulnerabilityTestSuite{})
+}
+
+func TestRawQueryValues(t *testing.T) {
+	values := rawQueryValues("package=openssl,package_version=007,severity={critical 'High'}")
+	assert.Equal(t, []string{"openssl"}, values["package"])
+	assert.Equal(t, []string{"007"}, values["package_version"])
+	assert.Equal(t, []string{"critical", "High"}, values["severity"])
+
+	values = rawQueryValues("package_version%3D%7B1.0%20010%7D")
+	assert.Equal(t, []string{"1.0", "010"}, values["package_version"])
+}
@@ -29,3 +29,4 @@ package controller
 //go:generate mockery --case snake --dir ../../controller/user --name Controller --output ./user --outpkg user
 //go:generate mockery --case snake --dir ../../controller/repository --name Controller --output ./repository --outpkg repository
 //go:generate mockery --case snake --dir ../../controller/request --name Controller --output ./request --outpkg request
+//go:generate mockery --case snake --dir ../../controller/member --name Controller --output ./member --outpkg member
@@ -55,6 +55,29 @@ func (_m *Controller) DeletePolicy(ctx context.Context, id int64) error {
 	return r0
 }
 
+// DryRun provides a mock function with given fields: ctx, policy, resource
+func (_m *Controller) DryRun(ctx context.Context, policy *model.Policy, resource *regmodel.Resource) (*model.Plan, error) {
+	ret := _m.Called(ctx, policy, resource)
+
+	var r0 *model.Plan
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Policy, *regmodel.Resource) *model.Plan); ok {
+		r0 = rf(ctx, policy, resource)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Plan)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Policy, *regmodel.Resource) error); ok {
+		r1 = rf(ctx, policy, resource)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecutionCount provides a mock function with given fields: ctx, query
 func (_m *Controller) ExecutionCount(ctx context.Context, query *q.Query) (int64, error) {
 	ret := _m.Called(ctx, query)
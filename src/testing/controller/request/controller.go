@@ -19,7 +19,49 @@ type Controller struct {
 }
 
 // Approve provides a mock function with given fields: ctx, project
-func (_m *Controller) Approve(ctx context.Context, project *models.Request) error {
+func (_m *Controller) Approve(ctx context.Context, project *models.Request) (int64, error) {
+	ret := _m.Called(ctx, project)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Request) int64); ok {
+		r0 = rf(ctx, project)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Request) error); ok {
+		r1 = rf(ctx, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AutoApprove provides a mock function with given fields: ctx, project, groupIDs
+func (_m *Controller) AutoApprove(ctx context.Context, project *models.Request, groupIDs []int) (string, error) {
+	ret := _m.Called(ctx, project, groupIDs)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Request, []int) string); ok {
+		r0 = rf(ctx, project, groupIDs)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Request, []int) error); ok {
+		r1 = rf(ctx, project, groupIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Cancel provides a mock function with given fields: ctx, project
+func (_m *Controller) Cancel(ctx context.Context, project *models.Request) error {
 	ret := _m.Called(ctx, project)
 
 	var r0 error
@@ -53,6 +95,27 @@ func (_m *Controller) Count(ctx context.Context, query *q.Query) (int64, error)
 	return r0, r1
 }
 
+// CountHistories provides a mock function with given fields: ctx, requestID, query
+func (_m *Controller) CountHistories(ctx context.Context, requestID int64, query *q.Query) (int64, error) {
+	ret := _m.Called(ctx, requestID, query)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *q.Query) int64); ok {
+		r0 = rf(ctx, requestID, query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *q.Query) error); ok {
+		r1 = rf(ctx, requestID, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, project
 func (_m *Controller) Create(ctx context.Context, project *models.Request) (int64, error) {
 	ret := _m.Called(ctx, project)
@@ -109,6 +172,27 @@ func (_m *Controller) Exists(ctx context.Context, requestIDOrName interface{}) (
 	return r0, r1
 }
 
+// Expire provides a mock function with given fields: ctx
+func (_m *Controller) Expire(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: ctx, requestIDOrName, options
 func (_m *Controller) Get(ctx context.Context, requestIDOrName interface{}, options ...request.Option) (*models.Request, error) {
 	_va := make([]interface{}, len(options))
@@ -199,6 +283,29 @@ func (_m *Controller) List(ctx context.Context, query *q.Query, options ...reque
 	return r0, r1
 }
 
+// ListHistories provides a mock function with given fields: ctx, requestID, query
+func (_m *Controller) ListHistories(ctx context.Context, requestID int64, query *q.Query) ([]*models.History, error) {
+	ret := _m.Called(ctx, requestID, query)
+
+	var r0 []*models.History
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *q.Query) []*models.History); ok {
+		r0 = rf(ctx, requestID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.History)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *q.Query) error); ok {
+		r1 = rf(ctx, requestID, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Reject provides a mock function with given fields: ctx, project
 func (_m *Controller) Reject(ctx context.Context, project *models.Request) error {
 	ret := _m.Called(ctx, project)
@@ -11,9 +11,15 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	q "github.com/goharbor/harbor/src/lib/q"
+
 	models "github.com/goharbor/harbor/src/pkg/allowlist/models"
 
+	policy "github.com/goharbor/harbor/src/pkg/scan/policy"
+
 	scan "github.com/goharbor/harbor/src/controller/scan"
+
+	vuln "github.com/goharbor/harbor/src/pkg/scan/vuln"
 )
 
 // Controller is an autogenerated mock type for the Controller type
@@ -21,6 +27,27 @@ type Controller struct {
 	mock.Mock
 }
 
+// CountVulnerableArtifacts provides a mock function with given fields: ctx, query
+func (_m *Controller) CountVulnerableArtifacts(ctx context.Context, query *q.Query) (int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) int64); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteReports provides a mock function with given fields: ctx, digests
 func (_m *Controller) DeleteReports(ctx context.Context, digests ...string) error {
 	_va := make([]interface{}, len(digests))
@@ -42,6 +69,43 @@ func (_m *Controller) DeleteReports(ctx context.Context, digests ...string) erro
 	return r0
 }
 
+// DeleteRescanPolicy provides a mock function with given fields: ctx, projectID
+func (_m *Controller) DeleteRescanPolicy(ctx context.Context, projectID int64) error {
+	ret := _m.Called(ctx, projectID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DiffReports provides a mock function with given fields: ctx, base, target
+func (_m *Controller) DiffReports(ctx context.Context, base *artifact.Artifact, target *artifact.Artifact) (*vuln.ReportDiff, error) {
+	ret := _m.Called(ctx, base, target)
+
+	var r0 *vuln.ReportDiff
+	if rf, ok := ret.Get(0).(func(context.Context, *artifact.Artifact, *artifact.Artifact) *vuln.ReportDiff); ok {
+		r0 = rf(ctx, base, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*vuln.ReportDiff)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *artifact.Artifact, *artifact.Artifact) error); ok {
+		r1 = rf(ctx, base, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetReport provides a mock function with given fields: ctx, _a1, mimeTypes
 func (_m *Controller) GetReport(ctx context.Context, _a1 *artifact.Artifact, mimeTypes []string) ([]*daoscan.Report, error) {
 	ret := _m.Called(ctx, _a1, mimeTypes)
@@ -65,6 +129,29 @@ func (_m *Controller) GetReport(ctx context.Context, _a1 *artifact.Artifact, mim
 	return r0, r1
 }
 
+// GetRescanPolicy provides a mock function with given fields: ctx, projectID
+func (_m *Controller) GetRescanPolicy(ctx context.Context, projectID int64) (*policy.Policy, error) {
+	ret := _m.Called(ctx, projectID)
+
+	var r0 *policy.Policy
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *policy.Policy); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*policy.Policy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetScanLog provides a mock function with given fields: ctx, uuid
 func (_m *Controller) GetScanLog(ctx context.Context, uuid string) ([]byte, error) {
 	ret := _m.Called(ctx, uuid)
@@ -134,6 +221,50 @@ func (_m *Controller) GetVulnerable(ctx context.Context, _a1 *artifact.Artifact,
 	return r0, r1
 }
 
+// ListVulnerableArtifacts provides a mock function with given fields: ctx, query
+func (_m *Controller) ListVulnerableArtifacts(ctx context.Context, query *q.Query) ([]*daoscan.VulnerableArtifact, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*daoscan.VulnerableArtifact
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*daoscan.VulnerableArtifact); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*daoscan.VulnerableArtifact)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Rescan provides a mock function with given fields: ctx, projectID, trigger, async
+func (_m *Controller) Rescan(ctx context.Context, projectID int64, trigger string, async bool) (int64, error) {
+	ret := _m.Called(ctx, projectID, trigger, async)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, bool) int64); ok {
+		r0 = rf(ctx, projectID, trigger, async)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, bool) error); ok {
+		r1 = rf(ctx, projectID, trigger, async)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Scan provides a mock function with given fields: ctx, _a1, options
 func (_m *Controller) Scan(ctx context.Context, _a1 *artifact.Artifact, options ...scan.Option) error {
 	_va := make([]interface{}, len(options))
@@ -176,6 +307,20 @@ func (_m *Controller) ScanAll(ctx context.Context, trigger string, async bool) (
 	return r0, r1
 }
 
+// SetRescanPolicy provides a mock function with given fields: ctx, p
+func (_m *Controller) SetRescanPolicy(ctx context.Context, p *policy.Policy) error {
+	ret := _m.Called(ctx, p)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *policy.Policy) error); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Stop provides a mock function with given fields: ctx, _a1
 func (_m *Controller) Stop(ctx context.Context, _a1 *artifact.Artifact) error {
 	ret := _m.Called(ctx, _a1)
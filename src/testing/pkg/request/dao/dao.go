@@ -38,6 +38,27 @@ func (_m *DAO) Count(ctx context.Context, query *q.Query) (int64, error) {
 	return r0, r1
 }
 
+// CountHistories provides a mock function with given fields: ctx, query
+func (_m *DAO) CountHistories(ctx context.Context, query *q.Query) (int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) int64); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, request
 func (_m *DAO) Create(ctx context.Context, request *models.Request) (int64, error) {
 	ret := _m.Called(ctx, request)
@@ -59,6 +80,27 @@ func (_m *DAO) Create(ctx context.Context, request *models.Request) (int64, erro
 	return r0, r1
 }
 
+// CreateHistory provides a mock function with given fields: ctx, history
+func (_m *DAO) CreateHistory(ctx context.Context, history *models.History) (int64, error) {
+	ret := _m.Called(ctx, history)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *models.History) int64); ok {
+		r0 = rf(ctx, history)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.History) error); ok {
+		r1 = rf(ctx, history)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (_m *DAO) Delete(ctx context.Context, id int64) error {
 	ret := _m.Called(ctx, id)
@@ -119,6 +161,29 @@ func (_m *DAO) GetByName(ctx context.Context, name string) (*models.Request, err
 	return r0, r1
 }
 
+// GetForUpdate provides a mock function with given fields: ctx, id
+func (_m *DAO) GetForUpdate(ctx context.Context, id int64) (*models.Request, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Request
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.Request); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Request)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, query
 func (_m *DAO) List(ctx context.Context, query *q.Query) ([]*models.Request, error) {
 	ret := _m.Called(ctx, query)
@@ -142,6 +207,29 @@ func (_m *DAO) List(ctx context.Context, query *q.Query) ([]*models.Request, err
 	return r0, r1
 }
 
+// ListHistories provides a mock function with given fields: ctx, query
+func (_m *DAO) ListHistories(ctx context.Context, query *q.Query) ([]*models.History, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*models.History
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*models.History); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.History)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, request, props
 func (_m *DAO) Update(ctx context.Context, request *models.Request, props ...string) error {
 	_va := make([]interface{}, len(props))
@@ -30,6 +30,20 @@ func (_m *Manager) Approve(ctx context.Context, _a1 *models.Request) error {
 	return r0
 }
 
+// Cancel provides a mock function with given fields: ctx, _a1
+func (_m *Manager) Cancel(ctx context.Context, _a1 *models.Request) error {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Request) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Count provides a mock function with given fields: ctx, query
 func (_m *Manager) Count(ctx context.Context, query *q.Query) (int64, error) {
 	ret := _m.Called(ctx, query)
@@ -51,6 +65,27 @@ func (_m *Manager) Count(ctx context.Context, query *q.Query) (int64, error) {
 	return r0, r1
 }
 
+// CountHistories provides a mock function with given fields: ctx, query
+func (_m *Manager) CountHistories(ctx context.Context, query *q.Query) (int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) int64); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, _a1
 func (_m *Manager) Create(ctx context.Context, _a1 *models.Request) (int64, error) {
 	ret := _m.Called(ctx, _a1)
@@ -72,6 +107,27 @@ func (_m *Manager) Create(ctx context.Context, _a1 *models.Request) (int64, erro
 	return r0, r1
 }
 
+// CreateHistory provides a mock function with given fields: ctx, history
+func (_m *Manager) CreateHistory(ctx context.Context, history *models.History) (int64, error) {
+	ret := _m.Called(ctx, history)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *models.History) int64); ok {
+		r0 = rf(ctx, history)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.History) error); ok {
+		r1 = rf(ctx, history)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (_m *Manager) Delete(ctx context.Context, id int64) error {
 	ret := _m.Called(ctx, id)
@@ -86,6 +142,20 @@ func (_m *Manager) Delete(ctx context.Context, id int64) error {
 	return r0
 }
 
+// Expire provides a mock function with given fields: ctx, _a1
+func (_m *Manager) Expire(ctx context.Context, _a1 *models.Request) error {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Request) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, idOrName
 func (_m *Manager) Get(ctx context.Context, idOrName interface{}) (*models.Request, error) {
 	ret := _m.Called(ctx, idOrName)
@@ -109,6 +179,29 @@ func (_m *Manager) Get(ctx context.Context, idOrName interface{}) (*models.Reque
 	return r0, r1
 }
 
+// GetForUpdate provides a mock function with given fields: ctx, id
+func (_m *Manager) GetForUpdate(ctx context.Context, id int64) (*models.Request, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Request
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.Request); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Request)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, query
 func (_m *Manager) List(ctx context.Context, query *q.Query) ([]*models.Request, error) {
 	ret := _m.Called(ctx, query)
@@ -132,6 +225,29 @@ func (_m *Manager) List(ctx context.Context, query *q.Query) ([]*models.Request,
 	return r0, r1
 }
 
+// ListHistories provides a mock function with given fields: ctx, query
+func (_m *Manager) ListHistories(ctx context.Context, query *q.Query) ([]*models.History, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*models.History
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*models.History); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.History)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Reject provides a mock function with given fields: ctx, _a1
 func (_m *Manager) Reject(ctx context.Context, _a1 *models.Request) error {
 	ret := _m.Called(ctx, _a1)
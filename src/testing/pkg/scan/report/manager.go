@@ -16,6 +16,27 @@ type Manager struct {
 	mock.Mock
 }
 
+// CountVulnerableArtifacts provides a mock function with given fields: ctx, query
+func (_m *Manager) CountVulnerableArtifacts(ctx context.Context, query *q.Query) (int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) int64); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, r
 func (_m *Manager) Create(ctx context.Context, r *scan.Report) (string, error) {
 	ret := _m.Called(ctx, r)
@@ -118,6 +139,29 @@ func (_m *Manager) List(ctx context.Context, query *q.Query) ([]*scan.Report, er
 	return r0, r1
 }
 
+// ListVulnerableArtifacts provides a mock function with given fields: ctx, query
+func (_m *Manager) ListVulnerableArtifacts(ctx context.Context, query *q.Query) ([]*scan.VulnerableArtifact, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*scan.VulnerableArtifact
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*scan.VulnerableArtifact); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*scan.VulnerableArtifact)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateReportData provides a mock function with given fields: ctx, uuid, _a2
 func (_m *Manager) UpdateReportData(ctx context.Context, uuid string, _a2 string) error {
 	ret := _m.Called(ctx, uuid, _a2)
@@ -0,0 +1,141 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smtp
+
+import (
+	"bufio"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// Message is the email received by the server
+type Message struct {
+	From string
+	To   []string
+	Data string
+}
+
+// Server is a local SMTP server used in the tests, it accepts all the emails without
+// authentication and keeps them in memory
+type Server struct {
+	listener net.Listener
+	lock     sync.Mutex
+	messages []*Message
+	// the reply code of the DATA command, set it to 4xx or 5xx to simulate the failures
+	DataReplyCode int
+}
+
+// NewServer starts a SMTP server listening on a random local port
+func NewServer() (*Server, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{listener: l, DataReplyCode: 250}
+	go s.serve()
+	return s, nil
+}
+
+// Addr returns the address which the server listens on
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Messages returns the received emails
+func (s *Server) Messages() []*Message {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]*Message{}, s.messages...)
+}
+
+// Close stops the server
+func (s *Server) Close() error {
+	return s.listener.Close()
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 localhost ESMTP")
+
+	msg := &Message{}
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			_ = tp.PrintfLine("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			msg.From = trimAddress(line[len("MAIL FROM:"):])
+			_ = tp.PrintfLine("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			msg.To = append(msg.To, trimAddress(line[len("RCPT TO:"):]))
+			_ = tp.PrintfLine("250 OK")
+		case cmd == "DATA":
+			if s.DataReplyCode != 250 {
+				_ = tp.PrintfLine("%d mailbox unavailable", s.DataReplyCode)
+				continue
+			}
+			_ = tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := readData(tp.R)
+			if err != nil {
+				return
+			}
+			msg.Data = data
+			s.lock.Lock()
+			s.messages = append(s.messages, msg)
+			s.lock.Unlock()
+			msg = &Message{}
+			_ = tp.PrintfLine("250 OK")
+		case cmd == "RSET":
+			msg = &Message{}
+			_ = tp.PrintfLine("250 OK")
+		case cmd == "NOOP":
+			_ = tp.PrintfLine("250 OK")
+		case cmd == "QUIT":
+			_ = tp.PrintfLine("221 Bye")
+			return
+		default:
+			_ = tp.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+func readData(r *bufio.Reader) (string, error) {
+	data, err := textproto.NewReader(r).ReadDotLines()
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(data, "\r\n"), nil
+}
+
+func trimAddress(s string) string {
+	return strings.Trim(strings.TrimSpace(s), "<>")
+}